@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net"
 
@@ -12,8 +13,14 @@ import (
 	"github.com/thatlq1812/service-1-user/internal/auth"
 	"github.com/thatlq1812/service-1-user/internal/config"
 	"github.com/thatlq1812/service-1-user/internal/db"
+	"github.com/thatlq1812/service-1-user/internal/emailaddr"
+	"github.com/thatlq1812/service-1-user/internal/events"
+	"github.com/thatlq1812/service-1-user/internal/notify"
+	"github.com/thatlq1812/service-1-user/internal/ratelimit"
 	"github.com/thatlq1812/service-1-user/internal/repository"
 	"github.com/thatlq1812/service-1-user/internal/server"
+	"github.com/thatlq1812/service-1-user/internal/storage"
+	"github.com/thatlq1812/service-1-user/internal/worker"
 	pb "github.com/thatlq1812/service-1-user/proto"
 )
 
@@ -44,6 +51,26 @@ func main() {
 
 	// 3. Create repository
 	userRepo := repository.NewUserPostgresRepository(pool)
+	passwordHistoryRepo := repository.NewPasswordHistoryPostgresRepository(pool)
+	auditRepo := repository.NewAuditPostgresRepository(pool)
+	loginEventRepo := repository.NewLoginEventPostgresRepository(pool)
+	deviceRepo := repository.NewDevicePostgresRepository(pool)
+	attributeSchemaRepo := repository.NewAttributeSchemaPostgresRepository(pool)
+	externalIdentityRepo := repository.NewExternalIdentityPostgresRepository(pool)
+
+	// Recompute stored email keys when the normalization rules changed since the last start,
+	// e.g. after EMAIL_CANONICALIZE_PROVIDERS was toggled
+	emails := emailaddr.Normalizer{CanonicalizeProviders: cfg.EmailCanonicalizeProviders}
+	rekeyed, err := repository.NewEmailKeyPostgresRepository(pool).Rekey(context.Background(), emails.KeyRules(), func(email string) (string, bool) {
+		address, err := emails.Parse(email)
+		return address.Key, err == nil
+	})
+	if err != nil {
+		log.Fatalf("Failed to recompute email keys: %v", err)
+	}
+	if rekeyed > 0 {
+		log.Printf("Recomputed email keys of %d users (rules %s)", rekeyed, emails.KeyRules())
+	}
 
 	tokenManager := auth.NewTokenManager(
 		cfg.JWTSecret,
@@ -51,14 +78,83 @@ func main() {
 		cfg.RefreshTokenDuration,
 		redisClient,
 	)
+	tokenManager.SetSessionPolicies(cfg.Sessions)
+
+	// Precompute the dummy password hash used for constant-work login failures
+	auth.WarmUp()
 
 	// 4. Setup gRPC server
 	grpcServer := grpc.NewServer()
 
 	// 5. Register service implementation
-	userService := server.NewUserServiceServer(userRepo, tokenManager)
+	serverOpts := []server.Option{
+		server.WithPasswordHistory(passwordHistoryRepo, cfg.PasswordHistoryDepth),
+		server.WithAuditLog(auditRepo),
+		server.WithImpersonationDuration(cfg.ImpersonationTokenDuration),
+		server.WithStepUpPolicy(cfg.StepUpPolicy),
+		server.WithDPoP(auth.NewDPoPVerifier(redisClient, cfg.DPoPProofMaxAge)),
+		server.WithTokenExchange(cfg.TokenExchangeAudiences, cfg.TokenExchangeClients, cfg.TokenExchangeMaxDuration),
+		server.WithExchangeChaining(cfg.TokenExchangeChaining),
+		server.WithSilentSignup(cfg.SilentSignup),
+		server.WithLoginEvents(loginEventRepo),
+		server.WithExternalIdentities(externalIdentityRepo),
+		server.WithTrustedProxies(cfg.TrustedProxies),
+		server.WithAccountDeletion(cfg.AccountDeletionGracePeriod),
+		server.WithAttributeSchemas(attributeSchemaRepo),
+		server.WithEmailCanonicalization(cfg.EmailCanonicalizeProviders),
+		server.WithEmailChange(cfg.EmailChangeTokenTTL),
+	}
+	pageTokenSecret := cfg.PageTokenSecret
+	if pageTokenSecret == "" {
+		pageTokenSecret = cfg.JWTSecret
+	}
+	serverOpts = append(serverOpts, server.WithPageTokens([]byte(pageTokenSecret)))
+	if cfg.AvailabilityCheckRateLimit > 0 {
+		limiter := ratelimit.NewLimiter(redisClient, "availability", cfg.AvailabilityCheckRateLimit, cfg.AvailabilityCheckRateWindow)
+		serverOpts = append(serverOpts, server.WithAvailabilityRateLimit(limiter))
+	}
+	if cfg.BreachedPasswordsDir != "" {
+		breachChecker := auth.NewHIBPFileChecker(cfg.BreachedPasswordsDir, cfg.BreachedPasswordMinCount)
+		serverOpts = append(serverOpts, server.WithBreachChecker(breachChecker, cfg.BreachedPasswordLoginCheck))
+		log.Printf("Breached password check enabled (dataset: %s)", cfg.BreachedPasswordsDir)
+	}
+	if cfg.NewDeviceDetection {
+		serverOpts = append(serverOpts, server.WithDeviceTracking(deviceRepo))
+	}
+	if cfg.NotificationSinkFile != "" {
+		serverOpts = append(serverOpts, server.WithNotifier(notify.NewFileNotifier(cfg.NotificationSinkFile)))
+		log.Printf("User notifications written to %s", cfg.NotificationSinkFile)
+	}
+
+	var eventPublisher events.Publisher
+	if cfg.UserEventsStream != "" {
+		eventPublisher = events.NewRedisStreamPublisher(redisClient, cfg.UserEventsStream, cfg.UserEventsStreamMaxLen)
+		serverOpts = append(serverOpts, server.WithEventPublisher(eventPublisher))
+	}
+
+	var avatarStore storage.BlobStore
+	if cfg.AvatarStorageDir != "" {
+		avatarStore = storage.NewLocalStore(cfg.AvatarStorageDir, cfg.AvatarBaseURL)
+		serverOpts = append(serverOpts, server.WithAvatars(avatarStore, cfg.AvatarMaxSize))
+		log.Printf("Avatars stored in %s (served from %s)", cfg.AvatarStorageDir, cfg.AvatarBaseURL)
+	}
+
+	userService := server.NewUserServiceServer(userRepo, tokenManager, serverOpts...)
 	pb.RegisterUserServiceServer(grpcServer, userService)
 
+	// Background jobs stop when the server shuts down
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+
+	if cfg.DeletedUserRetention > 0 {
+		purgeWorker := worker.NewPurgeWorker(userRepo, avatarStore, cfg.DeletedUserRetention, cfg.PurgeInterval, cfg.PurgeBatchSize)
+		go purgeWorker.Run(workerCtx)
+		log.Printf("Purging deleted users after %s", cfg.DeletedUserRetention)
+	}
+
+	deletionWorker := worker.NewDeletionWorker(userRepo, eventPublisher, avatarStore, cfg.AccountDeletionInterval, cfg.PurgeBatchSize)
+	go deletionWorker.Run(workerCtx)
+
 	// 6. Enable reflection for tools like grpcurl
 	reflection.Register(grpcServer)
 
@@ -81,6 +177,7 @@ func main() {
 	ctx := common.WaitForShutdown(cfg.ShutdownTimeout)
 
 	log.Println("Shutting down gRPC server...")
+	stopWorkers()
 	grpcServer.GracefulStop()
 
 	<-ctx.Done()
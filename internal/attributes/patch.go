@@ -0,0 +1,28 @@
+package attributes
+
+// MergePatch applies an RFC 7396 JSON merge patch to a copy of target: null values remove
+// keys, objects are merged recursively and any other value replaces the existing one.
+func MergePatch(target, patch map[string]any) map[string]any {
+	result := make(map[string]any, len(target)+len(patch))
+	for key, value := range target {
+		result[key] = value
+	}
+
+	for key, value := range patch {
+		if value == nil {
+			delete(result, key)
+			continue
+		}
+
+		patchObject, isObject := value.(map[string]any)
+		if !isObject {
+			result[key] = value
+			continue
+		}
+
+		existing, _ := result[key].(map[string]any)
+		result[key] = MergePatch(existing, patchObject)
+	}
+
+	return result
+}
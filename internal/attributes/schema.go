@@ -0,0 +1,139 @@
+package attributes
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+
+	"github.com/thatlq1812/service-1-user/internal/repository"
+)
+
+// schemaURL is the base URL the attribute schema is compiled under
+const schemaURL = "urn:user-service:attributes"
+
+// ErrInvalidSchema is returned when a schema document cannot be compiled
+var ErrInvalidSchema = errors.New("invalid attribute schema")
+
+// Schema is a compiled attribute JSON Schema
+type Schema struct {
+	compiled *jsonschema.Schema
+}
+
+// Compile parses and compiles a JSON Schema document. External references are refused,
+// so an admin-provided schema cannot make the service read files or call URLs.
+func Compile(document []byte) (*Schema, error) {
+	compiler := jsonschema.NewCompiler()
+	compiler.Draft = jsonschema.Draft2020
+	compiler.LoadURL = func(url string) (io.ReadCloser, error) {
+		return nil, fmt.Errorf("external schema reference %q is not allowed", url)
+	}
+
+	if err := compiler.AddResource(schemaURL, bytes.NewReader(document)); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSchema, err)
+	}
+	compiled, err := compiler.Compile(schemaURL)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSchema, err)
+	}
+
+	return &Schema{compiled: compiled}, nil
+}
+
+// Validate checks a complete attributes document against the schema.
+// The error lists every violation as "<location>: <message>".
+func (s *Schema) Validate(attributes map[string]any) error {
+	err := s.compiled.Validate(attributes)
+	if err == nil {
+		return nil
+	}
+
+	var validationErr *jsonschema.ValidationError
+	if !errors.As(err, &validationErr) {
+		return err
+	}
+
+	var violations []string
+	for _, unit := range validationErr.BasicOutput().Errors {
+		if unit.Error == "" || strings.HasPrefix(unit.Error, "doesn't validate with") {
+			continue
+		}
+		location := unit.InstanceLocation
+		if location == "" {
+			location = "/"
+		}
+		violations = append(violations, location+": "+unit.Error)
+	}
+	sort.Strings(violations)
+	if len(violations) == 0 {
+		return errors.New(validationErr.Message)
+	}
+	return errors.New(strings.Join(violations, "; "))
+}
+
+// Registry serves the current attribute schema, recompiling only when a new version is stored
+type Registry struct {
+	repo repository.AttributeSchemaRepository
+
+	mu       sync.Mutex
+	version  int32
+	compiled *Schema
+}
+
+// NewRegistry creates a registry reading schema versions from repo
+func NewRegistry(repo repository.AttributeSchemaRepository) *Registry {
+	return &Registry{repo: repo}
+}
+
+// Current returns the newest stored schema version and its compiled form.
+// repository.ErrAttributeSchemaNotFound is returned when no schema is defined.
+func (r *Registry) Current(ctx context.Context) (*repository.AttributeSchema, *Schema, error) {
+	stored, err := r.repo.GetCurrent(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.compiled != nil && r.version == stored.Version {
+		return stored, r.compiled, nil
+	}
+
+	compiled, err := Compile(stored.Schema)
+	if err != nil {
+		return nil, nil, err
+	}
+	r.version = stored.Version
+	r.compiled = compiled
+	return stored, compiled, nil
+}
+
+// Publish compiles and stores a new schema version, making it current.
+// ErrInvalidSchema is returned when the document is not a valid schema.
+func (r *Registry) Publish(ctx context.Context, document []byte, createdBy int32) (*repository.AttributeSchema, error) {
+	compiled, err := Compile(document)
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := r.repo.Create(ctx, document, createdBy)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	if stored.Version > r.version {
+		r.version = stored.Version
+		r.compiled = compiled
+	}
+	r.mu.Unlock()
+
+	return stored, nil
+}
@@ -0,0 +1,90 @@
+package auth
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrPasswordBreached is returned when a password appears in a known breach corpus
+var ErrPasswordBreached = errors.New("password has appeared in a known data breach")
+
+// BreachChecker reports whether a plain text password is known from a data breach
+type BreachChecker interface {
+	IsBreached(ctx context.Context, password string) (bool, error)
+}
+
+// hibpFileChecker checks passwords against a local copy of the HIBP Pwned Passwords
+// range dataset. The directory holds one file per 5-character SHA-1 prefix
+// (e.g. "21BD1" or "21BD1.txt"), each line being "<35-char suffix>:<count>".
+type hibpFileChecker struct {
+	dir      string
+	minCount int
+}
+
+// NewHIBPFileChecker creates a checker reading range files from dir.
+// Passwords seen fewer than minCount times are not reported as breached.
+func NewHIBPFileChecker(dir string, minCount int) BreachChecker {
+	if minCount < 1 {
+		minCount = 1
+	}
+	return &hibpFileChecker{dir: dir, minCount: minCount}
+}
+
+// IsBreached hashes the password with SHA-1 and looks up its suffix in the prefix partition
+func (c *hibpFileChecker) IsBreached(ctx context.Context, password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	digest := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := digest[:5], digest[5:]
+
+	file, err := c.openRange(prefix)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			// No partition for this prefix means no breached hash shares it
+			return false, nil
+		}
+		return false, fmt.Errorf("open breach range %s: %w", prefix, err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+
+		line := strings.TrimSpace(scanner.Text())
+		hashSuffix, countStr, found := strings.Cut(line, ":")
+		if !found || !strings.EqualFold(hashSuffix, suffix) {
+			continue
+		}
+
+		var count int
+		if _, err := fmt.Sscanf(countStr, "%d", &count); err != nil {
+			// Entries without a usable count still mark the hash as breached
+			return true, nil
+		}
+		return count >= c.minCount, nil
+	}
+
+	if err := scanner.Err(); err != nil {
+		return false, fmt.Errorf("read breach range %s: %w", prefix, err)
+	}
+
+	return false, nil
+}
+
+// openRange opens the partition file for a prefix, accepting both bare and ".txt" names
+func (c *hibpFileChecker) openRange(prefix string) (*os.File, error) {
+	file, err := os.Open(filepath.Join(c.dir, prefix))
+	if err == nil || !errors.Is(err, os.ErrNotExist) {
+		return file, err
+	}
+	return os.Open(filepath.Join(c.dir, prefix+".txt"))
+}
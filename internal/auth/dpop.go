@@ -0,0 +1,246 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/redis/go-redis/v9"
+)
+
+// DPoP (RFC 9449) token types reported to clients
+const (
+	TokenTypeBearer = "Bearer"
+	TokenTypeDPoP   = "DPoP"
+
+	dpopProofType = "dpop+jwt"
+)
+
+var (
+	// ErrDPoPProofRequired is returned when a sender-constrained token is presented without a proof
+	ErrDPoPProofRequired = errors.New("dpop proof required for sender-constrained token")
+
+	// ErrDPoPKeyMismatch is returned when the proof key does not match the token's cnf.jkt
+	ErrDPoPKeyMismatch = errors.New("dpop proof key does not match token binding")
+)
+
+// ConfirmationClaim is the "cnf" claim binding a token to a DPoP key thumbprint
+type ConfirmationClaim struct {
+	JKT string `json:"jkt"`
+}
+
+// dpopClaims are the claims of a DPoP proof JWT
+type dpopClaims struct {
+	HTM string `json:"htm"`
+	HTU string `json:"htu"`
+	ATH string `json:"ath,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// DPoPProof is a verified DPoP proof
+type DPoPProof struct {
+	Thumbprint string
+	JTI        string
+	IssuedAt   time.Time
+}
+
+// DPoPVerifier validates DPoP proofs and prevents their replay
+type DPoPVerifier struct {
+	redisClient *redis.Client
+	maxAge      time.Duration
+}
+
+// NewDPoPVerifier creates a verifier accepting proofs issued within maxAge of now
+func NewDPoPVerifier(redisClient *redis.Client, maxAge time.Duration) *DPoPVerifier {
+	return &DPoPVerifier{
+		redisClient: redisClient,
+		maxAge:      maxAge,
+	}
+}
+
+// Verify checks a DPoP proof for the given HTTP method and URI (or RPC name).
+// When accessToken is non-empty the proof must also carry its "ath" hash.
+func (v *DPoPVerifier) Verify(ctx context.Context, proof, method, uri, accessToken string) (*DPoPProof, error) {
+	var thumbprint string
+	token, err := jwt.ParseWithClaims(proof, &dpopClaims{}, func(token *jwt.Token) (interface{}, error) {
+		if typ, _ := token.Header["typ"].(string); !strings.EqualFold(typ, dpopProofType) {
+			return nil, errors.New("invalid dpop proof type")
+		}
+
+		jwk, ok := token.Header["jwk"].(map[string]interface{})
+		if !ok {
+			return nil, errors.New("dpop proof missing jwk header")
+		}
+
+		key, tp, err := parsePublicJWK(jwk)
+		if err != nil {
+			return nil, err
+		}
+		thumbprint = tp
+		return key, nil
+	}, jwt.WithValidMethods([]string{"ES256", "ES384", "RS256", "PS256", "EdDSA"}))
+	if err != nil {
+		return nil, fmt.Errorf("invalid dpop proof: %w", err)
+	}
+
+	claims, ok := token.Claims.(*dpopClaims)
+	if !ok || !token.Valid {
+		return nil, errors.New("invalid dpop proof")
+	}
+
+	// Bind the proof to this request
+	if !strings.EqualFold(claims.HTM, method) {
+		return nil, errors.New("dpop proof htm does not match request method")
+	}
+	if normalizeHTU(claims.HTU) != normalizeHTU(uri) {
+		return nil, errors.New("dpop proof htu does not match request target")
+	}
+	if accessToken != "" {
+		sum := sha256.Sum256([]byte(accessToken))
+		if claims.ATH != base64.RawURLEncoding.EncodeToString(sum[:]) {
+			return nil, errors.New("dpop proof ath does not match access token")
+		}
+	}
+
+	// Freshness window
+	if claims.IssuedAt == nil {
+		return nil, errors.New("dpop proof missing iat")
+	}
+	issuedAt := claims.IssuedAt.Time
+	if age := time.Since(issuedAt); age > v.maxAge || age < -v.maxAge {
+		return nil, errors.New("dpop proof is not fresh")
+	}
+
+	// Replay protection: each jti may be used once within the freshness window
+	if claims.ID == "" {
+		return nil, errors.New("dpop proof missing jti")
+	}
+	key := "dpop:jti:" + thumbprint + ":" + claims.ID
+	fresh, err := v.redisClient.SetNX(ctx, key, "used", 2*v.maxAge).Result()
+	if err != nil {
+		return nil, fmt.Errorf("redis error: %w", err)
+	}
+	if !fresh {
+		return nil, errors.New("dpop proof has already been used")
+	}
+
+	return &DPoPProof{
+		Thumbprint: thumbprint,
+		JTI:        claims.ID,
+		IssuedAt:   issuedAt,
+	}, nil
+}
+
+// VerifyBinding checks that a token bound with cnf.jkt is presented with a matching proof.
+// Unbound (bearer) tokens pass without a proof.
+func (v *DPoPVerifier) VerifyBinding(ctx context.Context, claims *Claims, proof, method, uri, accessToken string) error {
+	if claims.Cnf == nil || claims.Cnf.JKT == "" {
+		return nil
+	}
+	if proof == "" {
+		return ErrDPoPProofRequired
+	}
+
+	verified, err := v.Verify(ctx, proof, method, uri, accessToken)
+	if err != nil {
+		return err
+	}
+	if verified.Thumbprint != claims.Cnf.JKT {
+		return ErrDPoPKeyMismatch
+	}
+	return nil
+}
+
+// normalizeHTU drops query and fragment from an HTTP URI; RPC names are compared as-is
+func normalizeHTU(uri string) string {
+	parsed, err := url.Parse(uri)
+	if err != nil || parsed.Scheme == "" {
+		return uri
+	}
+	parsed.RawQuery = ""
+	parsed.Fragment = ""
+	return parsed.String()
+}
+
+// parsePublicJWK converts a public JWK into a crypto key and its RFC 7638 SHA-256 thumbprint
+func parsePublicJWK(jwk map[string]interface{}) (crypto.PublicKey, string, error) {
+	str := func(name string) string {
+		value, _ := jwk[name].(string)
+		return value
+	}
+	if str("d") != "" {
+		return nil, "", errors.New("dpop jwk must not contain a private key")
+	}
+
+	var key crypto.PublicKey
+	var members map[string]string
+
+	switch kty := str("kty"); kty {
+	case "EC":
+		var curve elliptic.Curve
+		switch str("crv") {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		default:
+			return nil, "", errors.New("unsupported dpop jwk curve")
+		}
+		x, errX := base64.RawURLEncoding.DecodeString(str("x"))
+		y, errY := base64.RawURLEncoding.DecodeString(str("y"))
+		if errX != nil || errY != nil {
+			return nil, "", errors.New("invalid dpop jwk coordinates")
+		}
+		pub := &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !curve.IsOnCurve(pub.X, pub.Y) {
+			return nil, "", errors.New("invalid dpop jwk point")
+		}
+		key = pub
+		members = map[string]string{"crv": str("crv"), "kty": kty, "x": str("x"), "y": str("y")}
+
+	case "RSA":
+		n, errN := base64.RawURLEncoding.DecodeString(str("n"))
+		e, errE := base64.RawURLEncoding.DecodeString(str("e"))
+		if errN != nil || errE != nil || len(e) == 0 || len(e) > 4 {
+			return nil, "", errors.New("invalid dpop jwk modulus or exponent")
+		}
+		key = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+		members = map[string]string{"e": str("e"), "kty": kty, "n": str("n")}
+
+	case "OKP":
+		if str("crv") != "Ed25519" {
+			return nil, "", errors.New("unsupported dpop jwk curve")
+		}
+		x, err := base64.RawURLEncoding.DecodeString(str("x"))
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, "", errors.New("invalid dpop jwk key")
+		}
+		key = ed25519.PublicKey(x)
+		members = map[string]string{"crv": "Ed25519", "kty": kty, "x": str("x")}
+
+	default:
+		return nil, "", errors.New("unsupported dpop jwk key type")
+	}
+
+	// encoding/json sorts map keys, producing the canonical RFC 7638 member order
+	canonical, err := json.Marshal(members)
+	if err != nil {
+		return nil, "", err
+	}
+	sum := sha256.Sum256(canonical)
+
+	return key, base64.RawURLEncoding.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,100 @@
+package auth
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// IssuedTokenTypeAccessToken is the RFC 8693 token type URI of exchanged tokens
+const IssuedTokenTypeAccessToken = "urn:ietf:params:oauth:token-type:access_token"
+
+var (
+	// ErrScopeNotAllowed is returned when an exchange requests scopes the subject token does not hold
+	ErrScopeNotAllowed = errors.New("requested scope exceeds subject token scope")
+
+	// ErrSubjectTokenExpiring is returned when the subject token has no lifetime left to delegate
+	ErrSubjectTokenExpiring = errors.New("subject token is about to expire")
+)
+
+// Scopes returns the space-separated "scope" claim as a list (empty = unrestricted)
+func (c *Claims) Scopes() []string {
+	return strings.Fields(c.Scope)
+}
+
+// HasAudience reports whether the token was issued for the given audience
+func (c *Claims) HasAudience(audience string) bool {
+	return slices.Contains(c.Audience, audience)
+}
+
+// ExchangeRequest describes a downstream token requested through token exchange
+type ExchangeRequest struct {
+	Audience string
+	Actor    string
+	Scopes   []string
+	Duration time.Duration
+}
+
+// ExchangeToken issues a narrower access token for a single audience on behalf of the subject
+// token's user (RFC 8693). The new token:
+//   - carries only the requested scopes, which must be a subset of the subject token's scopes
+//   - never outlives the subject token or the requested duration
+//   - nests the subject token's "act" chain under the new actor
+//   - keeps auth_time/amr but is never DPoP-bound, since downstream services hold it as a bearer
+func (m *TokenManager) ExchangeToken(subject *Claims, req ExchangeRequest) (string, *Claims, error) {
+	subjectScopes := subject.Scopes()
+	if len(subjectScopes) > 0 {
+		for _, scope := range req.Scopes {
+			if !slices.Contains(subjectScopes, scope) {
+				return "", nil, ErrScopeNotAllowed
+			}
+		}
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(req.Duration)
+	if subject.ExpiresAt != nil && subject.ExpiresAt.Time.Before(expiresAt) {
+		expiresAt = subject.ExpiresAt.Time
+	}
+	if !expiresAt.After(now) {
+		return "", nil, ErrSubjectTokenExpiring
+	}
+
+	tokenID, err := newTokenID()
+	if err != nil {
+		return "", nil, err
+	}
+
+	claims := &Claims{
+		UserID:    subject.UserID,
+		Email:     subject.Email,
+		Role:      subject.Role,
+		TokenType: TokenTypeAccess,
+		AuthTime:  subject.AuthTime,
+		AMR:       subject.AMR,
+		Scope:     strings.Join(req.Scopes, " "),
+		Act: &ActorClaim{
+			Subject: req.Actor,
+			Act:     subject.Act,
+		},
+		IssuedAtMillis: now.UnixMilli(),
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenID,
+			Subject:   strconv.Itoa(int(subject.UserID)),
+			Audience:  jwt.ClaimStrings{req.Audience},
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	signed, err := token.SignedString(m.secretKey)
+	if err != nil {
+		return "", nil, err
+	}
+	return signed, claims, nil
+}
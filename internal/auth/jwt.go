@@ -2,8 +2,11 @@ package auth
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -11,19 +14,21 @@ import (
 )
 
 type TokenManager struct {
-	secretKey            []byte
-	accessTokenDuration  time.Duration
-	refreshTokenDuration time.Duration
-	redisClient          *redis.Client
+	secretKey           []byte
+	accessTokenDuration time.Duration
+	sessionPolicies     SessionPolicies
+	redisClient         *redis.Client
 }
 
 // Constructor
 func NewTokenManager(secret string, accessDuration, refreshDuration time.Duration, redisClient *redis.Client) *TokenManager {
 	return &TokenManager{
-		secretKey:            []byte(secret),
-		accessTokenDuration:  accessDuration,
-		refreshTokenDuration: refreshDuration,
-		redisClient:          redisClient,
+		secretKey:           []byte(secret),
+		accessTokenDuration: accessDuration,
+		sessionPolicies: SessionPolicies{
+			Default: SessionPolicy{IdleTimeout: refreshDuration},
+		},
+		redisClient: redisClient,
 	}
 }
 
@@ -33,62 +38,255 @@ const (
 	TokenTypeRefresh = "refresh"
 )
 
+// User roles
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
+// Authentication method references ("amr" claim values)
+const (
+	AMRPassword = "pwd"
+	AMROTP      = "otp"
+	AMRWebAuthn = "webauthn"
+)
+
 // Claims
 type Claims struct {
 	UserID    int32  `json:"user_id"`
 	Email     string `json:"email"`
+	Role      string `json:"role,omitempty"`
 	TokenType string `json:"token_type"`
+
+	// AuthTime and AMR record when and how the user last authenticated interactively
+	AuthTime *jwt.NumericDate `json:"auth_time,omitempty"`
+	AMR      []string         `json:"amr,omitempty"`
+
+	// Cnf binds the token to a DPoP key (RFC 9449); nil for bearer tokens
+	Cnf *ConfirmationClaim `json:"cnf,omitempty"`
+
+	// Scope limits exchanged tokens to space-separated scopes (empty = unrestricted)
+	Scope string `json:"scope,omitempty"`
+
+	// Session lifetime (refresh tokens only): client application, remember-me choice,
+	// original login time and absolute expiry
+	ClientID       string           `json:"client_id,omitempty"`
+	ClientVerified bool             `json:"client_verified,omitempty"`
+	RememberMe     bool             `json:"remember_me,omitempty"`
+	SessionStart   *jwt.NumericDate `json:"session_start,omitempty"`
+	SessionExpiry  *jwt.NumericDate `json:"session_exp,omitempty"`
+
+	// Act identifies the party acting on behalf of the subject (RFC 8693)
+	Act *ActorClaim `json:"act,omitempty"`
+
+	// IssuedAtMillis is the issue time in unix milliseconds, so revocations can tell apart
+	// tokens issued within the same second as the revocation
+	IssuedAtMillis int64 `json:"iat_ms,omitempty"`
+
 	jwt.RegisteredClaims
 }
 
-// Generate Access token
-func (m *TokenManager) GenerateToken(userID int32, email string) (string, error) {
-	now := time.Now()
+// ActorClaim is an RFC 8693 "act" claim; nested Act values describe prior actors in a delegation chain
+type ActorClaim struct {
+	Subject string      `json:"sub"`
+	Email   string      `json:"email,omitempty"`
+	Act     *ActorClaim `json:"act,omitempty"`
+}
+
+// Session identifies an authenticated user and how they authenticated.
+// It is copied unchanged from refresh token claims into the rotated token pair.
+type Session struct {
+	UserID   int32
+	Email    string
+	Role     string
+	AuthTime time.Time
+	AMR      []string
+
+	// JKT is the DPoP key thumbprint the tokens are bound to (empty = bearer)
+	JKT string
+
+	// Session lifetime, set by StartSession at login. ClientVerified records that the
+	// login presented the client's secret, so the client's session policy applies.
+	ClientID       string
+	ClientVerified bool
+	RememberMe     bool
+	StartedAt      time.Time
+	ExpiresAt      time.Time // zero = no absolute limit
+}
+
+// Session returns the authentication session recorded in the claims
+func (c *Claims) Session() Session {
+	session := Session{
+		UserID: c.UserID,
+		Email:  c.Email,
+		Role:   c.Role,
+		AMR:    c.AMR,
+	}
+	if c.AuthTime != nil {
+		session.AuthTime = c.AuthTime.Time
+	}
+	if c.Cnf != nil {
+		session.JKT = c.Cnf.JKT
+	}
+	session.ClientID = c.ClientID
+	session.ClientVerified = c.ClientVerified
+	session.RememberMe = c.RememberMe
+	if c.SessionStart != nil {
+		session.StartedAt = c.SessionStart.Time
+	}
+	if c.SessionExpiry != nil {
+		session.ExpiresAt = c.SessionExpiry.Time
+	}
+	return session
+}
+
+// Scheme returns the token type clients must use when presenting the token
+func (c *Claims) Scheme() string {
+	if c.Cnf != nil && c.Cnf.JKT != "" {
+		return TokenTypeDPoP
+	}
+	return TokenTypeBearer
+}
+
+// AuthAge returns how long ago the user authenticated; ok is false when the token has no auth_time
+func (c *Claims) AuthAge() (age time.Duration, ok bool) {
+	if c.AuthTime == nil {
+		return 0, false
+	}
+	return time.Since(c.AuthTime.Time), true
+}
+
+// newSessionClaims builds claims of the given type for a session, never outliving the session itself
+func newSessionClaims(session Session, tokenType string, now time.Time, duration time.Duration) Claims {
 	claims := Claims{
-		UserID:    userID,
-		Email:     email,
-		TokenType: TokenTypeAccess,
+		UserID:         session.UserID,
+		Email:          session.Email,
+		Role:           session.Role,
+		TokenType:      tokenType,
+		AMR:            session.AMR,
+		IssuedAtMillis: now.UnixMilli(),
 		RegisteredClaims: jwt.RegisteredClaims{
-			// Use config from struct
-			ExpiresAt: jwt.NewNumericDate(now.Add(m.accessTokenDuration)),
+			ExpiresAt: jwt.NewNumericDate(capToSession(session, now.Add(duration))),
 			IssuedAt:  jwt.NewNumericDate(now),
 		},
 	}
+	if !session.AuthTime.IsZero() {
+		claims.AuthTime = jwt.NewNumericDate(session.AuthTime)
+	}
+	if session.JKT != "" {
+		claims.Cnf = &ConfirmationClaim{JKT: session.JKT}
+	}
+	return claims
+}
+
+// IsAdmin reports whether the token subject has the admin role
+func (c *Claims) IsAdmin() bool {
+	return c.Role == RoleAdmin
+}
+
+// Actors returns the delegation chain of the token, current actor first
+func (c *Claims) Actors() []*ActorClaim {
+	var actors []*ActorClaim
+	for act := c.Act; act != nil; act = act.Act {
+		actors = append(actors, act)
+	}
+	return actors
+}
+
+// Generate Access token
+func (m *TokenManager) GenerateToken(session Session) (string, error) {
+	// Use config from struct
+	claims := newSessionClaims(session, TokenTypeAccess, time.Now(), m.accessTokenDuration)
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString(m.secretKey)
 }
 
-// GenerateRefreshToken
-func (m *TokenManager) GenerateRefreshToken(userID int32, email string) (string, error) {
+// GenerateImpersonationToken issues a short-lived access token for the target user acting as the admin.
+// The admin is recorded in the "act" claim; no refresh token is ever issued for impersonation.
+// The token has no auth_time, so it never satisfies step-up authentication.
+func (m *TokenManager) GenerateImpersonationToken(targetID int32, targetEmail, targetRole string, adminID int32, adminEmail string, duration time.Duration) (string, *Claims, error) {
+	tokenID, err := newTokenID()
+	if err != nil {
+		return "", nil, err
+	}
+
 	now := time.Now()
-	claims := Claims{
-		UserID:    userID,
-		Email:     email,
-		TokenType: TokenTypeRefresh,
+	claims := &Claims{
+		UserID:    targetID,
+		Email:     targetEmail,
+		Role:      targetRole,
+		TokenType: TokenTypeAccess,
+		Act: &ActorClaim{
+			Subject: strconv.Itoa(int(adminID)),
+			Email:   adminEmail,
+		},
+		IssuedAtMillis: now.UnixMilli(),
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(now.Add(m.refreshTokenDuration)),
+			ID:        tokenID,
+			Subject:   strconv.Itoa(int(targetID)),
+			ExpiresAt: jwt.NewNumericDate(now.Add(duration)),
 			IssuedAt:  jwt.NewNumericDate(now),
 		},
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(m.secretKey)
+	signed, err := token.SignedString(m.secretKey)
+	if err != nil {
+		return "", nil, err
+	}
+	return signed, claims, nil
 }
 
-// Validate token (for access tokens only)
-func (m *TokenManager) ValidateToken(ctx context.Context, tokenString string) (*Claims, error) {
-	// 1. Blacklist checking
-	key := "blacklist:" + tokenString
-	_, err := m.redisClient.Get(ctx, key).Result()
-	if err == nil {
-		// Key found = revoked token
-		return nil, errors.New("token has been revoked")
+// newTokenID generates a random token identifier for the "jti" claim
+func newTokenID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate token id: %w", err)
 	}
-	if err != redis.Nil {
-		return nil, fmt.Errorf("redis error: %w", err)
+	return hex.EncodeToString(b), nil
+}
+
+// GenerateRefreshToken issues a refresh token expiring after the session's idle timeout,
+// capped at the session's absolute expiry
+func (m *TokenManager) GenerateRefreshToken(session Session) (string, error) {
+	now := time.Now()
+	if !session.ExpiresAt.IsZero() && !now.Before(session.ExpiresAt) {
+		return "", ErrSessionExpired
+	}
+
+	policy := m.sessionPolicies.Resolve(session.ClientID, session.ClientVerified, session.RememberMe)
+	claims := newSessionClaims(session, TokenTypeRefresh, now, policy.IdleTimeout)
+	claims.ClientID = session.ClientID
+	claims.ClientVerified = session.ClientVerified
+	claims.RememberMe = session.RememberMe
+	if !session.StartedAt.IsZero() {
+		claims.SessionStart = jwt.NewNumericDate(session.StartedAt)
+	}
+	if !session.ExpiresAt.IsZero() {
+		claims.SessionExpiry = jwt.NewNumericDate(session.ExpiresAt)
 	}
 
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(m.secretKey)
+}
+
+// Token validation errors
+var (
+	ErrTokenRevoked          = errors.New("token has been revoked")
+	ErrUserTokensRevoked     = errors.New("all tokens of the user have been revoked")
+	ErrInvalidTokenType      = errors.New("invalid token type")
+	ErrTokenExpired          = jwt.ErrTokenExpired
+	ErrTokenSignatureInvalid = jwt.ErrTokenSignatureInvalid
+)
+
+// blacklistKey returns the Redis key marking a token as revoked
+func blacklistKey(tokenString string) string {
+	return "blacklist:" + tokenString
+}
+
+// parseToken verifies the signature and registered claims of a token
+func (m *TokenManager) parseToken(tokenString string) (*Claims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, errors.New("unexpected signing method")
@@ -101,49 +299,164 @@ func (m *TokenManager) ValidateToken(ctx context.Context, tokenString string) (*
 	}
 
 	if claims, ok := token.Claims.(*Claims); ok && token.Valid {
-		// Verify this is an access token
-		if claims.TokenType != TokenTypeAccess {
-			return nil, errors.New("invalid token type: expected access token")
-		}
 		return claims, nil
 	}
 
 	return nil, errors.New("invalid token")
 }
 
+// parseAccessToken verifies a token and ensures it is an access token
+func (m *TokenManager) parseAccessToken(tokenString string) (*Claims, error) {
+	claims, err := m.parseToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify this is an access token
+	if claims.TokenType != TokenTypeAccess {
+		return nil, fmt.Errorf("%w: expected access token", ErrInvalidTokenType)
+	}
+	return claims, nil
+}
+
+// Validate token (for access tokens only)
+func (m *TokenManager) ValidateToken(ctx context.Context, tokenString string) (*Claims, error) {
+	claims, errs, err := m.ValidateTokens(ctx, []string{tokenString})
+	if err != nil {
+		return nil, err
+	}
+	return claims[0], errs[0]
+}
+
+// ValidateTokens validates many access tokens, checking the blacklist and per-user
+// revocations for all of them in a single pipelined Redis round-trip. Results are in
+// input order: errs[i] is set when tokens[i] is invalid. The returned error is only
+// set when Redis fails.
+func (m *TokenManager) ValidateTokens(ctx context.Context, tokens []string) ([]*Claims, []error, error) {
+	claims := make([]*Claims, len(tokens))
+	errs := make([]error, len(tokens))
+	if len(tokens) == 0 {
+		return claims, errs, nil
+	}
+
+	// 1. Verify signatures and expiry locally
+	for i, tokenString := range tokens {
+		claims[i], errs[i] = m.parseAccessToken(tokenString)
+	}
+
+	// 2. Blacklist and user revocation checking for every token at once
+	pipe := m.redisClient.Pipeline()
+	revoked := make([]*redis.IntCmd, len(tokens))
+	cutoffs := make([]*redis.StringCmd, len(tokens))
+	for i, tokenString := range tokens {
+		revoked[i] = pipe.Exists(ctx, blacklistKey(tokenString))
+		if claims[i] != nil {
+			cutoffs[i] = pipe.Get(ctx, userRevocationKey(claims[i].UserID))
+		}
+	}
+	// A missing revocation key yields redis.Nil; real failures are checked per command below
+	_, _ = pipe.Exec(ctx)
+
+	for i := range tokens {
+		if err := revoked[i].Err(); err != nil {
+			return nil, nil, fmt.Errorf("redis error: %w", err)
+		}
+		if revoked[i].Val() > 0 {
+			claims[i], errs[i] = nil, ErrTokenRevoked
+			continue
+		}
+		if claims[i] == nil {
+			continue
+		}
+
+		cutoff, err := cutoffs[i].Int64()
+		if err != nil && err != redis.Nil {
+			return nil, nil, fmt.Errorf("redis error: %w", err)
+		}
+		if err == nil && issuedBefore(claims[i], cutoff) {
+			claims[i], errs[i] = nil, ErrUserTokensRevoked
+		}
+	}
+
+	return claims, errs, nil
+}
+
 // ValidateRefreshToken validates refresh tokens specifically
 func (m *TokenManager) ValidateRefreshToken(ctx context.Context, tokenString string) (*Claims, error) {
 	// 1. Blacklist checking
-	key := "blacklist:" + tokenString
-	_, err := m.redisClient.Get(ctx, key).Result()
+	_, err := m.redisClient.Get(ctx, blacklistKey(tokenString)).Result()
 	if err == nil {
-		return nil, errors.New("token has been revoked")
+		return nil, ErrTokenRevoked
 	}
 	if err != redis.Nil {
 		return nil, fmt.Errorf("redis error: %w", err)
 	}
 
 	// 2. Parse token
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errors.New("unexpected signing method")
-		}
-		return m.secretKey, nil
-	})
-
+	claims, err := m.parseToken(tokenString)
 	if err != nil {
 		return nil, err
 	}
 
-	if claims, ok := token.Claims.(*Claims); ok && token.Valid {
-		// Verify this is a refresh token
-		if claims.TokenType != TokenTypeRefresh {
-			return nil, errors.New("invalid token type: expected refresh token")
-		}
-		return claims, nil
+	// Verify this is a refresh token
+	if claims.TokenType != TokenTypeRefresh {
+		return nil, fmt.Errorf("%w: expected refresh token", ErrInvalidTokenType)
+	}
+	// Impersonation never yields refresh tokens
+	if claims.Act != nil {
+		return nil, errors.New("invalid refresh token: delegated tokens cannot be refreshed")
+	}
+	// Enforce the absolute session lifetime from the original login
+	if claims.SessionExpiry != nil && !time.Now().Before(claims.SessionExpiry.Time) {
+		return nil, ErrSessionExpired
 	}
 
-	return nil, errors.New("invalid token")
+	// Reject sessions started before the user's tokens were revoked
+	cutoff, err := m.redisClient.Get(ctx, userRevocationKey(claims.UserID)).Int64()
+	if err != nil && err != redis.Nil {
+		return nil, fmt.Errorf("redis error: %w", err)
+	}
+	if err == nil && issuedBefore(claims, cutoff) {
+		return nil, ErrUserTokensRevoked
+	}
+	return claims, nil
+}
+
+// RevokeUserTokens invalidates every token issued to the user until now, e.g. when the
+// account is suspended. The cutoff is kept as long as any such token could still be valid.
+func (m *TokenManager) RevokeUserTokens(ctx context.Context, userID int32) error {
+	ttl := max(m.accessTokenDuration, m.sessionPolicies.longestIdleTimeout())
+	return m.redisClient.Set(ctx, userRevocationKey(userID), time.Now().UnixMilli(), ttl).Err()
+}
+
+// userRevocationKey returns the Redis key holding the unix time in milliseconds before which
+// all tokens of the user are revoked
+func userRevocationKey(userID int32) string {
+	return "revoked:user:" + strconv.Itoa(int(userID))
+}
+
+// legacyCutoffLimit separates revocation cutoffs stored in unix seconds by earlier versions
+// (any current time) from millisecond cutoffs (never below it after 1973)
+const legacyCutoffLimit = 100_000_000_000
+
+// issuedBefore reports whether the token was issued up to the cutoff (unix milliseconds).
+// Tokens issued in the millisecond of the revocation are revoked too, since they may have been
+// minted before it. Tokens without a millisecond issue time count as issued at the start of
+// their second, so those issued in the second of the revocation are rejected.
+func issuedBefore(claims *Claims, cutoff int64) bool {
+	if cutoff < legacyCutoffLimit {
+		// A seconds cutoff revoked everything issued up to the end of that second
+		cutoff = cutoff*1000 + 999
+	}
+
+	issuedAt := claims.IssuedAtMillis
+	if issuedAt == 0 {
+		if claims.IssuedAt == nil {
+			return true
+		}
+		issuedAt = claims.IssuedAt.UnixMilli()
+	}
+	return issuedAt <= cutoff
 }
 
 // InvalidateToken to blacklist
@@ -169,8 +482,7 @@ func (m *TokenManager) InvalidateToken(ctx context.Context, tokenString string)
 	// Key: "blacklist:<token>"
 	// value: "revoked"
 	// TTL: timeRemaining
-	key := "blacklist:" + tokenString
-	err = m.redisClient.Set(ctx, key, "revoked", timeRemaining).Err()
+	err = m.redisClient.Set(ctx, blacklistKey(tokenString), "revoked", timeRemaining).Err()
 	if err != nil {
 		return err
 	}
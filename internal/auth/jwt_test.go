@@ -0,0 +1,49 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestIssuedBefore(t *testing.T) {
+	revokedAt := time.Date(2026, 1, 2, 3, 4, 5, 500*int(time.Millisecond), time.UTC)
+	cutoff := revokedAt.UnixMilli()
+
+	claimsAt := func(issued time.Time) *Claims {
+		return &Claims{
+			IssuedAtMillis:   issued.UnixMilli(),
+			RegisteredClaims: jwt.RegisteredClaims{IssuedAt: jwt.NewNumericDate(issued)},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		claims *Claims
+		cutoff int64
+		want   bool
+	}{
+		{"earlier in the same second", claimsAt(revokedAt.Add(-200 * time.Millisecond)), cutoff, true},
+		{"in the millisecond of the revocation", &Claims{IssuedAtMillis: cutoff}, cutoff, true},
+		{"one millisecond after the revocation", &Claims{IssuedAtMillis: cutoff + 1}, cutoff, false},
+		{"later in the same second", claimsAt(revokedAt.Add(200 * time.Millisecond)), cutoff, false},
+		{"without issue time", &Claims{}, cutoff, true},
+		{
+			name:   "legacy token in the same second",
+			claims: &Claims{RegisteredClaims: jwt.RegisteredClaims{IssuedAt: jwt.NewNumericDate(revokedAt.Add(200 * time.Millisecond))}},
+			cutoff: cutoff,
+			want:   true,
+		},
+		{"seconds cutoff covers its whole second", claimsAt(revokedAt.Add(200 * time.Millisecond)), revokedAt.Unix(), true},
+		{"seconds cutoff spares the next second", claimsAt(revokedAt.Add(time.Second)), revokedAt.Unix(), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := issuedBefore(tt.claims, tt.cutoff); got != tt.want {
+				t.Errorf("issuedBefore() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
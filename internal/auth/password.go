@@ -1,8 +1,10 @@
 package auth
 
 import (
+	"crypto/rand"
 	"errors"
 	"regexp"
+	"sync"
 
 	"golang.org/x/crypto/bcrypt"
 )
@@ -51,3 +53,33 @@ func CheckPassword(password, hash string) bool {
 	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
 	return err == nil
 }
+
+// dummyPasswordHash is a bcrypt hash of a random secret with the same cost as real hashes.
+// Comparing against it makes logins for unknown or password-less accounts cost the same work.
+var dummyPasswordHash = sync.OnceValue(func() []byte {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		panic("auth: generate dummy password: " + err.Error())
+	}
+	hash, err := bcrypt.GenerateFromPassword(secret, bcrypt.DefaultCost)
+	if err != nil {
+		panic("auth: hash dummy password: " + err.Error())
+	}
+	return hash
+})
+
+// VerifyPassword compares a password with a bcrypt hash in constant work.
+// An empty hash (unknown user or legacy account without password) still costs one
+// full bcrypt comparison against a dummy hash and always fails.
+func VerifyPassword(password, hash string) bool {
+	if hash == "" {
+		_ = bcrypt.CompareHashAndPassword(dummyPasswordHash(), []byte(password))
+		return false
+	}
+	return CheckPassword(password, hash)
+}
+
+// WarmUp precomputes the dummy hash so the first failed login is not slower than later ones
+func WarmUp() {
+	dummyPasswordHash()
+}
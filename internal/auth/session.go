@@ -0,0 +1,152 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"time"
+)
+
+// ErrSessionExpired is returned when a session has reached its absolute maximum lifetime
+var ErrSessionExpired = errors.New("session has reached its maximum lifetime")
+
+// SessionPolicy bounds how long a login session can be kept alive with refresh tokens
+type SessionPolicy struct {
+	// IdleTimeout is the sliding lifetime of each refresh token, renewed on every refresh
+	IdleTimeout time.Duration
+
+	// MaxLifetime is the absolute session lifetime counted from the original login (0 = unlimited)
+	MaxLifetime time.Duration
+}
+
+// SessionPolicies selects a SessionPolicy by client application and "remember me" choice
+type SessionPolicies struct {
+	Default    SessionPolicy
+	RememberMe SessionPolicy
+	Clients    map[string]SessionPolicy
+
+	// ClientSecrets authenticate client applications; a client policy applies only to
+	// logins presenting the client's secret
+	ClientSecrets map[string]string
+}
+
+// VerifyClient reports whether secret is the configured secret of clientID
+func (p SessionPolicies) VerifyClient(clientID, secret string) bool {
+	expected, ok := p.ClientSecrets[clientID]
+	return ok && VerifySecret(expected, secret)
+}
+
+// VerifySecret compares a presented client secret with the configured one in constant time.
+// Empty secrets never match.
+func VerifySecret(expected, presented string) bool {
+	if expected == "" || presented == "" {
+		return false
+	}
+	// Compare digests so the comparison time does not depend on the secret length
+	a, b := sha256.Sum256([]byte(expected)), sha256.Sum256([]byte(presented))
+	return subtle.ConstantTimeCompare(a[:], b[:]) == 1
+}
+
+// Resolve returns the policy for a client application, using the longer
+// "remember me" profile when the user opted in. Unknown or unverified client IDs
+// get the strictest configured policy, so callers cannot pick a lenient one.
+func (p SessionPolicies) Resolve(clientID string, clientVerified, rememberMe bool) SessionPolicy {
+	policy := p.Default
+	if clientID != "" {
+		if clientPolicy, ok := p.Clients[clientID]; ok && clientVerified {
+			policy = clientPolicy
+		} else {
+			policy = p.strictest()
+		}
+	}
+
+	if rememberMe && p.RememberMe.IdleTimeout > 0 {
+		policy.IdleTimeout = max(policy.IdleTimeout, p.RememberMe.IdleTimeout)
+		if policy.MaxLifetime > 0 {
+			// A zero remember-me lifetime means unlimited
+			if p.RememberMe.MaxLifetime == 0 {
+				policy.MaxLifetime = 0
+			} else {
+				policy.MaxLifetime = max(policy.MaxLifetime, p.RememberMe.MaxLifetime)
+			}
+		}
+	}
+
+	return policy
+}
+
+// strictest returns the shortest idle timeout and absolute lifetime of the default and client policies
+func (p SessionPolicies) strictest() SessionPolicy {
+	policy := p.Default
+	for _, client := range p.Clients {
+		policy.IdleTimeout = min(policy.IdleTimeout, client.IdleTimeout)
+		// Zero means unlimited, the least strict lifetime
+		if policy.MaxLifetime == 0 || (client.MaxLifetime > 0 && client.MaxLifetime < policy.MaxLifetime) {
+			policy.MaxLifetime = client.MaxLifetime
+		}
+	}
+	return policy
+}
+
+// longestIdleTimeout returns the longest refresh token lifetime any policy can issue
+func (p SessionPolicies) longestIdleTimeout() time.Duration {
+	longest := max(p.Default.IdleTimeout, p.RememberMe.IdleTimeout)
+	for _, policy := range p.Clients {
+		longest = max(longest, policy.IdleTimeout)
+	}
+	return longest
+}
+
+// SetSessionPolicies replaces the session policies used for refresh tokens.
+// By default every session uses REFRESH_TOKEN_DURATION as idle timeout without an absolute limit.
+func (m *TokenManager) SetSessionPolicies(policies SessionPolicies) {
+	m.sessionPolicies = policies
+}
+
+// VerifyClient reports whether a login presents the secret of its client application
+func (m *TokenManager) VerifyClient(clientID, secret string) bool {
+	return m.sessionPolicies.VerifyClient(clientID, secret)
+}
+
+// StartSession stamps a new login session with its start time and absolute expiry
+func (m *TokenManager) StartSession(session Session) Session {
+	now := time.Now()
+	session.StartedAt = now
+
+	policy := m.sessionPolicies.Resolve(session.ClientID, session.ClientVerified, session.RememberMe)
+	if policy.MaxLifetime > 0 {
+		session.ExpiresAt = now.Add(policy.MaxLifetime)
+	}
+
+	return session
+}
+
+// ContinueSession stamps the absolute expiry on sessions from refresh tokens issued before
+// sessions had one, counted from the original login (or now when that is unknown)
+func (m *TokenManager) ContinueSession(session Session) Session {
+	if !session.ExpiresAt.IsZero() {
+		return session
+	}
+
+	policy := m.sessionPolicies.Resolve(session.ClientID, session.ClientVerified, session.RememberMe)
+	if policy.MaxLifetime == 0 {
+		return session
+	}
+
+	if session.StartedAt.IsZero() {
+		session.StartedAt = session.AuthTime
+	}
+	if session.StartedAt.IsZero() {
+		session.StartedAt = time.Now()
+	}
+	session.ExpiresAt = session.StartedAt.Add(policy.MaxLifetime)
+	return session
+}
+
+// capToSession limits a token expiry to the session's absolute expiry
+func capToSession(session Session, expiresAt time.Time) time.Time {
+	if !session.ExpiresAt.IsZero() && session.ExpiresAt.Before(expiresAt) {
+		return session.ExpiresAt
+	}
+	return expiresAt
+}
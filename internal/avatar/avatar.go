@@ -0,0 +1,130 @@
+package avatar
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"net/http"
+
+	// Decoders for the accepted upload formats
+	_ "image/gif"
+	_ "image/jpeg"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+// Content types accepted for uploads
+var AllowedContentTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+	"image/webp": true,
+}
+
+// ContentType of the stored renditions
+const ContentType = "image/png"
+
+// MaxDimension bounds the width and height of uploads, so small files cannot decode into huge bitmaps
+const MaxDimension = 4096
+
+// Sizes of the square renditions generated for every upload, largest first.
+// The largest one is the avatar; the others are thumbnails.
+var Sizes = []int{512, 128, 64}
+
+var (
+	// ErrUnsupportedType is returned for uploads that are not one of AllowedContentTypes
+	ErrUnsupportedType = errors.New("unsupported image type")
+
+	// ErrInvalidImage is returned for uploads that cannot be decoded or exceed MaxDimension
+	ErrInvalidImage = errors.New("invalid image")
+)
+
+// Rendition is one stored size of an avatar
+type Rendition struct {
+	Size int    // Width and height in pixels
+	Name string // File name within the avatar's key prefix
+	Data []byte
+}
+
+// DetectContentType sniffs the image type from the data itself rather than trusting the client
+func DetectContentType(data []byte) string {
+	return http.DetectContentType(data)
+}
+
+// Process decodes an uploaded image, crops it to a centered square and encodes one PNG per
+// size in Sizes. Images smaller than a size are not upscaled. Re-encoding also drops any
+// metadata (EXIF location, camera details) embedded in the original file.
+func Process(data []byte) ([]Rendition, error) {
+	if !AllowedContentTypes[DetectContentType(data)] {
+		return nil, ErrUnsupportedType
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+	if config.Width <= 0 || config.Height <= 0 || config.Width > MaxDimension || config.Height > MaxDimension {
+		return nil, fmt.Errorf("%w: dimensions %dx%d exceed %dx%d", ErrInvalidImage, config.Width, config.Height, MaxDimension, MaxDimension)
+	}
+
+	source, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+
+	square := centerSquare(source.Bounds())
+
+	renditions := make([]Rendition, 0, len(Sizes))
+	for _, size := range Sizes {
+		side := min(size, square.Dx())
+
+		scaled := image.NewRGBA(image.Rect(0, 0, side, side))
+		draw.CatmullRom.Scale(scaled, scaled.Bounds(), source, square, draw.Src, nil)
+
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, scaled); err != nil {
+			return nil, fmt.Errorf("encode %dpx avatar: %w", size, err)
+		}
+
+		renditions = append(renditions, Rendition{
+			Size: side,
+			Name: fmt.Sprintf("avatar-%d.png", size),
+			Data: buf.Bytes(),
+		})
+	}
+
+	return renditions, nil
+}
+
+// centerSquare returns the largest square centered in bounds
+func centerSquare(bounds image.Rectangle) image.Rectangle {
+	side := min(bounds.Dx(), bounds.Dy())
+	x := bounds.Min.X + (bounds.Dx()-side)/2
+	y := bounds.Min.Y + (bounds.Dy()-side)/2
+	return image.Rect(x, y, x+side, y+side)
+}
+
+// UserPrefix is the key prefix holding every avatar version of a user
+func UserPrefix(userID int32) string {
+	return fmt.Sprintf("avatars/%d/", userID)
+}
+
+// RevisionPrefix is the key prefix of one uploaded avatar. A new revision per upload
+// gives every avatar a new URL, so caches never serve a replaced picture.
+func RevisionPrefix(userID int32, revision string) string {
+	return UserPrefix(userID) + revision + "/"
+}
+
+// NewRevision generates a random revision identifier for RevisionPrefix
+func NewRevision() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate avatar revision: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -1,9 +1,16 @@
 package config
 
 import (
+	"log"
+	"net/netip"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/thatlq1812/agrios-shared/pkg/common"
+	"github.com/thatlq1812/service-1-user/internal/auth"
 	"github.com/thatlq1812/service-1-user/internal/db"
-	"time"
 )
 
 // Config holds all configuration for the application
@@ -16,11 +23,80 @@ type Config struct {
 	AccessTokenDuration  time.Duration
 	RefreshTokenDuration time.Duration
 
+	// Refresh session lifetimes (idle timeout is sliding, max lifetime is absolute)
+	Sessions auth.SessionPolicies
+
+	// Lifetime of admin impersonation access tokens
+	ImpersonationTokenDuration time.Duration
+
+	// Maximum authentication age per RPC before step-up is required
+	StepUpPolicy map[string]time.Duration
+
+	// Accepted clock skew / age of DPoP proofs
+	DPoPProofMaxAge time.Duration
+
+	// Token exchange: allowed downstream audiences and maximum lifetime of exchanged tokens
+	TokenExchangeAudiences   []string
+	TokenExchangeClients     map[string]string
+	TokenExchangeMaxDuration time.Duration
+	TokenExchangeChaining    bool
+
+	// Breached password check (empty dir = disabled)
+	BreachedPasswordsDir       string
+	BreachedPasswordMinCount   int
+	BreachedPasswordLoginCheck bool
+
+	// Number of previous passwords that cannot be reused (0 = disabled)
+	PasswordHistoryDepth int
+
+	// Hide whether an email is registered from CreateUser responses
+	SilentSignup bool
+
+	// New device detection and the development notification sink (empty file = no notifications)
+	NewDeviceDetection   bool
+	NotificationSinkFile string
+
+	// Proxies whose client IP and user agent headers are trusted (empty = use the peer address)
+	TrustedProxies []netip.Prefix
+
+	// Soft-deleted users are purged after the retention period (0 = never purge)
+	DeletedUserRetention time.Duration
+	PurgeInterval        time.Duration
+	PurgeBatchSize       int32
+
+	// Grace period of self-service account deletion (0 = disabled) and how often due accounts are erased
+	AccountDeletionGracePeriod time.Duration
+	AccountDeletionInterval    time.Duration
+
+	// Redis stream receiving domain events for other services (empty = not published)
+	UserEventsStream       string
+	UserEventsStreamMaxLen int64
+
+	// Provider-specific email canonicalization (Gmail dots, "+tag" subaddresses)
+	EmailCanonicalizeProviders bool
+
+	// Validity of email change confirmation tokens
+	EmailChangeTokenTTL time.Duration
+
+	// Avatar storage directory (empty = avatars disabled), public URL prefix and upload limit in bytes
+	AvatarStorageDir string
+	AvatarBaseURL    string
+	AvatarMaxSize    int
+
+	// CheckAvailability calls allowed per client IP and window
+	AvailabilityCheckRateLimit  int
+	AvailabilityCheckRateWindow time.Duration
+
+	// Secret signing list page tokens (empty = JWT secret)
+	PageTokenSecret string
+
 	Redis db.RedisConfig
 	DB    db.Config
 }
 
 func Load() *Config {
+	refreshTokenDuration := common.GetEnvDuration("REFRESH_TOKEN_DURATION", 7*24*time.Hour)
+
 	return &Config{
 		// Server Config
 		GRPCPort:        common.GetEnvString("GRPC_PORT", "50051"),
@@ -29,7 +105,78 @@ func Load() *Config {
 		// JWT Config
 		JWTSecret:            common.GetEnvString("JWT_SECRET", ""), //
 		AccessTokenDuration:  common.GetEnvDuration("ACCESS_TOKEN_DURATION", 15*time.Minute),
-		RefreshTokenDuration: common.GetEnvDuration("REFRESH_TOKEN_DURATION", 7*24*time.Hour),
+		RefreshTokenDuration: refreshTokenDuration,
+
+		// Session Config
+		Sessions: auth.SessionPolicies{
+			Default: auth.SessionPolicy{
+				IdleTimeout: common.GetEnvDuration("SESSION_IDLE_TIMEOUT", refreshTokenDuration),
+				MaxLifetime: common.GetEnvDuration("SESSION_MAX_LIFETIME", 30*24*time.Hour),
+			},
+			RememberMe: auth.SessionPolicy{
+				IdleTimeout: common.GetEnvDuration("SESSION_REMEMBER_ME_IDLE_TIMEOUT", 30*24*time.Hour),
+				MaxLifetime: common.GetEnvDuration("SESSION_REMEMBER_ME_MAX_LIFETIME", 90*24*time.Hour),
+			},
+			Clients:       getEnvSessionPolicies("SESSION_CLIENT_POLICIES"),
+			ClientSecrets: getEnvSecretMap("SESSION_CLIENT_SECRETS"),
+		},
+
+		ImpersonationTokenDuration: common.GetEnvDuration("IMPERSONATION_TOKEN_DURATION", 10*time.Minute),
+		// Only RPCs that authenticate anyway are listed by default; DeleteUser and UpdateUser are
+		// opt-in because listing them makes previously unauthenticated callers send a bearer token
+		StepUpPolicy:    getEnvDurationMap("STEP_UP_POLICY", "RequestAccountDeletion=5m,ExportUserData=15m,RequestEmailChange=5m"),
+		DPoPProofMaxAge: common.GetEnvDuration("DPOP_PROOF_MAX_AGE", 5*time.Minute),
+
+		// Token Exchange Config
+		TokenExchangeAudiences:   getEnvList("TOKEN_EXCHANGE_AUDIENCES", "user-service,article-service"),
+		TokenExchangeClients:     getEnvSecretMap("TOKEN_EXCHANGE_CLIENTS"),
+		TokenExchangeMaxDuration: common.GetEnvDuration("TOKEN_EXCHANGE_MAX_DURATION", 5*time.Minute),
+		TokenExchangeChaining:    getEnvBool("TOKEN_EXCHANGE_ALLOW_CHAINING", false),
+
+		// Breached Password Config
+		BreachedPasswordsDir:       common.GetEnvString("BREACHED_PASSWORDS_DIR", ""),
+		BreachedPasswordMinCount:   common.GetEnvInt("BREACHED_PASSWORD_MIN_COUNT", 1),
+		BreachedPasswordLoginCheck: getEnvBool("BREACHED_PASSWORD_LOGIN_CHECK", false),
+
+		// Password History Config
+		PasswordHistoryDepth: common.GetEnvInt("PASSWORD_HISTORY_DEPTH", 5),
+
+		// Signup Config
+		SilentSignup: getEnvBool("SILENT_SIGNUP", false),
+
+		// Security Notification Config
+		NewDeviceDetection:   getEnvBool("NEW_DEVICE_DETECTION", true),
+		NotificationSinkFile: common.GetEnvString("NOTIFICATION_SINK_FILE", ""),
+		TrustedProxies:       getEnvPrefixList("TRUSTED_PROXIES"),
+
+		// Purge Config
+		DeletedUserRetention: common.GetEnvDuration("DELETED_USER_RETENTION", 30*24*time.Hour),
+		PurgeInterval:        common.GetEnvDuration("PURGE_INTERVAL", time.Hour),
+		PurgeBatchSize:       common.GetEnvInt32("PURGE_BATCH_SIZE", 100),
+
+		// Account Deletion Config
+		AccountDeletionGracePeriod: common.GetEnvDuration("ACCOUNT_DELETION_GRACE_PERIOD", 14*24*time.Hour),
+		AccountDeletionInterval:    common.GetEnvDuration("ACCOUNT_DELETION_INTERVAL", time.Hour),
+
+		// Event Config
+		UserEventsStream:       common.GetEnvString("USER_EVENTS_STREAM", "user.events"),
+		UserEventsStreamMaxLen: int64(common.GetEnvInt("USER_EVENTS_STREAM_MAX_LEN", 100000)),
+
+		// Email Config
+		EmailCanonicalizeProviders: getEnvBool("EMAIL_CANONICALIZE_PROVIDERS", false),
+		EmailChangeTokenTTL:        common.GetEnvDuration("EMAIL_CHANGE_TOKEN_TTL", 24*time.Hour),
+
+		// Avatar Config
+		AvatarStorageDir: common.GetEnvString("AVATAR_STORAGE_DIR", ""),
+		AvatarBaseURL:    common.GetEnvString("AVATAR_BASE_URL", "http://localhost:8080/media"),
+		AvatarMaxSize:    common.GetEnvInt("AVATAR_MAX_SIZE", 5<<20),
+
+		// Availability Check Config
+		AvailabilityCheckRateLimit:  common.GetEnvInt("AVAILABILITY_CHECK_RATE_LIMIT", 20),
+		AvailabilityCheckRateWindow: common.GetEnvDuration("AVAILABILITY_CHECK_RATE_WINDOW", time.Minute),
+
+		// Pagination Config
+		PageTokenSecret: common.GetEnvString("PAGE_TOKEN_SECRET", ""),
 
 		Redis: db.RedisConfig{
 			Addr:     common.GetEnvString("REDIS_ADDR", "localhost:6379"),
@@ -53,3 +200,125 @@ func Load() *Config {
 		},
 	}
 }
+
+// getEnvBool reads a boolean environment variable, falling back to defaultValue when unset or invalid
+func getEnvBool(key string, defaultValue bool) bool {
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		return defaultValue
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return defaultValue
+	}
+	return parsed
+}
+
+// getEnvList reads a comma-separated list, dropping empty entries
+func getEnvList(key, defaultValue string) []string {
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		value = defaultValue
+	}
+
+	var result []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// getEnvPrefixList parses comma-separated CIDR networks; a plain IP address is a single-host network.
+// Invalid entries are skipped with a warning.
+func getEnvPrefixList(key string) []netip.Prefix {
+	var result []netip.Prefix
+	for _, item := range getEnvList(key, "") {
+		prefix, err := netip.ParsePrefix(item)
+		if err != nil {
+			addr, addrErr := netip.ParseAddr(item)
+			if addrErr != nil {
+				log.Printf("Ignoring invalid %s entry %q", key, item)
+				continue
+			}
+			prefix = netip.PrefixFrom(addr, addr.BitLen())
+		}
+		result = append(result, prefix.Masked())
+	}
+	return result
+}
+
+// getEnvDurationMap parses "key=duration" pairs separated by commas (e.g. "DeleteUser=5m,UpdateUser=10m").
+// Invalid entries are skipped with a warning.
+func getEnvDurationMap(key, defaultValue string) map[string]time.Duration {
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		value = defaultValue
+	}
+
+	result := make(map[string]time.Duration)
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		name, durationStr, found := strings.Cut(pair, "=")
+		duration, err := time.ParseDuration(strings.TrimSpace(durationStr))
+		if !found || err != nil || duration <= 0 {
+			log.Printf("Ignoring invalid %s entry %q", key, pair)
+			continue
+		}
+		result[strings.TrimSpace(name)] = duration
+	}
+	return result
+}
+
+// getEnvSecretMap parses "name=secret" pairs separated by commas; entries without a secret are skipped
+func getEnvSecretMap(key string) map[string]string {
+	result := make(map[string]string)
+	for _, pair := range strings.Split(os.Getenv(key), ",") {
+		name, secret, found := strings.Cut(strings.TrimSpace(pair), "=")
+		if !found || strings.TrimSpace(name) == "" || secret == "" {
+			if strings.TrimSpace(pair) != "" {
+				log.Printf("Ignoring invalid %s entry for %q", key, strings.TrimSpace(name))
+			}
+			continue
+		}
+		result[strings.TrimSpace(name)] = secret
+	}
+	return result
+}
+
+// getEnvSessionPolicies parses per-client session policies formatted as
+// "client=idle/max" pairs separated by commas (e.g. "web=24h/720h,mobile=720h/4320h").
+// Invalid entries are skipped with a warning.
+func getEnvSessionPolicies(key string) map[string]auth.SessionPolicy {
+	result := make(map[string]auth.SessionPolicy)
+	for _, pair := range strings.Split(os.Getenv(key), ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		clientID, durations, found := strings.Cut(pair, "=")
+		idleStr, maxStr, hasMax := strings.Cut(durations, "/")
+		idle, idleErr := time.ParseDuration(strings.TrimSpace(idleStr))
+		if !found || !hasMax || idleErr != nil || idle <= 0 {
+			log.Printf("Ignoring invalid %s entry %q", key, pair)
+			continue
+		}
+		maxLifetime, err := time.ParseDuration(strings.TrimSpace(maxStr))
+		if err != nil || maxLifetime < 0 {
+			log.Printf("Ignoring invalid %s entry %q", key, pair)
+			continue
+		}
+
+		result[strings.TrimSpace(clientID)] = auth.SessionPolicy{
+			IdleTimeout: idle,
+			MaxLifetime: maxLifetime,
+		}
+	}
+	return result
+}
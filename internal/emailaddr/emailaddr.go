@@ -0,0 +1,162 @@
+package emailaddr
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+// Length limits of RFC 5321
+const (
+	maxLocalLength   = 64
+	maxAddressLength = 254
+)
+
+// ErrInvalid is returned for strings that are not a plain email address
+var ErrInvalid = errors.New("invalid email address")
+
+// domainProfile converts internationalized domains to their ASCII (punycode) form,
+// enforcing the IDNA lookup rules and DNS length limits
+var domainProfile = idna.New(
+	idna.MapForLookup(),
+	idna.VerifyDNSLength(true),
+	idna.BidiRule(),
+)
+
+// Address is a parsed, normalized email address
+type Address struct {
+	// Address is the address to store and send mail to: the local part as entered
+	// and the domain lower-cased in its ASCII (punycode) form
+	Address string
+
+	// Key identifies the account: the address with a case-folded local part and, when
+	// enabled, provider-specific canonicalization. Two addresses with the same key are
+	// the same identity.
+	Key string
+}
+
+// keyRulesVersion must be bumped whenever Parse computes a different key for the same input,
+// so stored keys are recomputed
+const keyRulesVersion = "1"
+
+// Normalizer parses and normalizes email addresses
+type Normalizer struct {
+	// CanonicalizeProviders applies the mailbox rules of well-known providers to the key,
+	// e.g. "J.Doe+news@googlemail.com" and "jdoe@gmail.com" get the same key
+	CanonicalizeProviders bool
+}
+
+// KeyRules identifies how the normalizer computes keys; stored keys computed under
+// different rules must be recomputed
+func (n Normalizer) KeyRules() string {
+	if n.CanonicalizeProviders {
+		return keyRulesVersion + "+providers"
+	}
+	return keyRulesVersion
+}
+
+// Parse validates raw as a single RFC 5322 address without display name or comments
+// and returns its normalized forms
+func (n Normalizer) Parse(raw string) (Address, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return Address{}, fmt.Errorf("%w: empty", ErrInvalid)
+	}
+	if strings.ContainsAny(raw, "<>()") {
+		return Address{}, fmt.Errorf("%w: only the address itself is allowed", ErrInvalid)
+	}
+
+	parsed, err := mail.ParseAddress(raw)
+	if err != nil || parsed.Name != "" {
+		return Address{}, fmt.Errorf("%w: malformed", ErrInvalid)
+	}
+
+	at := strings.LastIndex(parsed.Address, "@")
+	if at <= 0 || at == len(parsed.Address)-1 {
+		return Address{}, fmt.Errorf("%w: malformed", ErrInvalid)
+	}
+	local, domain := parsed.Address[:at], parsed.Address[at+1:]
+
+	if strings.HasPrefix(domain, "[") {
+		return Address{}, fmt.Errorf("%w: IP address literals are not allowed", ErrInvalid)
+	}
+	asciiDomain, err := domainProfile.ToASCII(domain)
+	if err != nil {
+		return Address{}, fmt.Errorf("%w: invalid domain", ErrInvalid)
+	}
+	asciiDomain = strings.ToLower(strings.TrimSuffix(asciiDomain, "."))
+	if !strings.Contains(asciiDomain, ".") {
+		return Address{}, fmt.Errorf("%w: domain must be fully qualified", ErrInvalid)
+	}
+
+	// Quoted local parts come back unquoted; only accept those that are valid without quotes
+	if _, err := mail.ParseAddress(local + "@" + asciiDomain); err != nil {
+		return Address{}, fmt.Errorf("%w: quoted local parts are not supported", ErrInvalid)
+	}
+	if len(local) > maxLocalLength {
+		return Address{}, fmt.Errorf("%w: local part too long", ErrInvalid)
+	}
+	address := local + "@" + asciiDomain
+	if len(address) > maxAddressLength {
+		return Address{}, fmt.Errorf("%w: too long", ErrInvalid)
+	}
+
+	keyLocal, keyDomain := strings.ToLower(local), asciiDomain
+	if n.CanonicalizeProviders {
+		keyLocal, keyDomain = canonicalizeProvider(keyLocal, keyDomain)
+	}
+
+	return Address{
+		Address: address,
+		Key:     keyLocal + "@" + keyDomain,
+	}, nil
+}
+
+// Parse parses raw with the default normalizer (no provider canonicalization)
+func Parse(raw string) (Address, error) {
+	return Normalizer{}.Parse(raw)
+}
+
+// providerRule describes how a mail provider maps addresses to mailboxes
+type providerRule struct {
+	domain     string // Canonical domain ("" = unchanged)
+	ignoreDots bool   // Dots in the local part are not significant
+	subaddress bool   // "+tag" suffixes deliver to the same mailbox
+}
+
+// providerRules lists providers whose aliasing rules are documented and stable
+var providerRules = map[string]providerRule{
+	"gmail.com":      {ignoreDots: true, subaddress: true},
+	"googlemail.com": {domain: "gmail.com", ignoreDots: true, subaddress: true},
+	"outlook.com":    {subaddress: true},
+	"hotmail.com":    {subaddress: true},
+	"live.com":       {subaddress: true},
+	"icloud.com":     {subaddress: true},
+	"fastmail.com":   {subaddress: true},
+	"proton.me":      {subaddress: true},
+	"protonmail.com": {subaddress: true},
+}
+
+// canonicalizeProvider applies the provider rule of domain to a lower-cased address
+func canonicalizeProvider(local, domain string) (string, string) {
+	rule, ok := providerRules[domain]
+	if !ok {
+		return local, domain
+	}
+
+	if rule.subaddress {
+		if plus := strings.Index(local, "+"); plus > 0 {
+			local = local[:plus]
+		}
+	}
+	if rule.ignoreDots {
+		local = strings.ReplaceAll(local, ".", "")
+	}
+	if rule.domain != "" {
+		domain = rule.domain
+	}
+	return local, domain
+}
@@ -0,0 +1,27 @@
+package events
+
+import (
+	"context"
+	"time"
+)
+
+// Event types
+const (
+	TypeUserDeleted = "user.deleted"
+
+	// TypeUserAnonymized is a tombstone: consumers must drop or anonymize copies of the user's PII
+	TypeUserAnonymized = "user.anonymized"
+)
+
+// Event is a domain event published for other services (e.g. the article service)
+type Event struct {
+	Type       string
+	UserID     int32
+	OccurredAt time.Time
+	Data       map[string]string
+}
+
+// Publisher delivers domain events to other services
+type Publisher interface {
+	Publish(ctx context.Context, event *Event) error
+}
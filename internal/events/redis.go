@@ -0,0 +1,52 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisStreamPublisher appends events to a Redis stream that consumers read with XREADGROUP
+type redisStreamPublisher struct {
+	client *redis.Client
+	stream string
+	maxLen int64
+}
+
+// NewRedisStreamPublisher creates a publisher writing to stream, trimmed to about maxLen entries (0 = untrimmed)
+func NewRedisStreamPublisher(client *redis.Client, stream string, maxLen int64) Publisher {
+	return &redisStreamPublisher{client: client, stream: stream, maxLen: maxLen}
+}
+
+// Publish adds the event as a stream entry with its data flattened into fields
+func (p *redisStreamPublisher) Publish(ctx context.Context, event *Event) error {
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+
+	values := map[string]any{
+		"type":        event.Type,
+		"user_id":     strconv.Itoa(int(event.UserID)),
+		"occurred_at": event.OccurredAt.UTC().Format(time.RFC3339),
+	}
+	for key, value := range event.Data {
+		values["data."+key] = value
+	}
+
+	args := &redis.XAddArgs{
+		Stream: p.stream,
+		Values: values,
+	}
+	if p.maxLen > 0 {
+		args.MaxLen = p.maxLen
+		args.Approx = true
+	}
+
+	if err := p.client.XAdd(ctx, args).Err(); err != nil {
+		return fmt.Errorf("publish %s event: %w", event.Type, err)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// fileNotifier appends notifications as JSON lines to a local file.
+// Intended for development, where no mail or push provider is configured.
+type fileNotifier struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileNotifier creates a notifier writing to path (created if missing)
+func NewFileNotifier(path string) Notifier {
+	return &fileNotifier{path: path}
+}
+
+// Notify appends the notification to the sink file
+func (n *fileNotifier) Notify(ctx context.Context, notification *Notification) error {
+	if notification.CreatedAt.IsZero() {
+		notification.CreatedAt = time.Now()
+	}
+
+	line, err := json.Marshal(notification)
+	if err != nil {
+		return fmt.Errorf("marshal notification: %w", err)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	file, err := os.OpenFile(n.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("open notification sink %s: %w", n.path, err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("write notification: %w", err)
+	}
+	return nil
+}
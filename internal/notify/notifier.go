@@ -0,0 +1,31 @@
+package notify
+
+import (
+	"context"
+	"time"
+)
+
+// Notification types
+const (
+	TypeNewDeviceLogin           = "security.new_device_login"
+	TypeAccountDeletionScheduled = "account.deletion_scheduled"
+	TypeEmailChangeConfirmation  = "account.email_change_confirmation"
+	TypeEmailChangeRequested     = "security.email_change_requested"
+	TypeEmailChanged             = "security.email_changed"
+)
+
+// Notification is a message to be delivered to a user
+type Notification struct {
+	Type      string            `json:"type"`
+	UserID    int32             `json:"user_id"`
+	Email     string            `json:"email"`
+	Subject   string            `json:"subject"`
+	Body      string            `json:"body"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+// Notifier delivers notifications to users (email, push, ...)
+type Notifier interface {
+	Notify(ctx context.Context, notification *Notification) error
+}
@@ -0,0 +1,52 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Limiter allows at most limit requests per key in fixed windows, counted in Redis
+// so the limit holds across service instances
+type Limiter struct {
+	redisClient *redis.Client
+	prefix      string
+	limit       int64
+	window      time.Duration
+}
+
+// NewLimiter creates a limiter whose Redis keys are "ratelimit:<prefix>:<key>"
+func NewLimiter(redisClient *redis.Client, prefix string, limit int, window time.Duration) *Limiter {
+	return &Limiter{
+		redisClient: redisClient,
+		prefix:      prefix,
+		limit:       int64(limit),
+		window:      window,
+	}
+}
+
+// Allow counts a request for key. When the limit is exceeded it returns false and the time
+// until the window resets.
+func (l *Limiter) Allow(ctx context.Context, key string) (bool, time.Duration, error) {
+	redisKey := fmt.Sprintf("ratelimit:%s:%s", l.prefix, key)
+
+	// SET NX starts a window with its expiry; INCR keeps the TTL of an existing key
+	pipe := l.redisClient.TxPipeline()
+	pipe.SetNX(ctx, redisKey, 0, l.window)
+	count := pipe.Incr(ctx, redisKey)
+	ttl := pipe.PTTL(ctx, redisKey)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return false, 0, fmt.Errorf("count request: %w", err)
+	}
+
+	if count.Val() > l.limit {
+		retryAfter := ttl.Val()
+		if retryAfter <= 0 {
+			retryAfter = l.window
+		}
+		return false, retryAfter, nil
+	}
+	return true, 0, nil
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// attributeSchemaPostgresRepo implement AttributeSchemaRepository with PostgreSQL
+type attributeSchemaPostgresRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewAttributeSchemaPostgresRepository create new instance
+func NewAttributeSchemaPostgresRepository(db *pgxpool.Pool) AttributeSchemaRepository {
+	return &attributeSchemaPostgresRepo{db: db}
+}
+
+// GetCurrent implement method to get the newest attribute schema
+func (r *attributeSchemaPostgresRepo) GetCurrent(ctx context.Context) (*AttributeSchema, error) {
+	query := `
+		SELECT version, schema, COALESCE(created_by, 0), created_at
+		FROM attribute_schemas
+		ORDER BY version DESC
+		LIMIT 1
+	`
+
+	var schema AttributeSchema
+	err := r.db.QueryRow(ctx, query).Scan(&schema.Version, &schema.Schema, &schema.CreatedBy, &schema.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrAttributeSchemaNotFound
+		}
+		return nil, fmt.Errorf("Query attribute schema failed: %w", err)
+	}
+
+	return &schema, nil
+}
+
+// Create implement method to store a new attribute schema version
+func (r *attributeSchemaPostgresRepo) Create(ctx context.Context, schema []byte, createdBy int32) (*AttributeSchema, error) {
+	query := `
+		INSERT INTO attribute_schemas (schema, created_by)
+		VALUES ($1, NULLIF($2, 0))
+		RETURNING version, schema, COALESCE(created_by, 0), created_at
+	`
+
+	var created AttributeSchema
+	err := r.db.QueryRow(ctx, query, string(schema), createdBy).Scan(&created.Version, &created.Schema, &created.CreatedBy, &created.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("Insert attribute schema failed: %w", err)
+	}
+
+	return &created, nil
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrAttributeSchemaNotFound is returned when no attribute schema has been defined yet
+var ErrAttributeSchemaNotFound = errors.New("attribute schema not found")
+
+// AttributeSchema is a version of the JSON Schema for custom profile attributes
+type AttributeSchema struct {
+	Version   int32
+	Schema    []byte // JSON document
+	CreatedBy int32
+	CreatedAt time.Time
+}
+
+// AttributeSchemaRepository defines the interface for attribute schema versions
+type AttributeSchemaRepository interface {
+	// GetCurrent returns the newest schema version
+	GetCurrent(ctx context.Context) (*AttributeSchema, error)
+
+	// Create stores a new schema version, which becomes current
+	Create(ctx context.Context, schema []byte, createdBy int32) (*AttributeSchema, error)
+}
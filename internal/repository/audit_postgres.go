@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// auditPostgresRepo implement AuditRepository with PostgreSQL
+type auditPostgresRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewAuditPostgresRepository create new instance
+func NewAuditPostgresRepository(db *pgxpool.Pool) AuditRepository {
+	return &auditPostgresRepo{db: db}
+}
+
+// Record implement method to append an audit entry
+func (r *auditPostgresRepo) Record(ctx context.Context, entry *AuditEntry) error {
+	query := `
+		INSERT INTO audit_log (actor_user_id, action, target_user_id, reason, metadata)
+		VALUES (NULLIF($1, 0), $2, NULLIF($3, 0), $4, $5)
+		RETURNING id, created_at
+	`
+
+	metadata := entry.Metadata
+	if metadata == nil {
+		metadata = map[string]any{}
+	}
+
+	err := r.db.QueryRow(ctx, query,
+		entry.ActorUserID,
+		entry.Action,
+		entry.TargetUserID,
+		entry.Reason,
+		metadata,
+	).Scan(&entry.ID, &entry.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("Insert audit entry failed: %w", err)
+	}
+
+	return nil
+}
+
+// ListByUser implement method to get a page of the audit entries involving a user.
+// IDs grow with insertion time, so they serve as the keyset.
+func (r *auditPostgresRepo) ListByUser(ctx context.Context, userID int32, afterID int64, limit int32) ([]*AuditEntry, error) {
+	query := `
+		SELECT id, COALESCE(actor_user_id, 0), action, COALESCE(target_user_id, 0), reason, metadata, created_at
+		FROM audit_log
+		WHERE (actor_user_id = $1 OR target_user_id = $1) AND id > $2
+		ORDER BY id
+		LIMIT $3
+	`
+
+	rows, err := r.db.Query(ctx, query, userID, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("Query audit entries failed: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*AuditEntry
+	for rows.Next() {
+		var entry AuditEntry
+		err := rows.Scan(
+			&entry.ID,
+			&entry.ActorUserID,
+			&entry.Action,
+			&entry.TargetUserID,
+			&entry.Reason,
+			&entry.Metadata,
+			&entry.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("Scan audit entry failed: %w", err)
+		}
+		entries = append(entries, &entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("Iterate audit entries failed: %w", err)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// Audit actions
+const (
+	AuditActionImpersonate = "user.impersonate"
+	AuditActionSuspend     = "user.suspend"
+	AuditActionReactivate  = "user.reactivate"
+	AuditActionDisable     = "user.disable"
+	AuditActionRestore     = "user.restore"
+	AuditActionExport      = "user.export"
+	AuditActionAnonymize   = "user.anonymize"
+
+	AuditActionIdentityLink   = "user.identity_link"
+	AuditActionIdentityUnlink = "user.identity_unlink"
+
+	AuditActionAttributeSchemaUpdate = "attribute_schema.update"
+)
+
+// AuditEntry is a single security-relevant action recorded in the audit trail
+type AuditEntry struct {
+	ID           int64
+	ActorUserID  int32 // 0 when performed by the system
+	Action       string
+	TargetUserID int32
+	Reason       string
+	Metadata     map[string]any
+	CreatedAt    time.Time
+}
+
+// AuditRepository defines the interface for the audit trail
+type AuditRepository interface {
+	// Record appends an entry to the audit trail
+	Record(ctx context.Context, entry *AuditEntry) error
+
+	// ListByUser returns up to limit entries where the user is the actor or the target,
+	// oldest first, starting after the entry with ID afterID (0 = from the beginning)
+	ListByUser(ctx context.Context, userID int32, afterID int64, limit int32) ([]*AuditEntry, error)
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// devicePostgresRepo implement DeviceRepository with PostgreSQL
+type devicePostgresRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewDevicePostgresRepository create new instance
+func NewDevicePostgresRepository(db *pgxpool.Pool) DeviceRepository {
+	return &devicePostgresRepo{db: db}
+}
+
+// Touch implement method to upsert a device in a single statement.
+// The count CTE reads the snapshot before the insert, so it excludes a newly added device.
+func (r *devicePostgresRepo) Touch(ctx context.Context, device *Device) (bool, int32, error) {
+	query := `
+		WITH known AS (
+			SELECT COUNT(*)::int AS total FROM user_devices WHERE user_id = $1
+		), upserted AS (
+			INSERT INTO user_devices (user_id, fingerprint, device_id, user_agent, ip_prefix)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (user_id, fingerprint)
+			DO UPDATE SET last_seen_at = CURRENT_TIMESTAMP, user_agent = EXCLUDED.user_agent, ip_prefix = EXCLUDED.ip_prefix
+			RETURNING id, first_seen_at, last_seen_at, (xmax = 0) AS inserted
+		)
+		SELECT upserted.id, upserted.first_seen_at, upserted.last_seen_at, upserted.inserted, known.total
+		FROM upserted, known
+	`
+
+	var isNew bool
+	var knownDevices int32
+	err := r.db.QueryRow(ctx, query,
+		device.UserID,
+		device.Fingerprint,
+		device.DeviceID,
+		device.UserAgent,
+		device.IPPrefix,
+	).Scan(&device.ID, &device.FirstSeenAt, &device.LastSeenAt, &isNew, &knownDevices)
+	if err != nil {
+		return false, 0, fmt.Errorf("Upsert device failed: %w", err)
+	}
+
+	return isNew, knownDevices, nil
+}
+
+// ListByUser implement method to get a user's known devices
+func (r *devicePostgresRepo) ListByUser(ctx context.Context, userID int32) ([]*Device, error) {
+	query := `
+		SELECT id, user_id, fingerprint, device_id, user_agent, ip_prefix, first_seen_at, last_seen_at
+		FROM user_devices
+		WHERE user_id = $1
+		ORDER BY last_seen_at DESC, id DESC
+	`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("Query devices failed: %w", err)
+	}
+	defer rows.Close()
+
+	var devices []*Device
+	for rows.Next() {
+		var device Device
+		err := rows.Scan(
+			&device.ID,
+			&device.UserID,
+			&device.Fingerprint,
+			&device.DeviceID,
+			&device.UserAgent,
+			&device.IPPrefix,
+			&device.FirstSeenAt,
+			&device.LastSeenAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("Scan device failed: %w", err)
+		}
+		devices = append(devices, &device)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("Iterate devices failed: %w", err)
+	}
+
+	return devices, nil
+}
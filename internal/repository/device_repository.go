@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// Device is a client a user has logged in from
+type Device struct {
+	ID          int64
+	UserID      int32
+	Fingerprint string
+	DeviceID    string
+	UserAgent   string
+	IPPrefix    string
+	FirstSeenAt time.Time
+	LastSeenAt  time.Time
+}
+
+// DeviceRepository defines the interface for a user's known devices
+type DeviceRepository interface {
+	// Touch stores the device or refreshes its last_seen_at. It reports whether the device
+	// was new and how many devices the user had before this call.
+	Touch(ctx context.Context, device *Device) (isNew bool, knownDevices int32, err error)
+
+	// ListByUser returns the user's known devices, most recently seen first
+	ListByUser(ctx context.Context, userID int32) ([]*Device, error)
+}
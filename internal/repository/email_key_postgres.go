@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// rekeyPageSize is the number of users read and re-keyed per round-trip
+const rekeyPageSize = 1000
+
+// emailKeyPostgresRepo implement EmailKeyRepository with PostgreSQL
+type emailKeyPostgresRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewEmailKeyPostgresRepository create new instance
+func NewEmailKeyPostgresRepository(db *pgxpool.Pool) EmailKeyRepository {
+	return &emailKeyPostgresRepo{db: db}
+}
+
+// Rekey implement method to recompute all email keys in one transaction. Keys are computed
+// page by page into a temporary table, checked for collisions and then applied at once.
+func (r *emailKeyPostgresRepo) Rekey(ctx context.Context, rules string, key EmailKeyFunc) (int, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("Begin transaction failed: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// The lock makes concurrently starting instances re-key one after the other
+	var storedRules string
+	if err := tx.QueryRow(ctx, `SELECT rules FROM email_key_state WHERE id FOR UPDATE`).Scan(&storedRules); err != nil {
+		return 0, fmt.Errorf("Lock email key state failed: %w", err)
+	}
+	if storedRules == rules {
+		return 0, nil
+	}
+
+	createQuery := `
+		CREATE TEMP TABLE email_rekey (
+			id          INTEGER PRIMARY KEY,
+			email_key   VARCHAR(255) NOT NULL,
+			pending_key VARCHAR(255)
+		) ON COMMIT DROP
+	`
+	if _, err := tx.Exec(ctx, createQuery); err != nil {
+		return 0, fmt.Errorf("Create re-key table failed: %w", err)
+	}
+
+	var afterID int32
+	for {
+		rows, err := tx.Query(ctx, `SELECT id, email, pending_email FROM users WHERE id > $1 ORDER BY id LIMIT $2`, afterID, rekeyPageSize)
+		if err != nil {
+			return 0, fmt.Errorf("Query users failed: %w", err)
+		}
+
+		var keys [][]any
+		var count int
+		for rows.Next() {
+			var id int32
+			var email string
+			var pendingEmail *string
+			if err := rows.Scan(&id, &email, &pendingEmail); err != nil {
+				rows.Close()
+				return 0, fmt.Errorf("Scan user failed: %w", err)
+			}
+			afterID = id
+			count++
+
+			emailKey, ok := key(email)
+			if !ok {
+				continue
+			}
+			// A NULL pending key keeps the stored one
+			var pendingKey any
+			if pendingEmail != nil {
+				if k, ok := key(*pendingEmail); ok {
+					pendingKey = k
+				}
+			}
+			keys = append(keys, []any{id, emailKey, pendingKey})
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return 0, fmt.Errorf("Iterate users failed: %w", err)
+		}
+
+		if _, err := tx.CopyFrom(ctx, pgx.Identifier{"email_rekey"}, []string{"id", "email_key", "pending_key"}, pgx.CopyFromRows(keys)); err != nil {
+			return 0, fmt.Errorf("Copy email keys failed: %w", err)
+		}
+		if count < rekeyPageSize {
+			break
+		}
+	}
+
+	// The unique index covers active users only; report every conflict at once
+	collisionQuery := `
+		SELECT string_agg(format('%s (user ids %s)', email_key, ids), '; ')
+		FROM (
+			SELECT COALESCE(k.email_key, u.email_normalized) AS email_key, string_agg(u.id::text, ', ' ORDER BY u.id) AS ids
+			FROM users u
+			LEFT JOIN email_rekey k ON k.id = u.id
+			WHERE u.deleted_at IS NULL
+			GROUP BY 1
+			HAVING COUNT(*) > 1
+		) duplicates
+	`
+	var collisions *string
+	if err := tx.QueryRow(ctx, collisionQuery).Scan(&collisions); err != nil {
+		return 0, fmt.Errorf("Check email key collisions failed: %w", err)
+	}
+	if collisions != nil {
+		return 0, fmt.Errorf("%w: %s", ErrEmailKeyCollision, *collisions)
+	}
+
+	updateQuery := `
+		UPDATE users u
+		SET email_normalized = k.email_key,
+			pending_email_normalized = COALESCE(k.pending_key, u.pending_email_normalized)
+		FROM email_rekey k
+		WHERE u.id = k.id
+			AND (u.email_normalized IS DISTINCT FROM k.email_key
+				OR u.pending_email_normalized IS DISTINCT FROM COALESCE(k.pending_key, u.pending_email_normalized))
+	`
+	result, err := tx.Exec(ctx, updateQuery)
+	if err != nil {
+		return 0, fmt.Errorf("Update email keys failed: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE email_key_state SET rules = $1, updated_at = NOW() WHERE id`, rules); err != nil {
+		return 0, fmt.Errorf("Update email key state failed: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("Commit email re-key failed: %w", err)
+	}
+
+	return int(result.RowsAffected()), nil
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrEmailKeyCollision is returned by Rekey when active users would share an email key
+var ErrEmailKeyCollision = errors.New("active users share an email key")
+
+// EmailKeyFunc computes the identity key of a stored email address; ok is false for
+// addresses the current rules cannot parse, whose stored key is kept
+type EmailKeyFunc func(email string) (key string, ok bool)
+
+// EmailKeyRepository maintains the stored email identity keys
+type EmailKeyRepository interface {
+	// Rekey recomputes the email and pending email keys of every user with key unless the
+	// stored keys were already computed under rules, and returns how many users changed.
+	// Nothing changes when active users would end up with the same key (ErrEmailKeyCollision).
+	Rekey(ctx context.Context, rules string, key EmailKeyFunc) (int, error)
+}
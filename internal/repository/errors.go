@@ -8,4 +8,13 @@ var (
 
 	// ErrEmailDuplicate
 	ErrEmailDuplicate = errors.New("email already exists")
+
+	// ErrUsernameDuplicate
+	ErrUsernameDuplicate = errors.New("username already exists")
+
+	// ErrUserAnonymized is returned by changes to a user whose PII has been erased
+	ErrUserAnonymized = errors.New("user has been anonymized")
+
+	// ErrInvalidOrder is returned by ParseUserOrder for unknown fields or directions
+	ErrInvalidOrder = errors.New("invalid order_by")
 )
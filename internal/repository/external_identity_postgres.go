@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// externalIdentityPostgresRepo implement ExternalIdentityRepository with PostgreSQL
+type externalIdentityPostgresRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewExternalIdentityPostgresRepository create new instance
+func NewExternalIdentityPostgresRepository(db *pgxpool.Pool) ExternalIdentityRepository {
+	return &externalIdentityPostgresRepo{db: db}
+}
+
+// Link implement method to link an identity in a single statement. The user row is share-locked
+// so a concurrent anonymization cannot miss the new identity. A conflicting row is locked and
+// returned unchanged, so its owner tells a repeated link from a taken identity.
+func (r *externalIdentityPostgresRepo) Link(ctx context.Context, userID int32, provider, subject string) error {
+	query := `
+		INSERT INTO user_external_identities (user_id, provider, subject)
+		SELECT id, $2, $3 FROM users
+		WHERE id = $1 AND deleted_at IS NULL AND anonymized_at IS NULL
+		FOR SHARE
+		ON CONFLICT (provider, subject) DO UPDATE SET user_id = user_external_identities.user_id
+		RETURNING user_id
+	`
+
+	var ownerID int32
+	if err := r.db.QueryRow(ctx, query, userID, provider, subject).Scan(&ownerID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrUserNotFound
+		}
+		return fmt.Errorf("Link external identity failed: %w", err)
+	}
+	if ownerID != userID {
+		return ErrExternalIdentityLinked
+	}
+
+	return nil
+}
+
+// Unlink implement method to remove an identity from a user
+func (r *externalIdentityPostgresRepo) Unlink(ctx context.Context, userID int32, provider, subject string) error {
+	query := `DELETE FROM user_external_identities WHERE user_id = $1 AND provider = $2 AND subject = $3`
+
+	result, err := r.db.Exec(ctx, query, userID, provider, subject)
+	if err != nil {
+		return fmt.Errorf("Unlink external identity failed: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrExternalIdentityNotFound
+	}
+
+	return nil
+}
+
+// FindUserID implement method to get the holder of an identity
+func (r *externalIdentityPostgresRepo) FindUserID(ctx context.Context, provider, subject string) (int32, error) {
+	query := `SELECT user_id FROM user_external_identities WHERE provider = $1 AND subject = $2`
+
+	var userID int32
+	if err := r.db.QueryRow(ctx, query, provider, subject).Scan(&userID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, ErrUserNotFound
+		}
+		return 0, fmt.Errorf("Query external identity failed: %w", err)
+	}
+
+	return userID, nil
+}
+
+// ListByUser implement method to get a user's identities
+func (r *externalIdentityPostgresRepo) ListByUser(ctx context.Context, userID int32) ([]*ExternalIdentity, error) {
+	query := `
+		SELECT user_id, provider, subject, created_at
+		FROM user_external_identities
+		WHERE user_id = $1
+		ORDER BY created_at, provider, subject
+	`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("Query external identities failed: %w", err)
+	}
+	defer rows.Close()
+
+	var identities []*ExternalIdentity
+	for rows.Next() {
+		var identity ExternalIdentity
+		if err := rows.Scan(&identity.UserID, &identity.Provider, &identity.Subject, &identity.CreatedAt); err != nil {
+			return nil, fmt.Errorf("Scan external identity failed: %w", err)
+		}
+		identities = append(identities, &identity)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("Iterate external identities failed: %w", err)
+	}
+
+	return identities, nil
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrExternalIdentityLinked is returned when the identity is already linked to another user
+	ErrExternalIdentityLinked = errors.New("external identity is linked to another user")
+
+	// ErrExternalIdentityNotFound is returned when the identity is not linked to the user
+	ErrExternalIdentityNotFound = errors.New("external identity not found")
+)
+
+// ExternalIdentity is an account of a user at an external identity provider
+type ExternalIdentity struct {
+	UserID    int32
+	Provider  string
+	Subject   string
+	CreatedAt time.Time
+}
+
+// ExternalIdentityRepository defines the interface for users' external identities.
+// Providers are stored in lower case; subjects are matched exactly.
+type ExternalIdentityRepository interface {
+	// Link links the identity to an active, not anonymized user. Linking it again to the same
+	// user is a no-op; ErrExternalIdentityLinked when another user holds it, ErrUserNotFound
+	// when the user cannot be linked.
+	Link(ctx context.Context, userID int32, provider, subject string) error
+
+	// Unlink removes the identity from the user; ErrExternalIdentityNotFound when it is not linked
+	Unlink(ctx context.Context, userID int32, provider, subject string) error
+
+	// FindUserID returns the ID of the user holding the identity; ErrUserNotFound when none does
+	FindUserID(ctx context.Context, provider, subject string) (int32, error)
+
+	// ListByUser returns the user's identities, oldest first
+	ListByUser(ctx context.Context, userID int32) ([]*ExternalIdentity, error)
+}
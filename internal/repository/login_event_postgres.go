@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// loginEventPostgresRepo implement LoginEventRepository with PostgreSQL
+type loginEventPostgresRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewLoginEventPostgresRepository create new instance
+func NewLoginEventPostgresRepository(db *pgxpool.Pool) LoginEventRepository {
+	return &loginEventPostgresRepo{db: db}
+}
+
+// Record implement method to store a login event (and last_login_at for successful logins)
+func (r *loginEventPostgresRepo) Record(ctx context.Context, event *LoginEvent) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("Begin transaction failed: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	insertQuery := `
+		INSERT INTO login_events (user_id, email, event_type, outcome, failure_reason, ip_address, user_agent)
+		VALUES (NULLIF($1, 0), $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at
+	`
+	err = tx.QueryRow(ctx, insertQuery,
+		event.UserID,
+		event.Email,
+		event.EventType,
+		event.Outcome,
+		event.FailureReason,
+		event.IPAddress,
+		event.UserAgent,
+	).Scan(&event.ID, &event.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("Insert login event failed: %w", err)
+	}
+
+	if event.UserID != 0 && event.EventType == LoginEventLogin && event.Outcome == LoginOutcomeSuccess {
+		updateQuery := `UPDATE users SET last_login_at = $1 WHERE id = $2`
+		if _, err := tx.Exec(ctx, updateQuery, event.CreatedAt, event.UserID); err != nil {
+			return fmt.Errorf("Update last login failed: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("Commit login event failed: %w", err)
+	}
+
+	return nil
+}
+
+// ListByUser implement method to get a page of a user's login events
+func (r *loginEventPostgresRepo) ListByUser(ctx context.Context, userID int32, limit, offset int32) ([]*LoginEvent, int32, error) {
+	query := `
+		SELECT id, COALESCE(user_id, 0), email, event_type, outcome, failure_reason, ip_address, user_agent, created_at
+		FROM login_events
+		WHERE user_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.Query(ctx, query, userID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("Query login events failed: %w", err)
+	}
+	events, err := scanLoginEvents(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	countQuery := `SELECT COUNT(*) FROM login_events WHERE user_id = $1`
+	var total int32
+	if err := r.db.QueryRow(ctx, countQuery, userID).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("Count login events failed: %w", err)
+	}
+
+	return events, total, nil
+}
+
+// ListByUserBefore implement method to get a page of a user's login events by ID keyset.
+// IDs grow with insertion time, so they serve as the keyset.
+func (r *loginEventPostgresRepo) ListByUserBefore(ctx context.Context, userID int32, beforeID int64, limit int32) ([]*LoginEvent, error) {
+	query := `
+		SELECT id, COALESCE(user_id, 0), email, event_type, outcome, failure_reason, ip_address, user_agent, created_at
+		FROM login_events
+		WHERE user_id = $1 AND ($2 = 0 OR id < $2)
+		ORDER BY id DESC
+		LIMIT $3
+	`
+
+	rows, err := r.db.Query(ctx, query, userID, beforeID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("Query login events failed: %w", err)
+	}
+	return scanLoginEvents(rows)
+}
+
+// scanLoginEvents reads and closes rows of login event columns
+func scanLoginEvents(rows pgx.Rows) ([]*LoginEvent, error) {
+	defer rows.Close()
+
+	var events []*LoginEvent
+	for rows.Next() {
+		var event LoginEvent
+		err := rows.Scan(
+			&event.ID,
+			&event.UserID,
+			&event.Email,
+			&event.EventType,
+			&event.Outcome,
+			&event.FailureReason,
+			&event.IPAddress,
+			&event.UserAgent,
+			&event.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("Scan login event failed: %w", err)
+		}
+		events = append(events, &event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("Iterate login events failed: %w", err)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// Login event types
+const (
+	LoginEventLogin   = "login"
+	LoginEventRefresh = "refresh"
+
+	// LoginEventNewDevice is a security event for a successful login from an unknown device
+	LoginEventNewDevice = "new_device"
+)
+
+// Login event outcomes
+const (
+	LoginOutcomeSuccess = "success"
+	LoginOutcomeFailure = "failure"
+)
+
+// LoginEvent is a single login, token refresh or failed login attempt
+type LoginEvent struct {
+	ID            int64
+	UserID        int32 // 0 when the email is not registered
+	Email         string
+	EventType     string
+	Outcome       string
+	FailureReason string
+	IPAddress     string
+	UserAgent     string
+	CreatedAt     time.Time
+}
+
+// LoginEventRepository defines the interface for login history
+type LoginEventRepository interface {
+	// Record stores an event; a successful login also updates the user's last_login_at
+	Record(ctx context.Context, event *LoginEvent) error
+
+	// ListByUser returns a user's events, newest first, with the total count
+	ListByUser(ctx context.Context, userID int32, limit, offset int32) ([]*LoginEvent, int32, error)
+
+	// ListByUserBefore returns up to limit of a user's events, newest first, starting before the
+	// event with ID beforeID (0 = from the newest). Events recorded meanwhile never shift the pages.
+	ListByUserBefore(ctx context.Context, userID int32, beforeID int64, limit int32) ([]*LoginEvent, error)
+}
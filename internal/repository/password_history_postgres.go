@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// passwordHistoryPostgresRepo implement PasswordHistoryRepository with PostgreSQL
+type passwordHistoryPostgresRepo struct {
+	db *pgxpool.Pool
+}
+
+// NewPasswordHistoryPostgresRepository create new instance
+func NewPasswordHistoryPostgresRepository(db *pgxpool.Pool) PasswordHistoryRepository {
+	return &passwordHistoryPostgresRepo{db: db}
+}
+
+// GetRecent implement method to get the newest password hashes of a user
+func (r *passwordHistoryPostgresRepo) GetRecent(ctx context.Context, userID int32, limit int) ([]string, error) {
+	query := `
+		SELECT password_hash
+		FROM password_history
+		WHERE user_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2
+	`
+
+	rows, err := r.db.Query(ctx, query, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("Query password history failed: %w", err)
+	}
+	defer rows.Close()
+
+	var hashes []string
+	for rows.Next() {
+		var hash string
+		if err := rows.Scan(&hash); err != nil {
+			return nil, fmt.Errorf("Scan password history failed: %w", err)
+		}
+		hashes = append(hashes, hash)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("Iterate password history failed: %w", err)
+	}
+
+	return hashes, nil
+}
+
+// Add implement method to record a password hash and prune old entries in one transaction
+func (r *passwordHistoryPostgresRepo) Add(ctx context.Context, userID int32, passwordHash string, keep int) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("Begin transaction failed: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	insertQuery := `INSERT INTO password_history (user_id, password_hash) VALUES ($1, $2)`
+	if _, err := tx.Exec(ctx, insertQuery, userID, passwordHash); err != nil {
+		return fmt.Errorf("Insert password history failed: %w", err)
+	}
+
+	// Keep only the newest entries for this user
+	pruneQuery := `
+		DELETE FROM password_history
+		WHERE user_id = $1
+		  AND id NOT IN (
+			SELECT id FROM password_history
+			WHERE user_id = $1
+			ORDER BY created_at DESC, id DESC
+			LIMIT $2
+		  )
+	`
+	if _, err := tx.Exec(ctx, pruneQuery, userID, keep); err != nil {
+		return fmt.Errorf("Prune password history failed: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("Commit password history failed: %w", err)
+	}
+
+	return nil
+}
+
+// ListChanges implement method to get the times a user's retained passwords were set
+func (r *passwordHistoryPostgresRepo) ListChanges(ctx context.Context, userID int32) ([]time.Time, error) {
+	query := `
+		SELECT created_at
+		FROM password_history
+		WHERE user_id = $1
+		ORDER BY created_at DESC, id DESC
+	`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("Query password history failed: %w", err)
+	}
+	defer rows.Close()
+
+	var changes []time.Time
+	for rows.Next() {
+		var changedAt time.Time
+		if err := rows.Scan(&changedAt); err != nil {
+			return nil, fmt.Errorf("Scan password history failed: %w", err)
+		}
+		changes = append(changes, changedAt)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("Iterate password history failed: %w", err)
+	}
+
+	return changes, nil
+}
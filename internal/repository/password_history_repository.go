@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// PasswordHistoryRepository defines the interface for previous password hashes
+type PasswordHistoryRepository interface {
+	// GetRecent returns the most recent password hashes of a user, newest first
+	GetRecent(ctx context.Context, userID int32, limit int) ([]string, error)
+
+	// Add records a password hash and prunes entries beyond the newest keep hashes
+	Add(ctx context.Context, userID int32, passwordHash string, keep int) error
+
+	// ListChanges returns when the user's retained passwords were set, newest first
+	ListChanges(ctx context.Context, userID int32) ([]time.Time, error)
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+)
+
+// queryBuilder composes a WHERE clause from conditions with positional parameters.
+// Values are always sent as query arguments, never interpolated into the SQL text.
+type queryBuilder struct {
+	conditions []string
+	args       []any
+}
+
+// where adds a condition ANDed with the others. Each "?" in condition is replaced by the
+// parameter placeholder of the next value, so condition must contain one "?" per value.
+func (b *queryBuilder) where(condition string, values ...any) {
+	if strings.Count(condition, "?") != len(values) {
+		panic(fmt.Sprintf("queryBuilder: %q expects %d values, got %d", condition, strings.Count(condition, "?"), len(values)))
+	}
+
+	var sb strings.Builder
+	for _, value := range values {
+		before, after, _ := strings.Cut(condition, "?")
+		sb.WriteString(before)
+		sb.WriteString(b.arg(value))
+		condition = after
+	}
+	sb.WriteString(condition)
+
+	b.conditions = append(b.conditions, sb.String())
+}
+
+// arg adds a value and returns its placeholder, for parts of the query outside the WHERE clause
+func (b *queryBuilder) arg(value any) string {
+	b.args = append(b.args, value)
+	return fmt.Sprintf("$%d", len(b.args))
+}
+
+// whereClause returns the ANDed conditions ("TRUE" when there are none)
+func (b *queryBuilder) whereClause() string {
+	if len(b.conditions) == 0 {
+		return "TRUE"
+	}
+	return strings.Join(b.conditions, " AND ")
+}
+
+// likeEscaper escapes LIKE wildcards so user input matches literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// containsPattern returns an (I)LIKE pattern matching values that contain s
+func containsPattern(s string) string {
+	return "%" + likeEscaper.Replace(s) + "%"
+}
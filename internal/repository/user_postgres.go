@@ -2,16 +2,19 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
 	"time"
 
+	"github.com/thatlq1812/service-1-user/internal/emailaddr"
 	pb "github.com/thatlq1812/service-1-user/proto"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
 // userPostgresRepo implement User repository with PostgresSQL
@@ -24,24 +27,122 @@ func NewUserPostgresRepository(db *pgxpool.Pool) UserRepository {
 	return &userPostgresRepo{db: db}
 }
 
-// GetByID implement method with user by ID
-func (r *userPostgresRepo) GetByID(ctx context.Context, id int32) (*pb.User, error) {
-	query := `
-	SELECT id, name, email, created_at
-	FROM users
-	WHERE id = $1
-	`
+// userColumns lists the users columns read by scanUser, in scan order
+const userColumns = "id, name, email, role, created_at, updated_at, last_login_at, status, status_reason, status_expires_at, deleted_at, deletion_scheduled_at, anonymized_at, attributes, COALESCE(avatar_url, ''), CASE WHEN pending_email_expires_at > NOW() THEN pending_email ELSE '' END, COALESCE(username, '')"
+
+// usernameIndex is the unique index on active usernames
+const usernameIndex = "idx_users_username_active"
+
+// duplicateError maps a unique violation to the error of the identifier that is already taken
+func duplicateError(pgErr *pgconn.PgError) error {
+	if pgErr.ConstraintName == usernameIndex {
+		return ErrUsernameDuplicate
+	}
+	return ErrEmailDuplicate
+}
+
+// userStatuses maps the users.status column to the API enum
+var userStatuses = map[string]pb.UserStatus{
+	"active":    pb.UserStatus_USER_STATUS_ACTIVE,
+	"suspended": pb.UserStatus_USER_STATUS_SUSPENDED,
+	"disabled":  pb.UserStatus_USER_STATUS_DISABLED,
+	"pending":   pb.UserStatus_USER_STATUS_PENDING,
+}
 
+// statusColumnValue returns the users.status value of an API status
+func statusColumnValue(status pb.UserStatus) (string, bool) {
+	for value, candidate := range userStatuses {
+		if candidate == status {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// scanUser scans a row selected with userColumns into a pb.User.
+// Extra destinations are scanned from the columns following userColumns.
+// A suspension whose expiry has passed is reported as active.
+func scanUser(row pgx.Row, extra ...any) (*pb.User, error) {
 	var user pb.User
 	var createdAt time.Time
+	var updatedAt, lastLoginAt, statusExpiresAt, deletedAt, deletionScheduledAt, anonymizedAt *time.Time
+	var status, statusReason string
+	var attributes map[string]any
 
-	err := r.db.QueryRow(ctx, query, id).Scan(
+	dest := append([]any{
 		&user.Id,
 		&user.Name,
 		&user.Email,
+		&user.Role,
 		&createdAt,
-	)
+		&updatedAt,
+		&lastLoginAt,
+		&status,
+		&statusReason,
+		&statusExpiresAt,
+		&deletedAt,
+		&deletionScheduledAt,
+		&anonymizedAt,
+		&attributes,
+		&user.AvatarUrl,
+		&user.PendingEmail,
+		&user.Username,
+	}, extra...)
 
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	// Convert time.Time to string
+	user.CreatedAt = createdAt.Format(time.RFC3339)
+	if updatedAt != nil {
+		user.UpdatedAt = updatedAt.Format(time.RFC3339)
+	}
+	if lastLoginAt != nil {
+		user.LastLoginAt = lastLoginAt.Format(time.RFC3339)
+	}
+
+	if deletedAt != nil {
+		user.DeletedAt = deletedAt.Format(time.RFC3339)
+	}
+	if deletionScheduledAt != nil {
+		user.DeletionScheduledAt = deletionScheduledAt.Format(time.RFC3339)
+	}
+	if anonymizedAt != nil {
+		user.AnonymizedAt = anonymizedAt.Format(time.RFC3339)
+	}
+
+	if len(attributes) > 0 {
+		converted, err := structpb.NewStruct(attributes)
+		if err != nil {
+			return nil, fmt.Errorf("Convert attributes failed: %w", err)
+		}
+		user.Attributes = converted
+	}
+
+	user.Status = userStatuses[status]
+	if user.Status == pb.UserStatus_USER_STATUS_SUSPENDED && statusExpiresAt != nil && !statusExpiresAt.After(time.Now()) {
+		user.Status = pb.UserStatus_USER_STATUS_ACTIVE
+	}
+	if user.Status != pb.UserStatus_USER_STATUS_ACTIVE {
+		user.StatusReason = statusReason
+		if statusExpiresAt != nil {
+			user.SuspendedUntil = statusExpiresAt.Format(time.RFC3339)
+		}
+	}
+
+	return &user, nil
+}
+
+// GetByID implement method with user by ID
+func (r *userPostgresRepo) GetByID(ctx context.Context, id int32) (*pb.User, error) {
+	query := `
+	SELECT ` + userColumns + `
+	FROM users
+	WHERE id = $1 AND deleted_at IS NULL
+	`
+
+	user, err := scanUser(r.db.QueryRow(ctx, query, id))
 	if err != nil {
 		// No row
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -51,94 +152,111 @@ func (r *userPostgresRepo) GetByID(ctx context.Context, id int32) (*pb.User, err
 		return nil, fmt.Errorf("Query user failed: %w", err)
 	}
 
-	// Convert time.Time to string
-	user.CreatedAt = createdAt.Format(time.RFC3339)
-
-	return &user, nil
+	return user, nil
 }
 
-// Create implement method to create new user
-func (r *userPostgresRepo) Create(ctx context.Context, name, email string) (*pb.User, error) {
+// GetByIDs implement method to get active users by IDs in a single query (order is unspecified)
+func (r *userPostgresRepo) GetByIDs(ctx context.Context, ids []int32) ([]*pb.User, error) {
 	query := `
-		INSERT INTO users (name, email)
-		VALUES ($1, $2)
-		RETURNING id, name, email, created_at
+	SELECT ` + userColumns + `
+	FROM users
+	WHERE id = ANY($1) AND deleted_at IS NULL
 	`
 
-	var user pb.User
-	var createdAt time.Time
+	rows, err := r.db.Query(ctx, query, ids)
+	if err != nil {
+		return nil, fmt.Errorf("Query users by IDs failed: %w", err)
+	}
+	defer rows.Close()
 
-	err := r.db.QueryRow(ctx, query, name, email).Scan(
-		&user.Id,
-		&user.Name,
-		&user.Email,
-		&createdAt,
-	)
+	var users []*pb.User
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, fmt.Errorf("Scan user failed: %w", err)
+		}
+
+		users = append(users, user)
+	}
 
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("Iterate users failed: %w", err)
+	}
+
+	return users, nil
+}
+
+// Create implement method to create new user
+func (r *userPostgresRepo) Create(ctx context.Context, name string, email emailaddr.Address, username string) (*pb.User, error) {
+	query := `
+		INSERT INTO users (name, email, email_normalized, username)
+		VALUES ($1, $2, $3, NULLIF($4, ''))
+		RETURNING ` + userColumns + `
+	`
+
+	user, err := scanUser(r.db.QueryRow(ctx, query, name, email.Address, email.Key, username))
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
-			return nil, ErrEmailDuplicate
+			return nil, duplicateError(pgErr)
 		}
 		return nil, fmt.Errorf("Insert user failed: %w", err)
 	}
 
-	user.CreatedAt = createdAt.Format(time.RFC3339)
-
-	return &user, nil
+	return user, nil
 }
 
 // CreateWithPassword implement method to create new user with password
-func (r *userPostgresRepo) CreateWithPassword(ctx context.Context, name, email, passwordHash string) (*pb.User, error) {
+func (r *userPostgresRepo) CreateWithPassword(ctx context.Context, name string, email emailaddr.Address, username, passwordHash string) (*pb.User, error) {
 	query := `
-		INSERT INTO users (name, email, password_hash)
-		VALUES ($1, $2, $3)
-		RETURNING id, name, email, created_at
+		INSERT INTO users (name, email, email_normalized, username, password_hash)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5)
+		RETURNING ` + userColumns + `
 	`
 
-	var user pb.User
-	var createdAt time.Time
-
-	err := r.db.QueryRow(ctx, query, name, email, passwordHash).Scan(
-		&user.Id,
-		&user.Name,
-		&user.Email,
-		&createdAt,
-	)
-
+	user, err := scanUser(r.db.QueryRow(ctx, query, name, email.Address, email.Key, username, passwordHash))
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
-			return nil, ErrEmailDuplicate
+			return nil, duplicateError(pgErr)
 		}
 		return nil, fmt.Errorf("Insert user with password failed: %w", err)
 	}
 
-	user.CreatedAt = createdAt.Format(time.RFC3339)
+	return user, nil
+}
 
-	return &user, nil
+// GetByEmail implement method to get user by normalized email
+func (r *userPostgresRepo) GetByEmail(ctx context.Context, email emailaddr.Address) (*pb.User, error) {
+	query := `
+	SELECT ` + userColumns + `
+	FROM users
+	WHERE email_normalized = $1 AND deleted_at IS NULL
+	`
+
+	user, err := scanUser(r.db.QueryRow(ctx, query, email.Key))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("Query user by email failed: %w", err)
+	}
+
+	return user, nil
 }
 
 // GetByEmailWithPassword implement method to get user by email with password hash
-func (r *userPostgresRepo) GetByEmailWithPassword(ctx context.Context, email string) (*UserWithPassword, error) {
+func (r *userPostgresRepo) GetByEmailWithPassword(ctx context.Context, email emailaddr.Address) (*UserWithPassword, error) {
 	query := `
-	SELECT id, name, email, password_hash, created_at
+	SELECT ` + userColumns + `, COALESCE(password_hash, ''), password_rotation_required
 	FROM users
-	WHERE email = $1
+	WHERE email_normalized = $1 AND deleted_at IS NULL
 	`
 
-	var user pb.User
-	var createdAt time.Time
 	var passwordHash string
+	var rotationRequired bool
 
-	err := r.db.QueryRow(ctx, query, email).Scan(
-		&user.Id,
-		&user.Name,
-		&user.Email,
-		&passwordHash,
-		&createdAt,
-	)
-
+	user, err := scanUser(r.db.QueryRow(ctx, query, email.Key), &passwordHash, &rotationRequired)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrUserNotFound
@@ -146,57 +264,104 @@ func (r *userPostgresRepo) GetByEmailWithPassword(ctx context.Context, email str
 		return nil, fmt.Errorf("Query user by email failed: %w", err)
 	}
 
-	// Convert time.Time to string
-	user.CreatedAt = createdAt.Format(time.RFC3339)
-
 	return &UserWithPassword{
-		User:         &user,
-		PasswordHash: passwordHash,
+		User:                     user,
+		PasswordHash:             passwordHash,
+		PasswordRotationRequired: rotationRequired,
 	}, nil
 }
 
-// Update implement method for update user
-func (r *userPostgresRepo) Update(ctx context.Context, id int32, name, email string) (*pb.User, error) {
+// GetByUsername implement method to get user by case-folded username
+func (r *userPostgresRepo) GetByUsername(ctx context.Context, username string) (*pb.User, error) {
 	query := `
-		UPDATE users
-		SET name = $1, email = $2
-		WHERE id = $3
-		RETURNING id, name, email, created_at
+	SELECT ` + userColumns + `
+	FROM users
+	WHERE username = $1 AND deleted_at IS NULL
 	`
 
-	var user pb.User
-	var createdAt time.Time
+	user, err := scanUser(r.db.QueryRow(ctx, query, username))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("Query user by username failed: %w", err)
+	}
 
-	err := r.db.QueryRow(ctx, query, name, email, id).Scan(
-		&user.Id,
-		&user.Name,
-		&user.Email,
-		&createdAt,
-	)
+	return user, nil
+}
 
+// GetByUsernameWithPassword implement method to get user by username with password hash
+func (r *userPostgresRepo) GetByUsernameWithPassword(ctx context.Context, username string) (*UserWithPassword, error) {
+	query := `
+	SELECT ` + userColumns + `, COALESCE(password_hash, ''), password_rotation_required
+	FROM users
+	WHERE username = $1 AND deleted_at IS NULL
+	`
+
+	var passwordHash string
+	var rotationRequired bool
+
+	user, err := scanUser(r.db.QueryRow(ctx, query, username), &passwordHash, &rotationRequired)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrUserNotFound
 		}
+		return nil, fmt.Errorf("Query user by username failed: %w", err)
+	}
+
+	return &UserWithPassword{
+		User:                     user,
+		PasswordHash:             passwordHash,
+		PasswordRotationRequired: rotationRequired,
+	}, nil
+}
+
+// Update implement method for update user
+func (r *userPostgresRepo) Update(ctx context.Context, id int32, name string, email emailaddr.Address) (*pb.User, error) {
+	query := `
+		UPDATE users
+		SET name = $1, email = $2, email_normalized = $3
+		WHERE id = $4 AND deleted_at IS NULL AND anonymized_at IS NULL
+		RETURNING ` + userColumns + `
+	`
+
+	user, err := scanUser(r.db.QueryRow(ctx, query, name, email.Address, email.Key, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, r.unchangeableUserError(ctx, id)
+		}
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
-			return nil, ErrEmailDuplicate
+			return nil, duplicateError(pgErr)
 		}
 		return nil, fmt.Errorf("Update user failed: %w", err)
 	}
 
-	user.CreatedAt = createdAt.Format(time.RFC3339)
-
-	return &user, nil
+	return user, nil
 
 }
 
-// PartialUpdate updates only the provided fields
-func (r *userPostgresRepo) PartialUpdate(ctx context.Context, id int32, name *string, email *string, password *string) (*pb.User, error) {
-	// First, get current user to verify it exists
-	_, err := r.GetByID(ctx, id)
+// PartialUpdate updates only the provided fields in a transaction holding the row lock,
+// so concurrent attribute merges apply one after the other instead of losing keys
+func (r *userPostgresRepo) PartialUpdate(ctx context.Context, id int32, name *string, username *string, email *emailaddr.Address, password *string, mergeAttributes AttributesMerge) (*pb.User, error) {
+	tx, err := r.db.Begin(ctx)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("Begin transaction failed: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// First, lock the current user to verify it exists and read the attributes to merge into
+	var current map[string]any
+	var anonymized bool
+	lockQuery := `SELECT attributes, anonymized_at IS NOT NULL FROM users WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`
+	if err := tx.QueryRow(ctx, lockQuery, id).Scan(&current, &anonymized); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("Lock user failed: %w", err)
+	}
+	if anonymized {
+		return nil, ErrUserAnonymized
 	}
 
 	// Build dynamic query
@@ -210,9 +375,19 @@ func (r *userPostgresRepo) PartialUpdate(ctx context.Context, id int32, name *st
 		argIndex++
 	}
 
+	if username != nil {
+		updates = append(updates, fmt.Sprintf("username = NULLIF($%d, '')", argIndex))
+		args = append(args, *username)
+		argIndex++
+	}
+
 	if email != nil {
 		updates = append(updates, fmt.Sprintf("email = $%d", argIndex))
-		args = append(args, *email)
+		args = append(args, email.Address)
+		argIndex++
+
+		updates = append(updates, fmt.Sprintf("email_normalized = $%d", argIndex))
+		args = append(args, email.Key)
 		argIndex++
 	}
 
@@ -220,6 +395,20 @@ func (r *userPostgresRepo) PartialUpdate(ctx context.Context, id int32, name *st
 		updates = append(updates, fmt.Sprintf("password_hash = $%d", argIndex))
 		args = append(args, *password)
 		argIndex++
+
+		// A new password satisfies any pending forced rotation
+		updates = append(updates, "password_rotation_required = FALSE")
+	}
+
+	if mergeAttributes != nil {
+		// Errors of the merge (e.g. schema violations) are returned unchanged
+		attributes, err := mergeAttributes(current)
+		if err != nil {
+			return nil, err
+		}
+		updates = append(updates, fmt.Sprintf("attributes = $%d", argIndex))
+		args = append(args, attributes)
+		argIndex++
 	}
 
 	// If no fields to update, return error
@@ -237,38 +426,75 @@ func (r *userPostgresRepo) PartialUpdate(ctx context.Context, id int32, name *st
 	query := fmt.Sprintf(`
 		UPDATE users
 		SET %s
-		WHERE id = $%d
-		RETURNING id, name, email, created_at, updated_at
-	`, strings.Join(updates, ", "), argIndex)
-
-	var updatedUser pb.User
-	var createdAt, updatedAt time.Time
-
-	err = r.db.QueryRow(ctx, query, args...).Scan(
-		&updatedUser.Id,
-		&updatedUser.Name,
-		&updatedUser.Email,
-		&createdAt,
-		&updatedAt,
-	)
+		WHERE id = $%d AND deleted_at IS NULL AND anonymized_at IS NULL
+		RETURNING %s
+	`, strings.Join(updates, ", "), argIndex, userColumns)
 
+	updatedUser, err := scanUser(tx.QueryRow(ctx, query, args...))
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
-			return nil, ErrEmailDuplicate
+			return nil, duplicateError(pgErr)
 		}
 		return nil, fmt.Errorf("PartialUpdate user failed: %w", err)
 	}
 
-	updatedUser.CreatedAt = createdAt.Format(time.RFC3339)
-	updatedUser.UpdatedAt = updatedAt.Format(time.RFC3339)
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("Commit user update failed: %w", err)
+	}
+
+	return updatedUser, nil
+}
+
+// SetAvatar implement method to replace or clear the avatar, returning the previous key prefix
+func (r *userPostgresRepo) SetAvatar(ctx context.Context, id int32, key, url string) (*pb.User, string, error) {
+	query := `
+		WITH previous AS (
+			SELECT COALESCE(avatar_key, '') AS previous_key
+			FROM users
+			WHERE id = $1 AND deleted_at IS NULL AND anonymized_at IS NULL
+			FOR UPDATE
+		)
+		UPDATE users
+		SET avatar_key = NULLIF($2, ''), avatar_url = NULLIF($3, ''), updated_at = NOW()
+		FROM previous
+		WHERE id = $1 AND deleted_at IS NULL AND anonymized_at IS NULL
+		RETURNING ` + userColumns + `, previous.previous_key
+	`
+
+	var previousKey string
+	user, err := scanUser(r.db.QueryRow(ctx, query, id, key, url), &previousKey)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, "", r.unchangeableUserError(ctx, id)
+		}
+		return nil, "", fmt.Errorf("Set avatar failed: %w", err)
+	}
+
+	return user, previousKey, nil
+}
 
-	return &updatedUser, nil
+// unchangeableUserError explains why a change matched no row: ErrUserAnonymized when the
+// user exists but has been anonymized, ErrUserNotFound otherwise
+func (r *userPostgresRepo) unchangeableUserError(ctx context.Context, id int32) error {
+	var anonymized bool
+	err := r.db.QueryRow(ctx, `SELECT anonymized_at IS NOT NULL FROM users WHERE id = $1`, id).Scan(&anonymized)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrUserNotFound
+		}
+		return fmt.Errorf("Query user failed: %w", err)
+	}
+	if anonymized {
+		return ErrUserAnonymized
+	}
+	return ErrUserNotFound
 }
 
-// Delete implement method to delete user by ID
+// Delete implement method to soft delete user by ID.
+// The row is kept until PurgeDeleted removes it after the retention period.
 func (r *userPostgresRepo) Delete(ctx context.Context, id int32) error {
-	query := `DELETE FROM users WHERE id = $1`
+	query := `UPDATE users SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL`
 
 	result, err := r.db.Exec(ctx, query, id)
 	if err != nil {
@@ -282,47 +508,575 @@ func (r *userPostgresRepo) Delete(ctx context.Context, id int32) error {
 	return nil
 }
 
-// List implement method to get list of all users
-func (r *userPostgresRepo) List(ctx context.Context, limit, offset int32) ([]*pb.User, int32, error) {
+// List implement method to get a page of users matching the filter, after a keyset cursor or at an offset
+func (r *userPostgresRepo) List(ctx context.Context, filter UserFilter, order UserOrder, page UserPage) ([]*pb.User, *UserCursor, error) {
+	column, ok := userOrderColumns[order.Field]
+	if !ok {
+		column = userOrderColumns["id"]
+	}
+
+	b := &queryBuilder{}
+	b.where("deleted_at IS NULL")
+	applyUserFilter(b, filter)
+
+	// Keyset condition: rows strictly after the cursor in sort order, served by the (column, id) indexes
+	if page.After != nil {
+		comparison := ">"
+		if order.Descending {
+			comparison = "<"
+		}
+		if column.cast == "" {
+			b.where("id "+comparison+" ?", page.After.ID)
+		} else {
+			b.where(fmt.Sprintf("(%s, id) %s (?::%s, ?)", column.expr, comparison, column.cast), page.After.Key, page.After.ID)
+		}
+	}
+
+	sortKey := "''"
+	if column.cast != "" {
+		sortKey = column.expr + "::text"
+	}
+
+	// One extra row tells whether another page follows
+	query := fmt.Sprintf(`
+		SELECT %s, %s
+		FROM users
+		WHERE %s
+		ORDER BY %s
+		LIMIT %s OFFSET %s
+	`, userColumns, sortKey, b.whereClause(), userOrderClause(order), b.arg(page.Limit+1), b.arg(page.Offset))
+
+	rows, err := r.db.Query(ctx, query, b.args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Query users failed: %w", err)
+	}
+	defer rows.Close()
+
+	var users []*pb.User
+	var keys []string
+
+	for rows.Next() {
+		var key string
+		user, err := scanUser(rows, &key)
+		if err != nil {
+			return nil, nil, fmt.Errorf("Scan user failed: %w", err)
+		}
+
+		users = append(users, user)
+		keys = append(keys, key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("Iterate users failed: %w", err)
+	}
+
+	if len(users) <= int(page.Limit) {
+		return users, nil, nil
+	}
+
+	users = users[:page.Limit]
+	last := len(users) - 1
+	return users, &UserCursor{Key: keys[last], ID: users[last].Id}, nil
+}
+
+// Count implement method to count users matching the filter exactly
+func (r *userPostgresRepo) Count(ctx context.Context, filter UserFilter) (int64, error) {
+	b := &queryBuilder{}
+	b.where("deleted_at IS NULL")
+	applyUserFilter(b, filter)
+
+	var total int64
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM users WHERE `+b.whereClause(), b.args...).Scan(&total); err != nil {
+		return 0, fmt.Errorf("Count users failed: %w", err)
+	}
+
+	return total, nil
+}
+
+// EstimateCount implement method to estimate the users matching the filter from the query planner
+func (r *userPostgresRepo) EstimateCount(ctx context.Context, filter UserFilter) (int64, error) {
+	b := &queryBuilder{}
+	b.where("deleted_at IS NULL")
+	applyUserFilter(b, filter)
+
+	var plan []byte
+	if err := r.db.QueryRow(ctx, `EXPLAIN (FORMAT JSON) SELECT 1 FROM users WHERE `+b.whereClause(), b.args...).Scan(&plan); err != nil {
+		return 0, fmt.Errorf("Explain users count failed: %w", err)
+	}
+
+	var explained []struct {
+		Plan struct {
+			Rows float64 `json:"Plan Rows"`
+		} `json:"Plan"`
+	}
+	if err := json.Unmarshal(plan, &explained); err != nil {
+		return 0, fmt.Errorf("Parse users count plan failed: %w", err)
+	}
+	if len(explained) == 0 {
+		return 0, errors.New("Parse users count plan failed: empty plan")
+	}
+
+	return int64(explained[0].Plan.Rows), nil
+}
+
+// orderColumn is a sortable column of List and the SQL type of its keyset cursor values
+type orderColumn struct {
+	expr string
+	cast string
+}
+
+// userOrderColumns maps the sortable fields of List to their columns
+var userOrderColumns = map[string]orderColumn{
+	"id":         {expr: "id"},
+	"name":       {expr: "name", cast: "text"},
+	"email":      {expr: "email_normalized", cast: "text"},
+	"created_at": {expr: "created_at", cast: "timestamp"},
+	"updated_at": {expr: "updated_at", cast: "timestamp"},
+}
+
+// userOrderClause returns the ORDER BY expression of order, with ID as tie-breaker
+func userOrderClause(order UserOrder) string {
+	direction := "ASC"
+	if order.Descending {
+		direction = "DESC"
+	}
+
+	column, ok := userOrderColumns[order.Field]
+	if !ok || column.cast == "" {
+		return "id " + direction
+	}
+	return column.expr + " " + direction + ", id " + direction
+}
+
+// applyUserFilter adds the conditions of filter to b
+func applyUserFilter(b *queryBuilder, filter UserFilter) {
+	if len(filter.Attributes) > 0 {
+		b.where("attributes @> ?", filter.Attributes)
+	}
+	if filter.Name != "" {
+		b.where("name ILIKE ?", containsPattern(filter.Name))
+	}
+	if filter.Email != "" {
+		b.where("email ILIKE ?", containsPattern(filter.Email))
+	}
+	if !filter.CreatedAfter.IsZero() {
+		b.where("created_at >= ?", filter.CreatedAfter)
+	}
+	if !filter.CreatedBefore.IsZero() {
+		b.where("created_at < ?", filter.CreatedBefore)
+	}
+	if !filter.UpdatedAfter.IsZero() {
+		b.where("updated_at >= ?", filter.UpdatedAfter)
+	}
+	if !filter.UpdatedBefore.IsZero() {
+		b.where("updated_at < ?", filter.UpdatedBefore)
+	}
+
+	// Expired suspensions are reported as active by scanUser, so filter on the same rule
+	switch filter.Status {
+	case pb.UserStatus_USER_STATUS_UNSPECIFIED:
+	case pb.UserStatus_USER_STATUS_ACTIVE:
+		b.where("(status = 'active' OR (status = 'suspended' AND status_expires_at <= NOW()))")
+	case pb.UserStatus_USER_STATUS_SUSPENDED:
+		b.where("status = 'suspended' AND (status_expires_at IS NULL OR status_expires_at > NOW())")
+	default:
+		value, _ := statusColumnValue(filter.Status)
+		b.where("status = ?", value)
+	}
+
+	if filter.Role != "" {
+		b.where("role = ?", filter.Role)
+	}
+}
+
+// SetPasswordRotationRequired implement method to flag or clear a forced password rotation
+func (r *userPostgresRepo) SetPasswordRotationRequired(ctx context.Context, id int32, required bool) error {
+	query := `UPDATE users SET password_rotation_required = $1 WHERE id = $2 AND deleted_at IS NULL`
+
+	result, err := r.db.Exec(ctx, query, required, id)
+	if err != nil {
+		return fmt.Errorf("Set password rotation flag failed: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrUserNotFound
+	}
+
+	return nil
+}
+
+// UpdateStatus implement method to change the account status of a user
+func (r *userPostgresRepo) UpdateStatus(ctx context.Context, id int32, status pb.UserStatus, reason string, expiresAt *time.Time) (*pb.User, error) {
+	value, ok := statusColumnValue(status)
+	if !ok {
+		return nil, fmt.Errorf("unknown user status %v", status)
+	}
+
+	query := `
+		UPDATE users
+		SET status = $1, status_reason = $2, status_expires_at = $3, status_changed_at = NOW(), updated_at = NOW()
+		WHERE id = $4 AND deleted_at IS NULL AND anonymized_at IS NULL
+		RETURNING ` + userColumns + `
+	`
+
+	user, err := scanUser(r.db.QueryRow(ctx, query, value, reason, expiresAt, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, r.unchangeableUserError(ctx, id)
+		}
+		return nil, fmt.Errorf("Update user status failed: %w", err)
+	}
+
+	return user, nil
+}
+
+// Restore implement method to undo a soft delete
+func (r *userPostgresRepo) Restore(ctx context.Context, id int32) (*pb.User, error) {
+	query := `
+		UPDATE users
+		SET deleted_at = NULL, updated_at = NOW()
+		WHERE id = $1 AND deleted_at IS NOT NULL AND anonymized_at IS NULL
+		RETURNING ` + userColumns + `
+	`
+
+	user, err := scanUser(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, r.unchangeableUserError(ctx, id)
+		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, duplicateError(pgErr)
+		}
+		return nil, fmt.Errorf("Restore user failed: %w", err)
+	}
+
+	return user, nil
+}
+
+// ListDeleted implement method to get soft-deleted users, most recently deleted first
+func (r *userPostgresRepo) ListDeleted(ctx context.Context, limit, offset int32) ([]*pb.User, int32, error) {
 	query := `
-		SELECT id, name, email, created_at
+		SELECT ` + userColumns + `
 		FROM users
-		ORDER BY id
+		WHERE deleted_at IS NOT NULL
+		ORDER BY deleted_at DESC, id
 		LIMIT $1 OFFSET $2
 	`
 
 	rows, err := r.db.Query(ctx, query, limit, offset)
 	if err != nil {
-		return nil, 0, fmt.Errorf("Query users failed: %w", err)
+		return nil, 0, fmt.Errorf("Query deleted users failed: %w", err)
 	}
 	defer rows.Close()
 
 	var users []*pb.User
 
 	for rows.Next() {
-		var user pb.User
-		var createdAt time.Time
-		err := rows.Scan(
-			&user.Id,
-			&user.Name,
-			&user.Email,
-			&createdAt,
-		)
+		user, err := scanUser(rows)
 		if err != nil {
 			return nil, 0, fmt.Errorf("Scan user failed: %w", err)
 		}
 
-		user.CreatedAt = createdAt.Format(time.RFC3339)
-		users = append(users, &user)
+		users = append(users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("Iterate deleted users failed: %w", err)
 	}
 
-	// Query for count total users
-	countQuery := `SELECT COUNT(*) FROM users`
+	countQuery := `SELECT COUNT(*) FROM users WHERE deleted_at IS NOT NULL`
 	var total int32
-	err = r.db.QueryRow(ctx, countQuery).Scan(&total)
-	if err != nil {
-		return nil, 0, fmt.Errorf("Count users failed: %w", err)
+	if err := r.db.QueryRow(ctx, countQuery).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("Count deleted users failed: %w", err)
 	}
 
 	return users, total, nil
 }
+
+// PurgeDeleted implement method to hard delete up to limit users soft-deleted before deletedBefore
+func (r *userPostgresRepo) PurgeDeleted(ctx context.Context, deletedBefore time.Time, limit int32) ([]int32, error) {
+	query := `
+		DELETE FROM users
+		WHERE id IN (
+			SELECT id FROM users
+			WHERE deleted_at IS NOT NULL AND deleted_at < $1
+			ORDER BY deleted_at
+			LIMIT $2
+		)
+		RETURNING id
+	`
+
+	rows, err := r.db.Query(ctx, query, deletedBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("Purge deleted users failed: %w", err)
+	}
+
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[int32])
+	if err != nil {
+		return nil, fmt.Errorf("Purge deleted users failed: %w", err)
+	}
+
+	return ids, nil
+}
+
+// ScheduleDeletion implement method to schedule the erasure of a user
+func (r *userPostgresRepo) ScheduleDeletion(ctx context.Context, id int32, at time.Time) (*pb.User, error) {
+	query := `
+		UPDATE users
+		SET deletion_requested_at = NOW(), deletion_scheduled_at = $1, updated_at = NOW()
+		WHERE id = $2 AND deleted_at IS NULL
+		RETURNING ` + userColumns + `
+	`
+
+	user, err := scanUser(r.db.QueryRow(ctx, query, at, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("Schedule user deletion failed: %w", err)
+	}
+
+	return user, nil
+}
+
+// RequestEmailChange implement method to store a pending email address awaiting confirmation
+func (r *userPostgresRepo) RequestEmailChange(ctx context.Context, id int32, newEmail emailaddr.Address, tokenHash string, expiresAt time.Time) (*pb.User, error) {
+	query := `
+		UPDATE users
+		SET pending_email = $1,
+			pending_email_normalized = $2,
+			pending_email_token_hash = $3,
+			pending_email_expires_at = $4,
+			updated_at = NOW()
+		WHERE id = $5 AND deleted_at IS NULL
+		RETURNING ` + userColumns + `
+	`
+
+	user, err := scanUser(r.db.QueryRow(ctx, query, newEmail.Address, newEmail.Key, tokenHash, expiresAt, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("Request email change failed: %w", err)
+	}
+
+	return user, nil
+}
+
+// ConfirmEmailChange implement method to apply the pending email matching the token hash
+func (r *userPostgresRepo) ConfirmEmailChange(ctx context.Context, tokenHash string, beforeCommit func(user *pb.User) error) (*pb.User, string, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, "", fmt.Errorf("Begin transaction failed: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		WITH previous AS (
+			SELECT id AS user_id, email AS previous_email
+			FROM users
+			WHERE pending_email_token_hash = $1 AND pending_email_expires_at > NOW() AND deleted_at IS NULL
+			FOR UPDATE
+		)
+		UPDATE users
+		SET email = pending_email,
+			email_normalized = pending_email_normalized,
+			pending_email = NULL,
+			pending_email_normalized = NULL,
+			pending_email_token_hash = NULL,
+			pending_email_expires_at = NULL,
+			updated_at = NOW()
+		FROM previous
+		WHERE id = previous.user_id
+		RETURNING ` + userColumns + `, previous.previous_email
+	`
+
+	var previousEmail string
+	user, err := scanUser(tx.QueryRow(ctx, query, tokenHash), &previousEmail)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, "", ErrUserNotFound
+		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, "", ErrEmailDuplicate
+		}
+		return nil, "", fmt.Errorf("Confirm email change failed: %w", err)
+	}
+
+	// Errors of beforeCommit are returned unchanged
+	if err := beforeCommit(user); err != nil {
+		return nil, "", err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, "", fmt.Errorf("Commit email change failed: %w", err)
+	}
+
+	return user, previousEmail, nil
+}
+
+// CancelDeletion implement method to cancel a scheduled erasure
+func (r *userPostgresRepo) CancelDeletion(ctx context.Context, id int32) (*pb.User, error) {
+	query := `
+		UPDATE users
+		SET deletion_requested_at = NULL, deletion_scheduled_at = NULL, updated_at = NOW()
+		WHERE id = $1 AND deleted_at IS NULL AND deletion_scheduled_at IS NOT NULL
+		RETURNING ` + userColumns + `
+	`
+
+	user, err := scanUser(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("Cancel user deletion failed: %w", err)
+	}
+
+	return user, nil
+}
+
+// EraseScheduled implement method to permanently delete up to limit users whose deletion is due.
+// The users stay locked while beforeErase runs, so a failing call leaves every user of the batch
+// in place to be retried. Returns the erased users as they were just before deletion.
+func (r *userPostgresRepo) EraseScheduled(ctx context.Context, now time.Time, limit int32, beforeErase func(user *pb.User) error) ([]*pb.User, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Begin transaction failed: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		SELECT ` + userColumns + `
+		FROM users
+		WHERE deletion_scheduled_at IS NOT NULL AND deletion_scheduled_at <= $1
+		ORDER BY deletion_scheduled_at
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED
+	`
+
+	rows, err := tx.Query(ctx, query, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("Query scheduled users failed: %w", err)
+	}
+
+	var users []*pb.User
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("Scan user failed: %w", err)
+		}
+		users = append(users, user)
+	}
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("Query scheduled users failed: %w", err)
+	}
+	if len(users) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]int32, len(users))
+	for i, user := range users {
+		// Errors of beforeErase are returned unchanged
+		if err := beforeErase(user); err != nil {
+			return nil, err
+		}
+		ids[i] = user.Id
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM users WHERE id = ANY($1)`, ids); err != nil {
+		return nil, fmt.Errorf("Erase scheduled users failed: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("Commit erase failed: %w", err)
+	}
+
+	return users, nil
+}
+
+// Anonymize implement method to replace a user's PII with placeholders in users and satellite tables.
+// Soft-deleted users are included; an already anonymized user is returned unchanged.
+func (r *userPostgresRepo) Anonymize(ctx context.Context, id int32) (*pb.User, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Begin transaction failed: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var email string
+	var anonymizedAt *time.Time
+	lockQuery := `SELECT email, anonymized_at FROM users WHERE id = $1 FOR UPDATE`
+	if err := tx.QueryRow(ctx, lockQuery, id).Scan(&email, &anonymizedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("Lock user failed: %w", err)
+	}
+
+	if anonymizedAt == nil {
+		// Login history keeps outcomes and timestamps but loses client details, including
+		// failed attempts recorded against the email before it was registered
+		eventsQuery := `
+			UPDATE login_events
+			SET email = '', ip_address = '', user_agent = ''
+			WHERE user_id = $1 OR (user_id IS NULL AND lower(email) = lower($2))
+		`
+		if _, err := tx.Exec(ctx, eventsQuery, id, email); err != nil {
+			return nil, fmt.Errorf("Anonymize login events failed: %w", err)
+		}
+
+		if _, err := tx.Exec(ctx, `DELETE FROM user_devices WHERE user_id = $1`, id); err != nil {
+			return nil, fmt.Errorf("Delete devices failed: %w", err)
+		}
+		if _, err := tx.Exec(ctx, `DELETE FROM password_history WHERE user_id = $1`, id); err != nil {
+			return nil, fmt.Errorf("Delete password history failed: %w", err)
+		}
+		if _, err := tx.Exec(ctx, `DELETE FROM user_external_identities WHERE user_id = $1`, id); err != nil {
+			return nil, fmt.Errorf("Delete external identities failed: %w", err)
+		}
+
+		userQuery := `
+			UPDATE users
+			SET name = $2,
+				email = $3,
+				email_normalized = $3,
+				username = NULL,
+				password_hash = NULL,
+				attributes = '{}'::jsonb,
+				avatar_key = NULL,
+				avatar_url = NULL,
+				status = 'disabled',
+				status_reason = 'anonymized',
+				status_expires_at = NULL,
+				status_changed_at = NOW(),
+				deletion_requested_at = NULL,
+				deletion_scheduled_at = NULL,
+				pending_email = NULL,
+				pending_email_normalized = NULL,
+				pending_email_token_hash = NULL,
+				pending_email_expires_at = NULL,
+				anonymized_at = NOW(),
+				updated_at = NOW()
+			WHERE id = $1
+		`
+		if _, err := tx.Exec(ctx, userQuery, id, AnonymizedName, AnonymizedEmail(id)); err != nil {
+			return nil, fmt.Errorf("Anonymize user failed: %w", err)
+		}
+	}
+
+	user, err := scanUser(tx.QueryRow(ctx, `SELECT `+userColumns+` FROM users WHERE id = $1`, id))
+	if err != nil {
+		return nil, fmt.Errorf("Query anonymized user failed: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("Commit anonymization failed: %w", err)
+	}
+
+	return user, nil
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/thatlq1812/service-1-user/internal/emailaddr"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// testPool connects to the migrated database in TEST_DATABASE_URL, skipping the test without one
+func testPool(t *testing.T) *pgxpool.Pool {
+	t.Helper()
+	url := os.Getenv("TEST_DATABASE_URL")
+	if url == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	pool, err := pgxpool.New(context.Background(), url)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	t.Cleanup(pool.Close)
+	return pool
+}
+
+func TestListUsersPaginatesByTimestamps(t *testing.T) {
+	pool := testPool(t)
+	ctx := context.Background()
+	repo := NewUserPostgresRepository(pool)
+
+	// Users of this run share a unique email prefix, so other rows never match the filter
+	prefix := fmt.Sprintf("list-ts-%d-", time.Now().UnixNano())
+	t.Cleanup(func() {
+		_, _ = pool.Exec(context.Background(), `DELETE FROM users WHERE email LIKE $1`, prefix+"%")
+	})
+
+	var ids []int32
+	for i := range 5 {
+		email, err := emailaddr.Normalizer{}.Parse(fmt.Sprintf("%s%d@example.com", prefix, i))
+		if err != nil {
+			t.Fatalf("parse email: %v", err)
+		}
+		user, err := repo.Create(ctx, "List Test", email, "")
+		if err != nil {
+			t.Fatalf("create user: %v", err)
+		}
+		ids = append(ids, user.Id)
+	}
+
+	// Timestamps can no longer be NULL, so no row can drop out of keyset pages
+	_, err := pool.Exec(ctx, `UPDATE users SET updated_at = NULL WHERE id = $1`, ids[0])
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != "23502" {
+		t.Fatalf("setting updated_at to NULL: error = %v, want a not-null violation", err)
+	}
+
+	// Equal sort keys are ordered by ID across pages
+	if _, err := pool.Exec(ctx, `UPDATE users SET updated_at = '2026-01-01 00:00:00' WHERE id = ANY($1)`, ids); err != nil {
+		t.Fatalf("set updated_at: %v", err)
+	}
+
+	for _, order := range []UserOrder{{Field: "created_at"}, {Field: "updated_at", Descending: true}} {
+		seen := map[int32]bool{}
+		page := UserPage{Limit: 2}
+		for {
+			users, next, err := repo.List(ctx, UserFilter{Email: prefix}, order, page)
+			if err != nil {
+				t.Fatalf("List(%+v): %v", order, err)
+			}
+			for _, user := range users {
+				if seen[user.Id] {
+					t.Errorf("List(%+v) returned user %d twice", order, user.Id)
+				}
+				seen[user.Id] = true
+			}
+			if next == nil {
+				break
+			}
+			page.After = next
+		}
+		if len(seen) != len(ids) {
+			t.Errorf("List(%+v) returned %d users, want %d", order, len(seen), len(ids))
+		}
+	}
+}
@@ -2,7 +2,11 @@ package repository
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
 
+	"github.com/thatlq1812/service-1-user/internal/emailaddr"
 	pb "github.com/thatlq1812/service-1-user/proto"
 )
 
@@ -11,30 +15,180 @@ type UserRepository interface {
 	// GetByID user by ID
 	GetByID(ctx context.Context, id int32) (*pb.User, error)
 
-	// GetByEmailWithPassword user by email with password hash (for authentication)
-	GetByEmailWithPassword(ctx context.Context, email string) (*UserWithPassword, error)
+	// GetByIDs active users with the given IDs in one query; missing IDs are skipped and order is unspecified
+	GetByIDs(ctx context.Context, ids []int32) ([]*pb.User, error)
 
-	// Create new user with password
-	CreateWithPassword(ctx context.Context, name, email, passwordHash string) (*pb.User, error)
+	// GetByEmail user by normalized email
+	GetByEmail(ctx context.Context, email emailaddr.Address) (*pb.User, error)
+
+	// GetByEmailWithPassword user by normalized email with password hash (for authentication).
+	// Legacy accounts without a password have an empty PasswordHash.
+	GetByEmailWithPassword(ctx context.Context, email emailaddr.Address) (*UserWithPassword, error)
+
+	// GetByUsername user by case-folded username
+	GetByUsername(ctx context.Context, username string) (*pb.User, error)
+
+	// GetByUsernameWithPassword user by case-folded username with password hash (for authentication)
+	GetByUsernameWithPassword(ctx context.Context, username string) (*UserWithPassword, error)
+
+	// Create new user with password; an empty username leaves it unset
+	CreateWithPassword(ctx context.Context, name string, email emailaddr.Address, username, passwordHash string) (*pb.User, error)
 
 	// Create new user (legacy method without password)
-	Create(ctx context.Context, name, email string) (*pb.User, error)
+	Create(ctx context.Context, name string, email emailaddr.Address, username string) (*pb.User, error)
+
+	// Update user information (full update). Fails with ErrUserAnonymized for anonymized users.
+	Update(ctx context.Context, id int32, name string, email emailaddr.Address) (*pb.User, error)
 
-	// Update user information (full update)
-	Update(ctx context.Context, id int32, name, email string) (*pb.User, error)
+	// PartialUpdate user information (only provided fields).
+	// An empty username removes it; a non-nil mergeAttributes computes the new attributes document
+	// from the stored one while the user is locked. Fails with ErrEmailDuplicate or ErrUsernameDuplicate
+	// when the new identifier is taken and with ErrUserAnonymized for anonymized users.
+	PartialUpdate(ctx context.Context, id int32, name *string, username *string, email *emailaddr.Address, password *string, mergeAttributes AttributesMerge) (*pb.User, error)
 
-	// PartialUpdate user information (only provided fields)
-	PartialUpdate(ctx context.Context, id int32, name *string, email *string, password *string) (*pb.User, error)
+	// SetAvatar stores the blob key prefix and public URL of the user's avatar (empty strings clear it).
+	// The previous key prefix is returned so its blobs can be removed. Fails with ErrUserAnonymized
+	// for anonymized users.
+	SetAvatar(ctx context.Context, id int32, key, url string) (*pb.User, string, error)
 
-	// Delete user by ID
+	// Delete user by ID (soft delete; every other method ignores deleted users)
 	Delete(ctx context.Context, id int32) error
 
-	// List all user with pagination
-	List(ctx context.Context, limit, offset int32) ([]*pb.User, int32, error)
+	// Restore a soft-deleted user. Fails with ErrEmailDuplicate or ErrUsernameDuplicate when
+	// the email or username was taken meanwhile and with ErrUserAnonymized for anonymized users.
+	Restore(ctx context.Context, id int32) (*pb.User, error)
+
+	// List a page of users matching the filter in the given order. The returned cursor points
+	// after the last user and is nil when no more users follow.
+	List(ctx context.Context, filter UserFilter, order UserOrder, page UserPage) ([]*pb.User, *UserCursor, error)
+
+	// Count users matching the filter exactly
+	Count(ctx context.Context, filter UserFilter) (int64, error)
+
+	// EstimateCount users matching the filter from planner statistics, without scanning them
+	EstimateCount(ctx context.Context, filter UserFilter) (int64, error)
+
+	// ListDeleted soft-deleted users with pagination
+	ListDeleted(ctx context.Context, limit, offset int32) ([]*pb.User, int32, error)
+
+	// PurgeDeleted permanently removes up to limit users deleted before deletedBefore and returns their IDs
+	PurgeDeleted(ctx context.Context, deletedBefore time.Time, limit int32) ([]int32, error)
+
+	// SetPasswordRotationRequired flags (or clears) a forced password rotation for the user
+	SetPasswordRotationRequired(ctx context.Context, id int32, required bool) error
+
+	// ScheduleDeletion schedules the user to be erased at the given time
+	ScheduleDeletion(ctx context.Context, id int32, at time.Time) (*pb.User, error)
+
+	// CancelDeletion cancels a scheduled deletion; ErrUserNotFound when none is scheduled
+	CancelDeletion(ctx context.Context, id int32) (*pb.User, error)
+
+	// EraseScheduled permanently deletes up to limit users whose scheduled deletion is due.
+	// beforeErase runs for each locked user before the rows are deleted; its error keeps the whole batch.
+	EraseScheduled(ctx context.Context, now time.Time, limit int32, beforeErase func(user *pb.User) error) ([]*pb.User, error)
+
+	// Anonymize irreversibly replaces the user's PII in users and satellite tables, keeping the ID.
+	// Idempotent: an already anonymized user is returned unchanged. Methods changing a user
+	// fail with ErrUserAnonymized afterwards.
+	Anonymize(ctx context.Context, id int32) (*pb.User, error)
+
+	// RequestEmailChange stores newEmail as pending until the token with the given hash is confirmed.
+	// A new request replaces any earlier pending change.
+	RequestEmailChange(ctx context.Context, id int32, newEmail emailaddr.Address, tokenHash string, expiresAt time.Time) (*pb.User, error)
+
+	// ConfirmEmailChange applies the unexpired pending email matching tokenHash and returns the replaced email.
+	// beforeCommit runs with the changed user before the change is committed; its error rolls the change back.
+	// ErrUserNotFound when no change matches, ErrEmailDuplicate when the address was registered meanwhile.
+	ConfirmEmailChange(ctx context.Context, tokenHash string, beforeCommit func(user *pb.User) error) (*pb.User, string, error)
+
+	// UpdateStatus changes the account status; expiresAt ends a suspension automatically (nil = indefinite).
+	// Anonymized users stay disabled: their status cannot change (ErrUserAnonymized).
+	UpdateStatus(ctx context.Context, id int32, status pb.UserStatus, reason string, expiresAt *time.Time) (*pb.User, error)
 }
 
+// AnonymizedName replaces the name of anonymized users
+const AnonymizedName = "Anonymized User"
+
+// AnonymizedEmail returns the unique placeholder email of an anonymized user.
+// It depends only on the ID, so nothing about the original address can be recovered.
+func AnonymizedEmail(id int32) string {
+	return fmt.Sprintf("anonymized-%d@anonymized.invalid", id)
+}
+
+// UserFilter narrows List results; zero-value fields do not filter
+type UserFilter struct {
+	// Attributes matches users whose attributes contain this document (JSONB @>)
+	Attributes map[string]any
+
+	// Name and Email match case-insensitive substrings
+	Name  string
+	Email string
+
+	// Creation and last update time ranges, inclusive of After and exclusive of Before
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	UpdatedAfter  time.Time
+	UpdatedBefore time.Time
+
+	// Status matches the effective status (expired suspensions count as active)
+	Status pb.UserStatus
+	Role   string
+}
+
+// UserOrder sorts List results; the zero value sorts by ID ascending.
+// Ties are always broken by ID in the same direction.
+type UserOrder struct {
+	Field      string
+	Descending bool
+}
+
+// UserPage selects a page of List results: the users after a cursor (keyset pagination)
+// or, without a cursor, the users at an offset
+type UserPage struct {
+	After  *UserCursor
+	Offset int32
+	Limit  int32
+}
+
+// UserCursor is a position in List results: the sort key and ID of the last user of a page
+type UserCursor struct {
+	Key string
+	ID  int32
+}
+
+// ParseUserOrder parses an order_by expression "<field> [asc|desc]", e.g. "created_at desc".
+// Fields are id, name, email, created_at and updated_at; an empty expression sorts by ID.
+func ParseUserOrder(orderBy string) (UserOrder, error) {
+	parts := strings.Fields(strings.ToLower(orderBy))
+	if len(parts) == 0 {
+		return UserOrder{}, nil
+	}
+	if len(parts) > 2 {
+		return UserOrder{}, fmt.Errorf("%w: expected \"<field> [asc|desc]\"", ErrInvalidOrder)
+	}
+
+	order := UserOrder{Field: parts[0]}
+	if _, ok := userOrderColumns[order.Field]; !ok {
+		return UserOrder{}, fmt.Errorf("%w: cannot sort by %q", ErrInvalidOrder, parts[0])
+	}
+	if len(parts) == 2 {
+		switch parts[1] {
+		case "asc":
+		case "desc":
+			order.Descending = true
+		default:
+			return UserOrder{}, fmt.Errorf("%w: direction must be asc or desc", ErrInvalidOrder)
+		}
+	}
+	return order, nil
+}
+
+// AttributesMerge computes a user's new attributes document from the stored one
+type AttributesMerge func(current map[string]any) (map[string]any, error)
+
 // UserWithPassword extends User with password_hash field for internal use
 type UserWithPassword struct {
 	*pb.User
-	PasswordHash string
+	PasswordHash             string
+	PasswordRotationRequired bool
 }
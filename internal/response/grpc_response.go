@@ -1,10 +1,16 @@
 package response
 
 import (
+	"strconv"
+	"time"
+
+	"github.com/thatlq1812/service-1-user/internal/auth"
 	pb "github.com/thatlq1812/service-1-user/proto"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 // Standard response codes mapping
@@ -21,6 +27,18 @@ const (
 	CodeUnauthorized       = "016" // Unauthorized
 )
 
+// Error reasons attached as google.rpc.ErrorInfo details
+const (
+	ErrorDomain            = "user.agrios"
+	ReasonStepUpRequired   = "STEP_UP_REQUIRED"
+	ReasonAccountSuspended = "ACCOUNT_SUSPENDED"
+	ReasonAccountDisabled  = "ACCOUNT_DISABLED"
+	ReasonAccountPending   = "ACCOUNT_PENDING"
+
+	ReasonAccountDeletionPending = "ACCOUNT_DELETION_PENDING"
+	ReasonRateLimited            = "RATE_LIMITED"
+)
+
 // MapGRPCCodeToString converts gRPC status code to our standard string code
 func MapGRPCCodeToString(code codes.Code) string {
 	switch code {
@@ -59,6 +77,15 @@ func CreateUserSuccess(user *pb.User) *pb.CreateUserResponse {
 	}
 }
 
+// CreateUserAccepted is returned by silent signup for both new and already registered emails
+func CreateUserAccepted() *pb.CreateUserResponse {
+	return &pb.CreateUserResponse{
+		Code:    CodeSuccess,
+		Message: "Registration received. If the email can be used, the account is ready.",
+		Data:    &pb.CreateUserData{},
+	}
+}
+
 func GetUserSuccess(user *pb.User) *pb.GetUserResponse {
 	return &pb.GetUserResponse{
 		Code:    CodeSuccess,
@@ -89,43 +116,115 @@ func DeleteUserSuccess() *pb.DeleteUserResponse {
 	}
 }
 
-func ListUsersSuccess(users []*pb.User, total int64, page, size int32, hasMore bool) *pb.ListUsersResponse {
+func ListUsersSuccess(users []*pb.User, total int64, totalEstimated bool, page, size int32, nextPageToken string) *pb.ListUsersResponse {
 	return &pb.ListUsersResponse{
 		Code:    CodeSuccess,
 		Message: "Users listed successfully",
 		Data: &pb.ListUsersData{
-			Users:   users,
-			Total:   total,
-			Page:    page,
-			Size:    size,
-			HasMore: hasMore,
+			Users:          users,
+			Total:          total,
+			Page:           page,
+			Size:           size,
+			HasMore:        nextPageToken != "",
+			NextPageToken:  nextPageToken,
+			TotalEstimated: totalEstimated,
 		},
 	}
 }
 
-func LoginSuccess(accessToken, refreshToken string) *pb.LoginResponse {
+func LoginSuccess(accessToken, refreshToken, tokenType string, sessionExpiresAt time.Time, passwordRotationRequired bool) *pb.LoginResponse {
 	return &pb.LoginResponse{
 		Code:    CodeSuccess,
 		Message: "Login successful",
 		Data: &pb.LoginData{
-			AccessToken:  accessToken,
-			RefreshToken: refreshToken,
+			AccessToken:              accessToken,
+			RefreshToken:             refreshToken,
+			PasswordRotationRequired: passwordRotationRequired,
+			TokenType:                tokenType,
+			SessionExpiresAt:         formatOptionalTime(sessionExpiresAt),
 		},
 	}
 }
 
-func ValidateTokenSuccess(valid bool, userID int64, email string) *pb.ValidateTokenResponse {
+func ValidateTokenSuccess(valid bool, claims *auth.Claims) *pb.ValidateTokenResponse {
 	return &pb.ValidateTokenResponse{
 		Code:    CodeSuccess,
 		Message: "Token validated successfully",
-		Data: &pb.ValidateTokenData{
-			Valid:  valid,
-			UserId: userID,
-			Email:  email,
+		Data:    validateTokenData(valid, claims),
+	}
+}
+
+// validateTokenData converts verified claims into the token data returned to clients
+func validateTokenData(valid bool, claims *auth.Claims) *pb.ValidateTokenData {
+	var actors []*pb.TokenActor
+	for _, act := range claims.Actors() {
+		actors = append(actors, &pb.TokenActor{
+			Subject: act.Subject,
+			Email:   act.Email,
+		})
+	}
+
+	data := &pb.ValidateTokenData{
+		Valid:     valid,
+		UserId:    int64(claims.UserID),
+		Email:     claims.Email,
+		Role:      claims.Role,
+		Actors:    actors,
+		Amr:       claims.AMR,
+		TokenType: claims.Scheme(),
+		Audience:  claims.Audience,
+		Scopes:    claims.Scopes(),
+	}
+	if claims.AuthTime != nil {
+		data.AuthTime = claims.AuthTime.Time.Format(time.RFC3339)
+	}
+	return data
+}
+
+func ValidateTokensSuccess(requestID string, results []*pb.TokenValidationResult) *pb.ValidateTokensResponse {
+	return &pb.ValidateTokensResponse{
+		Code:    CodeSuccess,
+		Message: "Tokens validated successfully",
+		Data: &pb.ValidateTokensData{
+			RequestId: requestID,
+			Results:   results,
 		},
 	}
 }
 
+// ValidateTokensError reports a rejected batch inside a streaming response
+func ValidateTokensError(requestID string, err error) *pb.ValidateTokensResponse {
+	return &pb.ValidateTokensResponse{
+		Code:    MapGRPCCodeToString(status.Code(err)),
+		Message: ErrorMessage(err),
+		Data: &pb.ValidateTokensData{
+			RequestId: requestID,
+		},
+	}
+}
+
+func TokenValidationSuccess(index int, claims *auth.Claims) *pb.TokenValidationResult {
+	return &pb.TokenValidationResult{
+		Index:  int32(index),
+		Valid:  true,
+		Claims: validateTokenData(true, claims),
+	}
+}
+
+func TokenValidationFailure(index int, reason pb.TokenFailureReason, message string) *pb.TokenValidationResult {
+	return &pb.TokenValidationResult{
+		Index:          int32(index),
+		Valid:          false,
+		FailureReason:  reason,
+		FailureMessage: message,
+	}
+}
+
+// ErrorMessage returns the message of a gRPC status error
+func ErrorMessage(err error) string {
+	return status.Convert(err).Message()
+}
+
 func LogoutSuccess() *pb.LogoutResponse {
 	return &pb.LogoutResponse{
 		Code:    CodeSuccess,
@@ -136,13 +235,291 @@ func LogoutSuccess() *pb.LogoutResponse {
 	}
 }
 
-func RefreshTokenSuccess(accessToken, refreshToken string) *pb.RefreshTokenResponse {
+func RefreshTokenSuccess(accessToken, refreshToken, tokenType string, sessionExpiresAt time.Time) *pb.RefreshTokenResponse {
 	return &pb.RefreshTokenResponse{
 		Code:    CodeSuccess,
 		Message: "Token refreshed successfully",
 		Data: &pb.RefreshTokenData{
+			AccessToken:      accessToken,
+			RefreshToken:     refreshToken,
+			TokenType:        tokenType,
+			SessionExpiresAt: formatOptionalTime(sessionExpiresAt),
+		},
+	}
+}
+
+// formatOptionalTime formats t as RFC3339, or returns "" for the zero time
+func formatOptionalTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func ImpersonateUserSuccess(accessToken string, expiresAt time.Time, targetUserID int32) *pb.ImpersonateUserResponse {
+	return &pb.ImpersonateUserResponse{
+		Code:    CodeSuccess,
+		Message: "Impersonation token issued",
+		Data: &pb.ImpersonateUserData{
 			AccessToken:  accessToken,
-			RefreshToken: refreshToken,
+			ExpiresAt:    expiresAt.Format(time.RFC3339),
+			TargetUserId: targetUserID,
+		},
+	}
+}
+
+func ExchangeTokenSuccess(accessToken string, claims *auth.Claims) *pb.ExchangeTokenResponse {
+	return &pb.ExchangeTokenResponse{
+		Code:    CodeSuccess,
+		Message: "Token exchanged successfully",
+		Data: &pb.ExchangeTokenData{
+			AccessToken:     accessToken,
+			IssuedTokenType: auth.IssuedTokenTypeAccessToken,
+			TokenType:       auth.TokenTypeBearer,
+			ExpiresIn:       int32(time.Until(claims.ExpiresAt.Time).Seconds()),
+			Audience:        claims.Audience[0],
+			Scopes:          claims.Scopes(),
+		},
+	}
+}
+
+func SuspendUserSuccess(user *pb.User) *pb.SuspendUserResponse {
+	return &pb.SuspendUserResponse{
+		Code:    CodeSuccess,
+		Message: "User suspended successfully",
+		Data: &pb.SuspendUserData{
+			User: user,
+		},
+	}
+}
+
+func ReactivateUserSuccess(user *pb.User) *pb.ReactivateUserResponse {
+	return &pb.ReactivateUserResponse{
+		Code:    CodeSuccess,
+		Message: "User reactivated successfully",
+		Data: &pb.ReactivateUserData{
+			User: user,
+		},
+	}
+}
+
+func DisableUserSuccess(user *pb.User) *pb.DisableUserResponse {
+	return &pb.DisableUserResponse{
+		Code:    CodeSuccess,
+		Message: "User disabled successfully",
+		Data: &pb.DisableUserData{
+			User: user,
+		},
+	}
+}
+
+func RequestAccountDeletionSuccess(scheduledAt string) *pb.RequestAccountDeletionResponse {
+	return &pb.RequestAccountDeletionResponse{
+		Code:    CodeSuccess,
+		Message: "Account deletion scheduled",
+		Data: &pb.RequestAccountDeletionData{
+			DeletionScheduledAt: scheduledAt,
+		},
+	}
+}
+
+func CancelAccountDeletionSuccess(user *pb.User) *pb.CancelAccountDeletionResponse {
+	return &pb.CancelAccountDeletionResponse{
+		Code:    CodeSuccess,
+		Message: "Account deletion cancelled",
+		Data: &pb.CancelAccountDeletionData{
+			User: user,
+		},
+	}
+}
+
+func ExportUserDataChunk(fileName string, content []byte, sequence int32, lastChunk, endOfArchive bool) *pb.ExportUserDataResponse {
+	return &pb.ExportUserDataResponse{
+		Code:    CodeSuccess,
+		Message: "User data export chunk",
+		Data: &pb.ExportUserDataChunk{
+			FileName:     fileName,
+			Content:      content,
+			Sequence:     sequence,
+			LastChunk:    lastChunk,
+			EndOfArchive: endOfArchive,
+		},
+	}
+}
+
+func AnonymizeUserSuccess(user *pb.User) *pb.AnonymizeUserResponse {
+	return &pb.AnonymizeUserResponse{
+		Code:    CodeSuccess,
+		Message: "User anonymized successfully",
+		Data: &pb.AnonymizeUserData{
+			User: user,
+		},
+	}
+}
+
+func RestoreUserSuccess(user *pb.User) *pb.RestoreUserResponse {
+	return &pb.RestoreUserResponse{
+		Code:    CodeSuccess,
+		Message: "User restored successfully",
+		Data: &pb.RestoreUserData{
+			User: user,
+		},
+	}
+}
+
+func ListDeletedUsersSuccess(users []*pb.User, total int64, page, size int32, hasMore bool) *pb.ListDeletedUsersResponse {
+	return &pb.ListDeletedUsersResponse{
+		Code:    CodeSuccess,
+		Message: "Deleted users listed successfully",
+		Data: &pb.ListDeletedUsersData{
+			Users:   users,
+			Total:   total,
+			Page:    page,
+			Size:    size,
+			HasMore: hasMore,
+		},
+	}
+}
+
+func RequestEmailChangeSuccess(pendingEmail string, expiresAt time.Time) *pb.RequestEmailChangeResponse {
+	return &pb.RequestEmailChangeResponse{
+		Code:    CodeSuccess,
+		Message: "Confirmation sent to the new email address",
+		Data: &pb.RequestEmailChangeData{
+			PendingEmail: pendingEmail,
+			ExpiresAt:    expiresAt.Format(time.RFC3339),
+		},
+	}
+}
+
+func ConfirmEmailChangeSuccess(user *pb.User) *pb.ConfirmEmailChangeResponse {
+	return &pb.ConfirmEmailChangeResponse{
+		Code:    CodeSuccess,
+		Message: "Email changed successfully",
+		Data: &pb.ConfirmEmailChangeData{
+			User: user,
+		},
+	}
+}
+
+func BatchGetUsersSuccess(users []*pb.User, missingIDs []int32) *pb.BatchGetUsersResponse {
+	return &pb.BatchGetUsersResponse{
+		Code:    CodeSuccess,
+		Message: "Users retrieved successfully",
+		Data: &pb.BatchGetUsersData{
+			Users:      users,
+			MissingIds: missingIDs,
+		},
+	}
+}
+
+func LookupUserSuccess(user *pb.User) *pb.LookupUserResponse {
+	return &pb.LookupUserResponse{
+		Code:    CodeSuccess,
+		Message: "User retrieved successfully",
+		Data: &pb.LookupUserData{
+			User: user,
+		},
+	}
+}
+
+func LinkExternalIdentitySuccess(user *pb.User) *pb.LinkExternalIdentityResponse {
+	return &pb.LinkExternalIdentityResponse{
+		Code:    CodeSuccess,
+		Message: "External identity linked successfully",
+		Data: &pb.LinkExternalIdentityData{
+			User: user,
+		},
+	}
+}
+
+func UnlinkExternalIdentitySuccess(user *pb.User) *pb.UnlinkExternalIdentityResponse {
+	return &pb.UnlinkExternalIdentityResponse{
+		Code:    CodeSuccess,
+		Message: "External identity unlinked successfully",
+		Data: &pb.UnlinkExternalIdentityData{
+			User: user,
+		},
+	}
+}
+
+func GetUserByUsernameSuccess(user *pb.User) *pb.GetUserByUsernameResponse {
+	return &pb.GetUserByUsernameResponse{
+		Code:    CodeSuccess,
+		Message: "User retrieved successfully",
+		Data: &pb.GetUserByUsernameData{
+			User: user,
+		},
+	}
+}
+
+func CheckAvailabilitySuccess(available bool, reason, detail string) *pb.CheckAvailabilityResponse {
+	message := "Identifier is available"
+	if !available {
+		message = "Identifier is not available"
+	}
+	return &pb.CheckAvailabilityResponse{
+		Code:    CodeSuccess,
+		Message: message,
+		Data: &pb.CheckAvailabilityData{
+			Available: available,
+			Reason:    reason,
+			Detail:    detail,
+		},
+	}
+}
+
+func UploadAvatarSuccess(user *pb.User, renditions []*pb.AvatarRendition) *pb.UploadAvatarResponse {
+	return &pb.UploadAvatarResponse{
+		Code:    CodeSuccess,
+		Message: "Avatar uploaded successfully",
+		Data: &pb.UploadAvatarData{
+			User:       user,
+			Renditions: renditions,
+		},
+	}
+}
+
+func DeleteAvatarSuccess(user *pb.User) *pb.DeleteAvatarResponse {
+	return &pb.DeleteAvatarResponse{
+		Code:    CodeSuccess,
+		Message: "Avatar deleted successfully",
+		Data: &pb.DeleteAvatarData{
+			User: user,
+		},
+	}
+}
+
+func GetAttributeSchemaSuccess(schema *pb.AttributeSchema) *pb.GetAttributeSchemaResponse {
+	return &pb.GetAttributeSchemaResponse{
+		Code:    CodeSuccess,
+		Message: "Attribute schema retrieved successfully",
+		Data: &pb.GetAttributeSchemaData{
+			Schema: schema,
+		},
+	}
+}
+
+func SetAttributeSchemaSuccess(schema *pb.AttributeSchema) *pb.SetAttributeSchemaResponse {
+	return &pb.SetAttributeSchemaResponse{
+		Code:    CodeSuccess,
+		Message: "Attribute schema updated successfully",
+		Data: &pb.SetAttributeSchemaData{
+			Schema: schema,
+		},
+	}
+}
+
+func ListLoginEventsSuccess(events []*pb.LoginEvent, total int64, page, size int32, hasMore bool) *pb.ListLoginEventsResponse {
+	return &pb.ListLoginEventsResponse{
+		Code:    CodeSuccess,
+		Message: "Login events listed successfully",
+		Data: &pb.ListLoginEventsData{
+			Events:  events,
+			Total:   total,
+			Page:    page,
+			Size:    size,
+			HasMore: hasMore,
 		},
 	}
 }
@@ -169,6 +546,93 @@ func GRPCError(code codes.Code, message string) error {
 	return status.Error(code, fullMessage)
 }
 
+// StepUpRequiredError tells the client to re-authenticate because the login is older than maxAge.
+// It carries an ErrorInfo detail with reason STEP_UP_REQUIRED and the max age in seconds.
+func StepUpRequiredError(maxAge time.Duration) error {
+	st := status.New(codes.Unauthenticated, "Recent authentication required. Log in again to continue.")
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: ReasonStepUpRequired,
+		Domain: ErrorDomain,
+		Metadata: map[string]string{
+			"max_age": strconv.Itoa(int(maxAge.Seconds())),
+		},
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// AccountInactiveError tells the client that the account may not authenticate in its current status.
+// It carries an ErrorInfo detail with the status reason and, for temporary suspensions, their end.
+func AccountInactiveError(user *pb.User) error {
+	var message, reason string
+	switch user.Status {
+	case pb.UserStatus_USER_STATUS_SUSPENDED:
+		message, reason = "Account is suspended", ReasonAccountSuspended
+	case pb.UserStatus_USER_STATUS_PENDING:
+		message, reason = "Account is pending activation", ReasonAccountPending
+	default:
+		message, reason = "Account is disabled", ReasonAccountDisabled
+	}
+
+	metadata := map[string]string{}
+	if user.StatusReason != "" {
+		metadata["reason"] = user.StatusReason
+	}
+	if user.SuspendedUntil != "" {
+		metadata["suspended_until"] = user.SuspendedUntil
+	}
+
+	st := status.New(codes.PermissionDenied, message)
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason:   reason,
+		Domain:   ErrorDomain,
+		Metadata: metadata,
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// AccountDeletionPendingError tells the client that the account is scheduled for deletion.
+// Only CancelAccountDeletion is possible until the deletion takes effect at scheduledAt.
+func AccountDeletionPendingError(scheduledAt string) error {
+	st := status.New(codes.PermissionDenied, "Account is scheduled for deletion. Cancel the deletion to log in again.")
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: ReasonAccountDeletionPending,
+		Domain: ErrorDomain,
+		Metadata: map[string]string{
+			"deletion_scheduled_at": scheduledAt,
+		},
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// RateLimitedError tells the client to slow down and retry after retryAfter.
+// It carries an ErrorInfo detail with reason RATE_LIMITED and a RetryInfo detail.
+func RateLimitedError(retryAfter time.Duration) error {
+	st := status.New(codes.ResourceExhausted, "Too many requests. Try again later.")
+	detailed, err := st.WithDetails(
+		&errdetails.ErrorInfo{
+			Reason: ReasonRateLimited,
+			Domain: ErrorDomain,
+			Metadata: map[string]string{
+				"retry_after": strconv.Itoa(int(retryAfter.Round(time.Second).Seconds())),
+			},
+		},
+		&errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)},
+	)
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
 // Error with custom code
 func GRPCErrorWithCode(code codes.Code, message string) error {
 	return status.Error(code, message)
@@ -0,0 +1,117 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/thatlq1812/service-1-user/internal/auth"
+	"github.com/thatlq1812/service-1-user/internal/notify"
+	"github.com/thatlq1812/service-1-user/internal/repository"
+	"github.com/thatlq1812/service-1-user/internal/response"
+	pb "github.com/thatlq1812/service-1-user/proto"
+
+	"google.golang.org/grpc/codes"
+)
+
+// RequestAccountDeletion schedules the caller's account to be erased after the grace period.
+// All sessions end immediately; until the erase the user can only cancel with their credentials.
+func (s *userServiceServer) RequestAccountDeletion(ctx context.Context, req *pb.RequestAccountDeletionRequest) (*pb.RequestAccountDeletionResponse, error) {
+	if s.deletionGracePeriod <= 0 {
+		return nil, response.GRPCError(codes.FailedPrecondition, "Self-service account deletion is not enabled")
+	}
+
+	caller, err := s.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if caller.Act != nil {
+		return nil, response.GRPCError(codes.PermissionDenied, "Delegated tokens cannot delete accounts.")
+	}
+	if err := s.requireFreshAuth(ctx, "RequestAccountDeletion", caller.UserID); err != nil {
+		return nil, err
+	}
+
+	if err := s.tokenManager.RevokeUserTokens(ctx, caller.UserID); err != nil {
+		return nil, response.GRPCError(codes.Internal, "Failed to revoke user tokens")
+	}
+
+	user, err := s.repo.ScheduleDeletion(ctx, caller.UserID, time.Now().Add(s.deletionGracePeriod))
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, response.GRPCError(codes.NotFound, "User not found")
+		}
+		return nil, response.GRPCError(codes.Internal, "Failed to schedule account deletion")
+	}
+
+	if reason := strings.TrimSpace(req.Reason); reason != "" {
+		log.Printf("User %d requested account deletion: %s", user.Id, reason)
+	}
+
+	if s.notifier != nil {
+		notification := &notify.Notification{
+			Type:    notify.TypeAccountDeletionScheduled,
+			UserID:  user.Id,
+			Email:   user.Email,
+			Subject: "Your account is scheduled for deletion",
+			Body: fmt.Sprintf(
+				"Your account and its data will be permanently deleted on %s. Until then you can cancel the deletion with your email and password (CancelAccountDeletion); logging in is not possible while the deletion is pending.",
+				user.DeletionScheduledAt,
+			),
+			Metadata: map[string]string{
+				"deletion_scheduled_at": user.DeletionScheduledAt,
+			},
+		}
+		if err := s.notifier.Notify(ctx, notification); err != nil {
+			log.Printf("Failed to send deletion notice to user %d: %v", user.Id, err)
+		}
+	}
+
+	return response.RequestAccountDeletionSuccess(user.DeletionScheduledAt), nil
+}
+
+// CancelAccountDeletion cancels a scheduled deletion. The user proves ownership with their
+// credentials, with the same constant-work password check as Login.
+func (s *userServiceServer) CancelAccountDeletion(ctx context.Context, req *pb.CancelAccountDeletionRequest) (*pb.CancelAccountDeletionResponse, error) {
+	if req.Email == "" {
+		return nil, response.GRPCError(codes.InvalidArgument, "Email is required")
+	}
+	if req.Password == "" {
+		return nil, response.GRPCError(codes.InvalidArgument, "Password is required")
+	}
+
+	userWithPassword, err := s.findCredentials(ctx, req.Email)
+	if err != nil {
+		return nil, response.GRPCError(codes.Internal, "Failed to get user")
+	}
+
+	passwordHash := ""
+	if userWithPassword != nil {
+		passwordHash = userWithPassword.PasswordHash
+	}
+	if !auth.VerifyPassword(req.Password, passwordHash) {
+		var userID int32
+		reason := loginFailureUnknownEmail
+		if userWithPassword != nil {
+			userID = userWithPassword.User.Id
+			reason = loginFailureInvalidPassword
+		}
+		s.recordLoginEvent(ctx, userID, req.Email, repository.LoginEventLogin, repository.LoginOutcomeFailure, reason)
+		return nil, response.GRPCError(codes.Unauthenticated, "Invalid email or password")
+	}
+
+	user, err := s.repo.CancelDeletion(ctx, userWithPassword.User.Id)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, response.GRPCError(codes.FailedPrecondition, "No account deletion is scheduled")
+		}
+		return nil, response.GRPCError(codes.Internal, "Failed to cancel account deletion")
+	}
+
+	log.Printf("User %d cancelled account deletion", user.Id)
+
+	return response.CancelAccountDeletionSuccess(user), nil
+}
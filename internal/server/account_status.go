@@ -0,0 +1,178 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/thatlq1812/service-1-user/internal/auth"
+	"github.com/thatlq1812/service-1-user/internal/repository"
+	"github.com/thatlq1812/service-1-user/internal/response"
+	pb "github.com/thatlq1812/service-1-user/proto"
+
+	"google.golang.org/grpc/codes"
+)
+
+// loginFailureAccountInactive is stored in login history when a non-active account authenticates
+const loginFailureAccountInactive = "account_inactive"
+
+// checkAccountActive returns the account status error for users who may not authenticate:
+// non-active accounts and accounts scheduled for deletion
+func checkAccountActive(user *pb.User) error {
+	if user.Status != pb.UserStatus_USER_STATUS_ACTIVE {
+		return response.AccountInactiveError(user)
+	}
+	if user.DeletionScheduledAt != "" {
+		return response.AccountDeletionPendingError(user.DeletionScheduledAt)
+	}
+	return nil
+}
+
+// statusChange describes an admin change of a user's account status
+type statusChange struct {
+	userID    int32
+	status    pb.UserStatus
+	reason    string
+	expiresAt *time.Time
+	action    string
+}
+
+// changeAccountStatus applies a status change on behalf of an admin. Blocking changes revoke
+// every token the user holds before the status is stored, so no session outlives the change.
+func (s *userServiceServer) changeAccountStatus(ctx context.Context, change statusChange) (*pb.User, error) {
+	admin, err := s.requireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if change.userID <= 0 {
+		return nil, response.GRPCError(codes.InvalidArgument, "User ID must be positive")
+	}
+	if change.userID == admin.UserID {
+		return nil, response.GRPCError(codes.InvalidArgument, "Cannot change the status of your own account")
+	}
+
+	target, err := s.repo.GetByID(ctx, change.userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, response.GRPCError(codes.NotFound, "User not found")
+		}
+		return nil, response.GRPCError(codes.Internal, "Failed to get user")
+	}
+
+	blocking := change.status != pb.UserStatus_USER_STATUS_ACTIVE
+	if blocking && target.Role == auth.RoleAdmin {
+		return nil, response.GRPCError(codes.PermissionDenied, "Admin accounts cannot be suspended or disabled.")
+	}
+
+	if blocking {
+		if err := s.tokenManager.RevokeUserTokens(ctx, target.Id); err != nil {
+			return nil, response.GRPCError(codes.Internal, "Failed to revoke user tokens")
+		}
+	}
+
+	user, err := s.repo.UpdateStatus(ctx, target.Id, change.status, change.reason, change.expiresAt)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, response.GRPCError(codes.NotFound, "User not found")
+		}
+		if errors.Is(err, repository.ErrUserAnonymized) {
+			return nil, response.GRPCError(codes.FailedPrecondition, "User has been anonymized and can no longer be changed")
+		}
+		return nil, response.GRPCError(codes.Internal, "Failed to update user status")
+	}
+
+	if s.audit != nil {
+		metadata := map[string]any{
+			"previous_status": target.Status.String(),
+			"status":          user.Status.String(),
+		}
+		if change.expiresAt != nil {
+			metadata["expires_at"] = *change.expiresAt
+		}
+		entry := &repository.AuditEntry{
+			ActorUserID:  admin.UserID,
+			Action:       change.action,
+			TargetUserID: user.Id,
+			Reason:       change.reason,
+			Metadata:     metadata,
+		}
+		if err := s.audit.Record(ctx, entry); err != nil {
+			log.Printf("Failed to record %s audit entry for user %d: %v", change.action, user.Id, err)
+		}
+	}
+
+	log.Printf("Admin %d changed status of user %d to %s", admin.UserID, user.Id, user.Status)
+
+	return user, nil
+}
+
+// SuspendUser blocks a user temporarily (or until reactivated) and revokes all their tokens
+func (s *userServiceServer) SuspendUser(ctx context.Context, req *pb.SuspendUserRequest) (*pb.SuspendUserResponse, error) {
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, response.GRPCError(codes.InvalidArgument, "Reason is required for suspension")
+	}
+
+	var expiresAt *time.Time
+	if req.ExpiresAt != "" {
+		parsed, err := time.Parse(time.RFC3339, req.ExpiresAt)
+		if err != nil {
+			return nil, response.GRPCError(codes.InvalidArgument, "Expires_at must be an RFC3339 timestamp")
+		}
+		if !parsed.After(time.Now()) {
+			return nil, response.GRPCError(codes.InvalidArgument, "Expires_at must be in the future")
+		}
+		expiresAt = &parsed
+	}
+
+	user, err := s.changeAccountStatus(ctx, statusChange{
+		userID:    req.UserId,
+		status:    pb.UserStatus_USER_STATUS_SUSPENDED,
+		reason:    reason,
+		expiresAt: expiresAt,
+		action:    repository.AuditActionSuspend,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response.SuspendUserSuccess(user), nil
+}
+
+// ReactivateUser returns a suspended, disabled or pending account to active
+func (s *userServiceServer) ReactivateUser(ctx context.Context, req *pb.ReactivateUserRequest) (*pb.ReactivateUserResponse, error) {
+	user, err := s.changeAccountStatus(ctx, statusChange{
+		userID: req.UserId,
+		status: pb.UserStatus_USER_STATUS_ACTIVE,
+		reason: strings.TrimSpace(req.Reason),
+		action: repository.AuditActionReactivate,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response.ReactivateUserSuccess(user), nil
+}
+
+// DisableUser blocks a user until an admin reactivates the account and revokes all their tokens
+func (s *userServiceServer) DisableUser(ctx context.Context, req *pb.DisableUserRequest) (*pb.DisableUserResponse, error) {
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, response.GRPCError(codes.InvalidArgument, "Reason is required to disable an account")
+	}
+
+	user, err := s.changeAccountStatus(ctx, statusChange{
+		userID: req.UserId,
+		status: pb.UserStatus_USER_STATUS_DISABLED,
+		reason: reason,
+		action: repository.AuditActionDisable,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response.DisableUserSuccess(user), nil
+}
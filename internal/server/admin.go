@@ -0,0 +1,307 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+
+	"github.com/thatlq1812/service-1-user/internal/auth"
+	"github.com/thatlq1812/service-1-user/internal/avatar"
+	"github.com/thatlq1812/service-1-user/internal/events"
+	"github.com/thatlq1812/service-1-user/internal/repository"
+	"github.com/thatlq1812/service-1-user/internal/response"
+	"github.com/thatlq1812/service-1-user/internal/username"
+	pb "github.com/thatlq1812/service-1-user/proto"
+
+	"google.golang.org/grpc/codes"
+)
+
+// ImpersonateUser issues a short-lived access token for the target user on behalf of an admin.
+// The token carries an "act" claim naming the admin and is never paired with a refresh token.
+func (s *userServiceServer) ImpersonateUser(ctx context.Context, req *pb.ImpersonateUserRequest) (*pb.ImpersonateUserResponse, error) {
+	admin, err := s.requireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Every impersonation must be auditable
+	if s.audit == nil {
+		return nil, response.GRPCError(codes.FailedPrecondition, "Impersonation requires the audit trail to be enabled")
+	}
+
+	// Validate input
+	if req.TargetUserId <= 0 {
+		return nil, response.GRPCError(codes.InvalidArgument, "Target user ID must be positive")
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, response.GRPCError(codes.InvalidArgument, "Reason is required for impersonation")
+	}
+	if req.TargetUserId == admin.UserID {
+		return nil, response.GRPCError(codes.InvalidArgument, "Cannot impersonate yourself")
+	}
+
+	target, err := s.repo.GetByID(ctx, req.TargetUserId)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, response.GRPCError(codes.NotFound, "User not found")
+		}
+		return nil, response.GRPCError(codes.Internal, "Failed to get user")
+	}
+	if err := checkImpersonationTarget(target); err != nil {
+		return nil, err
+	}
+
+	token, claims, err := s.tokenManager.GenerateImpersonationToken(
+		target.Id, target.Email, target.Role,
+		admin.UserID, admin.Email,
+		s.impersonationDuration,
+	)
+	if err != nil {
+		return nil, response.GRPCError(codes.Internal, "Failed to generate impersonation token")
+	}
+
+	// Refuse to hand out the token if the audit entry cannot be written
+	entry := &repository.AuditEntry{
+		ActorUserID:  admin.UserID,
+		Action:       repository.AuditActionImpersonate,
+		TargetUserID: target.Id,
+		Reason:       reason,
+		Metadata: map[string]any{
+			"token_id":   claims.ID,
+			"expires_at": claims.ExpiresAt.Time,
+		},
+	}
+	if err := s.audit.Record(ctx, entry); err != nil {
+		return nil, response.GRPCError(codes.Internal, "Failed to record impersonation audit entry")
+	}
+
+	log.Printf("Admin %d impersonating user %d (token %s)", admin.UserID, target.Id, claims.ID)
+
+	return response.ImpersonateUserSuccess(token, claims.ExpiresAt.Time, target.Id), nil
+}
+
+// checkImpersonationTarget rejects users that may not be impersonated: admins, and accounts
+// that could not log in themselves (blocked, pending deletion, deleted or anonymized)
+func checkImpersonationTarget(target *pb.User) error {
+	if target.Role == auth.RoleAdmin {
+		return response.GRPCError(codes.PermissionDenied, "Admin accounts cannot be impersonated.")
+	}
+	if target.DeletedAt != "" || target.AnonymizedAt != "" {
+		return response.GRPCError(codes.FailedPrecondition, "Deleted or anonymized accounts cannot be impersonated")
+	}
+	return checkAccountActive(target)
+}
+
+// RestoreUser undoes a soft delete before the user is purged
+func (s *userServiceServer) RestoreUser(ctx context.Context, req *pb.RestoreUserRequest) (*pb.RestoreUserResponse, error) {
+	admin, err := s.requireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.UserId <= 0 {
+		return nil, response.GRPCError(codes.InvalidArgument, "User ID must be positive")
+	}
+
+	user, err := s.repo.Restore(ctx, req.UserId)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, response.GRPCError(codes.NotFound, "Deleted user not found")
+		}
+		if errors.Is(err, repository.ErrUserAnonymized) {
+			return nil, response.GRPCError(codes.FailedPrecondition, "User has been anonymized and can no longer be changed")
+		}
+		if errors.Is(err, repository.ErrEmailDuplicate) {
+			return nil, response.GRPCError(codes.AlreadyExists, "Email is now used by another account")
+		}
+		if errors.Is(err, repository.ErrUsernameDuplicate) {
+			return nil, response.GRPCError(codes.AlreadyExists, "Username is now used by another account")
+		}
+		return nil, response.GRPCError(codes.Internal, "Failed to restore user")
+	}
+
+	if s.audit != nil {
+		entry := &repository.AuditEntry{
+			ActorUserID:  admin.UserID,
+			Action:       repository.AuditActionRestore,
+			TargetUserID: user.Id,
+			Reason:       strings.TrimSpace(req.Reason),
+		}
+		if err := s.audit.Record(ctx, entry); err != nil {
+			log.Printf("Failed to record restore audit entry for user %d: %v", user.Id, err)
+		}
+	}
+
+	log.Printf("Admin %d restored user %d", admin.UserID, user.Id)
+
+	return response.RestoreUserSuccess(user), nil
+}
+
+// ListDeletedUsers returns soft-deleted users that have not been purged yet, most recently deleted first
+func (s *userServiceServer) ListDeletedUsers(ctx context.Context, req *pb.ListDeletedUsersRequest) (*pb.ListDeletedUsersResponse, error) {
+	if _, err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	// Validate and normalize pagination parameters
+	pageSize := req.PageSize
+	pageNumber := req.Page
+
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		return nil, response.GRPCError(codes.InvalidArgument, "Page_size too large")
+	}
+	if pageNumber < 0 {
+		return nil, response.GRPCError(codes.InvalidArgument, "Page_number must be non-negative")
+	}
+
+	offset := pageNumber * pageSize
+
+	users, total, err := s.repo.ListDeleted(ctx, pageSize, offset)
+	if err != nil {
+		return nil, response.GRPCError(codes.Internal, "Failed to list deleted users")
+	}
+
+	hasMore := int64(pageNumber+1)*int64(pageSize) < int64(total)
+
+	return response.ListDeletedUsersSuccess(users, int64(total), pageNumber, pageSize, hasMore), nil
+}
+
+// AnonymizeUser fulfils a right-to-erasure request: the user's PII is replaced by irreversible
+// placeholders, every token is revoked and a user.anonymized tombstone is published. The numeric
+// ID stays valid so references from other services and the audit trail remain intact.
+// Repeating the call re-publishes the tombstone, so failed deliveries can be retried.
+func (s *userServiceServer) AnonymizeUser(ctx context.Context, req *pb.AnonymizeUserRequest) (*pb.AnonymizeUserResponse, error) {
+	admin, err := s.requireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.UserId <= 0 {
+		return nil, response.GRPCError(codes.InvalidArgument, "User ID must be positive")
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, response.GRPCError(codes.InvalidArgument, "Reason is required for anonymization")
+	}
+	if req.UserId == admin.UserID {
+		return nil, response.GRPCError(codes.InvalidArgument, "Cannot anonymize your own account")
+	}
+
+	if err := s.tokenManager.RevokeUserTokens(ctx, req.UserId); err != nil {
+		return nil, response.GRPCError(codes.Internal, "Failed to revoke user tokens")
+	}
+
+	user, err := s.repo.Anonymize(ctx, req.UserId)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, response.GRPCError(codes.NotFound, "User not found")
+		}
+		return nil, response.GRPCError(codes.Internal, "Failed to anonymize user")
+	}
+
+	// The picture is PII as well; the row no longer references it
+	s.deleteAvatarBlobs(ctx, user.Id, avatar.UserPrefix(user.Id))
+
+	if s.audit != nil {
+		entry := &repository.AuditEntry{
+			ActorUserID:  admin.UserID,
+			Action:       repository.AuditActionAnonymize,
+			TargetUserID: user.Id,
+			Reason:       reason,
+		}
+		if err := s.audit.Record(ctx, entry); err != nil {
+			log.Printf("Failed to record anonymize audit entry for user %d: %v", user.Id, err)
+		}
+	}
+
+	if s.events != nil {
+		event := &events.Event{
+			Type:   events.TypeUserAnonymized,
+			UserID: user.Id,
+			Data: map[string]string{
+				"anonymized_at": user.AnonymizedAt,
+			},
+		}
+		if err := s.events.Publish(ctx, event); err != nil {
+			return nil, response.GRPCError(codes.Unavailable, "User anonymized but the tombstone event could not be published; retry the request")
+		}
+	}
+
+	log.Printf("Admin %d anonymized user %d", admin.UserID, user.Id)
+
+	return response.AnonymizeUserSuccess(user), nil
+}
+
+// LookupUser finds an active user by ID, email, username or external identity for admin tooling
+func (s *userServiceServer) LookupUser(ctx context.Context, req *pb.LookupUserRequest) (*pb.LookupUserResponse, error) {
+	if _, err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	user, err := s.lookupUser(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.LookupUserSuccess(user), nil
+}
+
+// lookupUser resolves the key of a LookupUser request to an active user
+func (s *userServiceServer) lookupUser(ctx context.Context, req *pb.LookupUserRequest) (*pb.User, error) {
+	var user *pb.User
+	var err error
+
+	switch key := req.Key.(type) {
+	case *pb.LookupUserRequest_Id:
+		if key.Id <= 0 {
+			return nil, response.GRPCError(codes.InvalidArgument, "User ID must be positive")
+		}
+		user, err = s.repo.GetByID(ctx, key.Id)
+
+	case *pb.LookupUserRequest_Email:
+		address, parseErr := s.parseEmail(key.Email)
+		if parseErr != nil {
+			return nil, parseErr
+		}
+		user, err = s.repo.GetByEmail(ctx, address)
+
+	case *pb.LookupUserRequest_Username:
+		name, parseErr := username.Normalize(key.Username)
+		if parseErr != nil {
+			// A username that could never be registered cannot exist
+			return nil, response.GRPCError(codes.NotFound, "User not found")
+		}
+		user, err = s.repo.GetByUsername(ctx, name)
+
+	case *pb.LookupUserRequest_ExternalIdentity:
+		if s.externalIdentities == nil {
+			return nil, response.GRPCError(codes.FailedPrecondition, "External identities are not enabled")
+		}
+		provider, subject, parseErr := parseExternalIdentity(key.ExternalIdentity)
+		if parseErr != nil {
+			return nil, parseErr
+		}
+		var userID int32
+		userID, err = s.externalIdentities.FindUserID(ctx, provider, subject)
+		if err == nil {
+			user, err = s.repo.GetByID(ctx, userID)
+		}
+
+	default:
+		return nil, response.GRPCError(codes.InvalidArgument, "One of id, email, username or external_identity is required")
+	}
+
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, response.GRPCError(codes.NotFound, "User not found")
+		}
+		return nil, response.GRPCError(codes.Internal, "Failed to look up user")
+	}
+
+	return user, nil
+}
@@ -0,0 +1,144 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/thatlq1812/service-1-user/internal/auth"
+	"github.com/thatlq1812/service-1-user/internal/repository"
+	pb "github.com/thatlq1812/service-1-user/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCheckImpersonationTarget(t *testing.T) {
+	active := func() *pb.User {
+		return &pb.User{Id: 7, Role: auth.RoleUser, Status: pb.UserStatus_USER_STATUS_ACTIVE}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*pb.User)
+		want   codes.Code
+	}{
+		{name: "active user", modify: func(*pb.User) {}, want: codes.OK},
+		{name: "admin", modify: func(u *pb.User) { u.Role = auth.RoleAdmin }, want: codes.PermissionDenied},
+		{name: "suspended", modify: func(u *pb.User) { u.Status = pb.UserStatus_USER_STATUS_SUSPENDED }, want: codes.PermissionDenied},
+		{name: "disabled", modify: func(u *pb.User) { u.Status = pb.UserStatus_USER_STATUS_DISABLED }, want: codes.PermissionDenied},
+		{name: "pending activation", modify: func(u *pb.User) { u.Status = pb.UserStatus_USER_STATUS_PENDING }, want: codes.PermissionDenied},
+		{name: "deletion pending", modify: func(u *pb.User) { u.DeletionScheduledAt = "2026-11-01T00:00:00Z" }, want: codes.PermissionDenied},
+		{name: "deleted", modify: func(u *pb.User) { u.DeletedAt = "2026-10-01T00:00:00Z" }, want: codes.FailedPrecondition},
+		{name: "anonymized", modify: func(u *pb.User) { u.AnonymizedAt = "2026-10-01T00:00:00Z" }, want: codes.FailedPrecondition},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := active()
+			tt.modify(target)
+			if got := status.Code(checkImpersonationTarget(target)); got != tt.want {
+				t.Errorf("checkImpersonationTarget() code = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// usersByID is an in-memory UserRepository supporting GetByID.
+// Other methods panic through the nil embedded interface.
+type usersByID struct {
+	repository.UserRepository
+	users map[int32]*pb.User
+}
+
+func (r *usersByID) GetByID(_ context.Context, id int32) (*pb.User, error) {
+	user, ok := r.users[id]
+	if !ok {
+		return nil, repository.ErrUserNotFound
+	}
+	return user, nil
+}
+
+// identityKey identifies an external identity in the in-memory repository
+type identityKey struct{ provider, subject string }
+
+// externalIdentities is an in-memory ExternalIdentityRepository
+type externalIdentities map[identityKey]int32
+
+func (r externalIdentities) Link(_ context.Context, userID int32, provider, subject string) error {
+	if owner, ok := r[identityKey{provider, subject}]; ok && owner != userID {
+		return repository.ErrExternalIdentityLinked
+	}
+	r[identityKey{provider, subject}] = userID
+	return nil
+}
+
+func (r externalIdentities) Unlink(_ context.Context, userID int32, provider, subject string) error {
+	if owner, ok := r[identityKey{provider, subject}]; !ok || owner != userID {
+		return repository.ErrExternalIdentityNotFound
+	}
+	delete(r, identityKey{provider, subject})
+	return nil
+}
+
+func (r externalIdentities) FindUserID(_ context.Context, provider, subject string) (int32, error) {
+	userID, ok := r[identityKey{provider, subject}]
+	if !ok {
+		return 0, repository.ErrUserNotFound
+	}
+	return userID, nil
+}
+
+func (r externalIdentities) ListByUser(context.Context, int32) ([]*repository.ExternalIdentity, error) {
+	return nil, nil
+}
+
+func TestLookupUserByExternalIdentity(t *testing.T) {
+	repo := &usersByID{users: map[int32]*pb.User{7: {Id: 7, Name: "Linked"}}}
+	identities := externalIdentities{
+		{"okta", "00u1AbC"}: 7,
+		{"google", "gone"}:  8, // Linked to a user that is no longer active
+	}
+	s := &userServiceServer{repo: repo, externalIdentities: identities}
+
+	lookup := func(provider, subject string) *pb.LookupUserRequest {
+		return &pb.LookupUserRequest{Key: &pb.LookupUserRequest_ExternalIdentity{
+			ExternalIdentity: &pb.ExternalIdentity{Provider: provider, Subject: subject},
+		}}
+	}
+
+	tests := []struct {
+		name   string
+		req    *pb.LookupUserRequest
+		want   codes.Code
+		wantID int32
+	}{
+		{name: "linked identity", req: lookup("okta", "00u1AbC"), want: codes.OK, wantID: 7},
+		{name: "provider is case-insensitive", req: lookup(" Okta ", "00u1AbC"), want: codes.OK, wantID: 7},
+		{name: "subject is matched exactly", req: lookup("okta", "00u1abc"), want: codes.NotFound},
+		{name: "unknown identity", req: lookup("github", "42"), want: codes.NotFound},
+		{name: "identity of an inactive user", req: lookup("google", "gone"), want: codes.NotFound},
+		{name: "missing identity", req: &pb.LookupUserRequest{Key: &pb.LookupUserRequest_ExternalIdentity{}}, want: codes.InvalidArgument},
+		{name: "invalid provider", req: lookup("bad provider", "1"), want: codes.InvalidArgument},
+		{name: "empty subject", req: lookup("okta", "  "), want: codes.InvalidArgument},
+		{name: "no key", req: &pb.LookupUserRequest{}, want: codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, err := s.lookupUser(context.Background(), tt.req)
+			if got := status.Code(err); got != tt.want {
+				t.Fatalf("lookupUser() code = %v, want %v (%v)", got, tt.want, err)
+			}
+			if user.GetId() != tt.wantID {
+				t.Errorf("lookupUser() user = %d, want %d", user.GetId(), tt.wantID)
+			}
+		})
+	}
+
+	t.Run("external identities disabled", func(t *testing.T) {
+		s := &userServiceServer{repo: repo}
+		if _, err := s.lookupUser(context.Background(), lookup("okta", "00u1AbC")); status.Code(err) != codes.FailedPrecondition {
+			t.Errorf("lookupUser() error = %v, want FailedPrecondition", err)
+		}
+	})
+}
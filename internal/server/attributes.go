@@ -0,0 +1,144 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/thatlq1812/service-1-user/internal/attributes"
+	"github.com/thatlq1812/service-1-user/internal/repository"
+	"github.com/thatlq1812/service-1-user/internal/response"
+	pb "github.com/thatlq1812/service-1-user/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// maxAttributesSize caps the encoded size of a user's attributes document
+const maxAttributesSize = 16 << 10
+
+// attributesMerge returns a merge applying an attributes merge patch to the stored attributes of
+// a user, validating the result against the current schema. The repository runs it while the
+// user is locked, so concurrent patches do not overwrite each other.
+func (s *userServiceServer) attributesMerge(ctx context.Context, patch *structpb.Struct) (repository.AttributesMerge, error) {
+	if s.attributeSchemas == nil {
+		return nil, response.GRPCError(codes.FailedPrecondition, "Custom attributes are not enabled")
+	}
+
+	_, schema, err := s.attributeSchemas.Current(ctx)
+	if err != nil {
+		if errors.Is(err, repository.ErrAttributeSchemaNotFound) {
+			return nil, response.GRPCError(codes.FailedPrecondition, "No attribute schema has been defined")
+		}
+		log.Printf("Failed to load attribute schema: %v", err)
+		return nil, response.GRPCError(codes.Internal, "Failed to load attribute schema")
+	}
+
+	return func(current map[string]any) (map[string]any, error) {
+		merged := attributes.MergePatch(current, patch.AsMap())
+
+		encoded, err := json.Marshal(merged)
+		if err != nil {
+			return nil, response.GRPCError(codes.InvalidArgument, "Attributes must be a JSON object")
+		}
+		if len(encoded) > maxAttributesSize {
+			return nil, response.GRPCError(codes.InvalidArgument, "Attributes are too large")
+		}
+
+		if err := schema.Validate(merged); err != nil {
+			return nil, response.GRPCError(codes.InvalidArgument, "Invalid attributes: "+err.Error())
+		}
+
+		return merged, nil
+	}, nil
+}
+
+// attributeSchemaProto converts a stored schema version for the API
+func attributeSchemaProto(stored *repository.AttributeSchema) (*pb.AttributeSchema, error) {
+	document := &structpb.Struct{}
+	if err := document.UnmarshalJSON(stored.Schema); err != nil {
+		return nil, err
+	}
+
+	return &pb.AttributeSchema{
+		Version:   stored.Version,
+		Schema:    document,
+		CreatedBy: stored.CreatedBy,
+		CreatedAt: stored.CreatedAt.Format(time.RFC3339),
+	}, nil
+}
+
+// GetAttributeSchema returns the current JSON Schema for custom profile attributes
+func (s *userServiceServer) GetAttributeSchema(ctx context.Context, req *pb.GetAttributeSchemaRequest) (*pb.GetAttributeSchemaResponse, error) {
+	if s.attributeSchemas == nil {
+		return nil, response.GRPCError(codes.FailedPrecondition, "Custom attributes are not enabled")
+	}
+
+	stored, _, err := s.attributeSchemas.Current(ctx)
+	if err != nil {
+		if errors.Is(err, repository.ErrAttributeSchemaNotFound) {
+			return nil, response.GRPCError(codes.NotFound, "No attribute schema has been defined")
+		}
+		return nil, response.GRPCError(codes.Internal, "Failed to get attribute schema")
+	}
+
+	schema, err := attributeSchemaProto(stored)
+	if err != nil {
+		return nil, response.GRPCError(codes.Internal, "Failed to decode attribute schema")
+	}
+
+	return response.GetAttributeSchemaSuccess(schema), nil
+}
+
+// SetAttributeSchema publishes a new attribute schema version (admin only).
+// Existing attributes are not revalidated; the schema applies to later updates.
+func (s *userServiceServer) SetAttributeSchema(ctx context.Context, req *pb.SetAttributeSchemaRequest) (*pb.SetAttributeSchemaResponse, error) {
+	admin, err := s.requireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.attributeSchemas == nil {
+		return nil, response.GRPCError(codes.FailedPrecondition, "Custom attributes are not enabled")
+	}
+	if req.Schema == nil {
+		return nil, response.GRPCError(codes.InvalidArgument, "Schema is required")
+	}
+
+	document, err := req.Schema.MarshalJSON()
+	if err != nil {
+		return nil, response.GRPCError(codes.InvalidArgument, "Schema must be a JSON object")
+	}
+
+	stored, err := s.attributeSchemas.Publish(ctx, document, admin.UserID)
+	if err != nil {
+		if errors.Is(err, attributes.ErrInvalidSchema) {
+			return nil, response.GRPCError(codes.InvalidArgument, err.Error())
+		}
+		return nil, response.GRPCError(codes.Internal, "Failed to save attribute schema")
+	}
+
+	if s.audit != nil {
+		entry := &repository.AuditEntry{
+			ActorUserID: admin.UserID,
+			Action:      repository.AuditActionAttributeSchemaUpdate,
+			Metadata: map[string]any{
+				"version": stored.Version,
+			},
+		}
+		if err := s.audit.Record(ctx, entry); err != nil {
+			log.Printf("Failed to record attribute schema audit entry for version %d: %v", stored.Version, err)
+		}
+	}
+
+	log.Printf("Admin %d published attribute schema version %d", admin.UserID, stored.Version)
+
+	schema, err := attributeSchemaProto(stored)
+	if err != nil {
+		return nil, response.GRPCError(codes.Internal, "Failed to decode attribute schema")
+	}
+
+	return response.SetAttributeSchemaSuccess(schema), nil
+}
@@ -0,0 +1,173 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+
+	"github.com/thatlq1812/service-1-user/internal/avatar"
+	"github.com/thatlq1812/service-1-user/internal/repository"
+	"github.com/thatlq1812/service-1-user/internal/response"
+	pb "github.com/thatlq1812/service-1-user/proto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// defaultAvatarMaxSize caps the size of an uploaded image before processing
+const defaultAvatarMaxSize = 5 << 20
+
+// authorizeAvatarChange allows users to change their own avatar and admins to change anyone's
+func (s *userServiceServer) authorizeAvatarChange(ctx context.Context, userID int32) error {
+	if userID <= 0 {
+		return response.GRPCError(codes.InvalidArgument, "User ID must be positive")
+	}
+
+	caller, err := s.authenticate(ctx)
+	if err != nil {
+		return err
+	}
+	if caller.UserID != userID && (!caller.IsAdmin() || caller.Act != nil) {
+		return response.GRPCError(codes.PermissionDenied, "Not allowed to change this user's avatar.")
+	}
+
+	if s.avatars == nil {
+		return response.GRPCError(codes.FailedPrecondition, "Avatars are not enabled")
+	}
+	return nil
+}
+
+// UploadAvatar receives an image in chunks, stores square renditions of it and makes it the user's avatar
+func (s *userServiceServer) UploadAvatar(stream grpc.ClientStreamingServer[pb.UploadAvatarRequest, pb.UploadAvatarResponse]) error {
+	ctx := stream.Context()
+
+	first, err := stream.Recv()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return response.GRPCError(codes.InvalidArgument, "Avatar metadata is required")
+		}
+		return err
+	}
+	metadata := first.GetMetadata()
+	if metadata == nil {
+		return response.GRPCError(codes.InvalidArgument, "First message must carry the avatar metadata")
+	}
+
+	if err := s.authorizeAvatarChange(ctx, metadata.UserId); err != nil {
+		return err
+	}
+	if !avatar.AllowedContentTypes[metadata.ContentType] {
+		return response.GRPCError(codes.InvalidArgument, "Content type must be image/jpeg, image/png, image/gif or image/webp")
+	}
+
+	// Collect the chunks, refusing to buffer more than the size limit
+	var data []byte
+	for {
+		msg, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if msg.GetMetadata() != nil {
+			return response.GRPCError(codes.InvalidArgument, "Avatar metadata must only be sent once")
+		}
+
+		chunk := msg.GetChunk()
+		if len(data)+len(chunk) > s.avatarMaxSize {
+			return response.GRPCError(codes.InvalidArgument, fmt.Sprintf("Avatar exceeds the maximum size of %d bytes", s.avatarMaxSize))
+		}
+		data = append(data, chunk...)
+	}
+
+	if len(data) == 0 {
+		return response.GRPCError(codes.InvalidArgument, "Avatar image is empty")
+	}
+	if avatar.DetectContentType(data) != metadata.ContentType {
+		return response.GRPCError(codes.InvalidArgument, "Avatar content does not match the declared content type")
+	}
+
+	renditions, err := avatar.Process(data)
+	if err != nil {
+		if errors.Is(err, avatar.ErrUnsupportedType) || errors.Is(err, avatar.ErrInvalidImage) {
+			return response.GRPCError(codes.InvalidArgument, "Avatar is not a valid image: "+err.Error())
+		}
+		return response.GRPCError(codes.Internal, "Failed to process avatar")
+	}
+
+	revision, err := avatar.NewRevision()
+	if err != nil {
+		return response.GRPCError(codes.Internal, "Failed to store avatar")
+	}
+	prefix := avatar.RevisionPrefix(metadata.UserId, revision)
+
+	urls := make([]*pb.AvatarRendition, 0, len(renditions))
+	for _, rendition := range renditions {
+		key := prefix + rendition.Name
+		if err := s.avatars.Put(ctx, key, avatar.ContentType, rendition.Data); err != nil {
+			log.Printf("Failed to store avatar of user %d: %v", metadata.UserId, err)
+			s.deleteAvatarBlobs(ctx, metadata.UserId, prefix)
+			return response.GRPCError(codes.Internal, "Failed to store avatar")
+		}
+		urls = append(urls, &pb.AvatarRendition{
+			Size: int32(rendition.Size),
+			Url:  s.avatars.URL(key),
+		})
+	}
+
+	user, previousKey, err := s.repo.SetAvatar(ctx, metadata.UserId, prefix, urls[0].Url)
+	if err != nil {
+		s.deleteAvatarBlobs(ctx, metadata.UserId, prefix)
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return response.GRPCError(codes.NotFound, "User not found")
+		}
+		if errors.Is(err, repository.ErrUserAnonymized) {
+			return response.GRPCError(codes.FailedPrecondition, "User has been anonymized and can no longer be changed")
+		}
+		return response.GRPCError(codes.Internal, "Failed to update avatar")
+	}
+
+	// The replaced avatar is no longer referenced
+	if previousKey != "" {
+		s.deleteAvatarBlobs(ctx, user.Id, previousKey)
+	}
+
+	return stream.SendAndClose(response.UploadAvatarSuccess(user, urls))
+}
+
+// DeleteAvatar removes the user's avatar; deleting a missing avatar succeeds
+func (s *userServiceServer) DeleteAvatar(ctx context.Context, req *pb.DeleteAvatarRequest) (*pb.DeleteAvatarResponse, error) {
+	if err := s.authorizeAvatarChange(ctx, req.UserId); err != nil {
+		return nil, err
+	}
+
+	user, previousKey, err := s.repo.SetAvatar(ctx, req.UserId, "", "")
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, response.GRPCError(codes.NotFound, "User not found")
+		}
+		if errors.Is(err, repository.ErrUserAnonymized) {
+			return nil, response.GRPCError(codes.FailedPrecondition, "User has been anonymized and can no longer be changed")
+		}
+		return nil, response.GRPCError(codes.Internal, "Failed to delete avatar")
+	}
+
+	if previousKey != "" {
+		s.deleteAvatarBlobs(ctx, user.Id, previousKey)
+	}
+
+	return response.DeleteAvatarSuccess(user), nil
+}
+
+// deleteAvatarBlobs removes stored avatar files; failures only leave unreferenced files behind and are logged
+func (s *userServiceServer) deleteAvatarBlobs(ctx context.Context, userID int32, prefix string) {
+	if s.avatars == nil {
+		return
+	}
+	if err := s.avatars.DeletePrefix(ctx, prefix); err != nil {
+		log.Printf("Failed to delete avatar files %s of user %d: %v", prefix, userID, err)
+	}
+}
@@ -0,0 +1,103 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/thatlq1812/service-1-user/internal/response"
+	pb "github.com/thatlq1812/service-1-user/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// maxBatchSize is the maximum number of IDs per BatchGetUsers call
+const maxBatchSize = 100
+
+// BatchGetUsers retrieves many users with one query, so callers resolving lists of users
+// (e.g. article authors) do not need one GetUser call per ID
+func (s *userServiceServer) BatchGetUsers(ctx context.Context, req *pb.BatchGetUsersRequest) (*pb.BatchGetUsersResponse, error) {
+	if len(req.Ids) == 0 {
+		return nil, response.GRPCError(codes.InvalidArgument, "At least one user ID is required")
+	}
+
+	// Duplicates are looked up and returned once, keeping the first position
+	ids := make([]int32, 0, len(req.Ids))
+	seen := make(map[int32]bool, len(req.Ids))
+	for _, id := range req.Ids {
+		if id <= 0 {
+			return nil, response.GRPCError(codes.InvalidArgument, "User IDs must be positive")
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) > maxBatchSize {
+		return nil, response.GRPCError(codes.InvalidArgument, fmt.Sprintf("At most %d user IDs can be requested at once", maxBatchSize))
+	}
+
+	fields, err := userMaskFields(req.FieldMask)
+	if err != nil {
+		return nil, err
+	}
+
+	found, err := s.repo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, response.GRPCError(codes.Internal, "Failed to get users")
+	}
+
+	byID := make(map[int32]*pb.User, len(found))
+	for _, user := range found {
+		byID[user.Id] = user
+	}
+
+	users := make([]*pb.User, 0, len(found))
+	missing := []int32{}
+	for _, id := range ids {
+		user, ok := byID[id]
+		if !ok {
+			missing = append(missing, id)
+			continue
+		}
+		users = append(users, maskUser(user, fields))
+	}
+
+	s.redactPendingEmails(ctx, users...)
+	return response.BatchGetUsersSuccess(users, missing), nil
+}
+
+// userMaskFields resolves a field mask to top-level User fields (nil = all fields)
+func userMaskFields(mask *fieldmaskpb.FieldMask) ([]protoreflect.FieldDescriptor, error) {
+	if len(mask.GetPaths()) == 0 {
+		return nil, nil
+	}
+
+	descriptor := (&pb.User{}).ProtoReflect().Descriptor().Fields()
+	fields := make([]protoreflect.FieldDescriptor, 0, len(mask.Paths))
+	for _, path := range mask.Paths {
+		field := descriptor.ByName(protoreflect.Name(path))
+		if field == nil {
+			return nil, response.GRPCError(codes.InvalidArgument, "Unknown user field in field_mask: "+path)
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// maskUser returns a copy of user with only the given fields and the ID set (nil fields = user unchanged)
+func maskUser(user *pb.User, fields []protoreflect.FieldDescriptor) *pb.User {
+	if fields == nil {
+		return user
+	}
+
+	masked := &pb.User{Id: user.Id}
+	src, dst := user.ProtoReflect(), masked.ProtoReflect()
+	for _, field := range fields {
+		if src.Has(field) {
+			dst.Set(field, src.Get(field))
+		}
+	}
+	return masked
+}
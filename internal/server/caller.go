@@ -0,0 +1,262 @@
+package server
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/thatlq1812/service-1-user/internal/auth"
+	"github.com/thatlq1812/service-1-user/internal/response"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+const (
+	authorizationHeader = "authorization"
+	dpopHeader          = "dpop"
+
+	// gRPC calls are HTTP/2 POSTs; DPoP proofs for RPCs use the full method name as htu
+	dpopRPCMethod = "POST"
+)
+
+// rpcScopes lists the scope an exchanged (scoped) token needs for each authenticated RPC.
+// RPCs not listed, such as all admin RPCs, cannot be called with scoped tokens at all.
+// Tokens without a scope claim (from Login) are unrestricted.
+var rpcScopes = map[string]string{
+	"UpdateUser":             "profile:write",
+	"UploadAvatar":           "profile:write",
+	"DeleteAvatar":           "profile:write",
+	"RequestEmailChange":     "email:write",
+	"DeleteUser":             "account:delete",
+	"RequestAccountDeletion": "account:delete",
+	"ExportUserData":         "data:export",
+	"ListLoginEvents":        "login_history:read",
+}
+
+// metadataValue returns the first value of an incoming metadata key
+func metadataValue(ctx context.Context, key string) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	values := md.Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return strings.TrimSpace(values[0])
+}
+
+// WithTrustedProxies trusts client IP and user agent headers (x-forwarded-for, x-real-ip,
+// x-user-agent) only on requests arriving directly from these networks
+func WithTrustedProxies(proxies []netip.Prefix) Option {
+	return func(s *userServiceServer) {
+		s.trustedProxies = proxies
+	}
+}
+
+// peerIP returns the address of the direct peer of the request
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	ip := p.Addr.String()
+	if host, _, err := net.SplitHostPort(ip); err == nil {
+		ip = host
+	}
+	return ip
+}
+
+// isTrustedProxy reports whether ip belongs to a configured trusted proxy network
+func (s *userServiceServer) isTrustedProxy(ip string) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range s.trustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// clientInfo returns the client IP and user agent of the request. Forwarding headers are
+// honoured only when the direct peer is a trusted proxy; anyone else could forge them.
+// The client IP is the last x-forwarded-for hop not added by a trusted proxy.
+func (s *userServiceServer) clientInfo(ctx context.Context) (ip, userAgent string) {
+	ip = peerIP(ctx)
+	userAgent = metadataValue(ctx, "user-agent")
+	if !s.isTrustedProxy(ip) {
+		return ip, userAgent
+	}
+
+	if forwarded := forwardedFor(ctx); len(forwarded) > 0 {
+		// Walk back from the proxy closest to us; earlier entries are client-controlled
+		client := forwarded[0]
+		for i := len(forwarded) - 1; i >= 0; i-- {
+			client = forwarded[i]
+			if !s.isTrustedProxy(client) {
+				break
+			}
+		}
+		ip = client
+	} else if realIP := metadataValue(ctx, "x-real-ip"); realIP != "" {
+		ip = realIP
+	}
+
+	if forwardedAgent := metadataValue(ctx, "x-user-agent"); forwardedAgent != "" {
+		userAgent = forwardedAgent
+	}
+	return ip, userAgent
+}
+
+// forwardedFor returns the hops of all x-forwarded-for metadata values in order
+func forwardedFor(ctx context.Context) []string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	var hops []string
+	for _, value := range md.Get("x-forwarded-for") {
+		for _, hop := range strings.Split(value, ",") {
+			if hop = strings.TrimSpace(hop); hop != "" {
+				hops = append(hops, hop)
+			}
+		}
+	}
+	return hops
+}
+
+// bearerToken extracts the access token from "authorization: Bearer <token>" (or "DPoP <token>") metadata
+func bearerToken(ctx context.Context) string {
+	scheme, token, found := strings.Cut(metadataValue(ctx, authorizationHeader), " ")
+	if !found {
+		return ""
+	}
+	if !strings.EqualFold(scheme, auth.TokenTypeBearer) && !strings.EqualFold(scheme, auth.TokenTypeDPoP) {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
+
+// dpopProof returns the DPoP proof JWT sent in "dpop" metadata, if any
+func dpopProof(ctx context.Context) string {
+	return metadataValue(ctx, dpopHeader)
+}
+
+// verifyDPoPForRPC verifies a DPoP proof bound to the current RPC and returns its key thumbprint.
+// It returns an empty thumbprint when the client sent no proof.
+func (s *userServiceServer) verifyDPoPForRPC(ctx context.Context) (string, error) {
+	proof := dpopProof(ctx)
+	if proof == "" {
+		return "", nil
+	}
+	if s.dpop == nil {
+		return "", response.GRPCError(codes.InvalidArgument, "DPoP is not enabled on this server.")
+	}
+
+	method, _ := grpc.Method(ctx)
+	verified, err := s.dpop.Verify(ctx, proof, dpopRPCMethod, method, "")
+	if err != nil {
+		return "", response.GRPCError(codes.Unauthenticated, "Invalid DPoP proof")
+	}
+	return verified.Thumbprint, nil
+}
+
+// verifyTokenBinding rejects sender-constrained tokens presented without a matching DPoP proof
+func (s *userServiceServer) verifyTokenBinding(ctx context.Context, claims *auth.Claims, proof, method, uri, token string) error {
+	if claims.Cnf == nil {
+		return nil
+	}
+	if s.dpop == nil {
+		return response.GRPCError(codes.Unauthenticated, "DPoP-bound token cannot be verified")
+	}
+	if err := s.dpop.VerifyBinding(ctx, claims, proof, method, uri, token); err != nil {
+		return response.GRPCError(codes.Unauthenticated, "Token requires a valid DPoP proof")
+	}
+	return nil
+}
+
+// authenticate validates the caller's access token (and DPoP proof for bound tokens) from request metadata
+func (s *userServiceServer) authenticate(ctx context.Context) (*auth.Claims, error) {
+	token := bearerToken(ctx)
+	if token == "" {
+		return nil, response.GRPCError(codes.Unauthenticated, "Missing bearer token in authorization metadata.")
+	}
+
+	claims, err := s.tokenManager.ValidateToken(ctx, token)
+	if err != nil {
+		return nil, response.GRPCError(codes.Unauthenticated, "Invalid or expired token")
+	}
+
+	// Exchanged tokens meant for other services cannot be used here
+	if len(claims.Audience) > 0 && !claims.HasAudience(serviceAudience) {
+		return nil, response.GRPCError(codes.Unauthenticated, "Token was not issued for this service")
+	}
+
+	method, _ := grpc.Method(ctx)
+	if err := s.verifyTokenBinding(ctx, claims, dpopProof(ctx), dpopRPCMethod, method, token); err != nil {
+		return nil, err
+	}
+
+	// Scoped tokens may only call the RPCs their scopes grant
+	if scopes := claims.Scopes(); len(scopes) > 0 {
+		required, ok := rpcScopes[path.Base(method)]
+		if !ok || !slices.Contains(scopes, required) {
+			return nil, response.GRPCError(codes.PermissionDenied, "Token scope does not allow this operation.")
+		}
+	}
+
+	return claims, nil
+}
+
+// requireAdmin authenticates the caller and ensures it is an admin acting as itself
+func (s *userServiceServer) requireAdmin(ctx context.Context) (*auth.Claims, error) {
+	claims, err := s.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Delegated tokens never carry the delegating admin's privileges
+	if !claims.IsAdmin() || claims.Act != nil {
+		return nil, response.GRPCError(codes.PermissionDenied, "Admin role required.")
+	}
+
+	return claims, nil
+}
+
+// requireFreshAuth enforces the step-up policy for an RPC acting on userID.
+// The caller must be the user (or an admin) and have authenticated within the RPC's max age.
+func (s *userServiceServer) requireFreshAuth(ctx context.Context, rpcName string, userID int32) error {
+	maxAge, ok := s.stepUpPolicy[rpcName]
+	if !ok {
+		return nil
+	}
+
+	claims, err := s.authenticate(ctx)
+	if err != nil {
+		return err
+	}
+
+	if claims.UserID != userID && (!claims.IsAdmin() || claims.Act != nil) {
+		return response.GRPCError(codes.PermissionDenied, "Not allowed to modify this user.")
+	}
+
+	// Tokens without auth_time (e.g. impersonation) never satisfy step-up
+	age, ok := claims.AuthAge()
+	if !ok || age > maxAge {
+		return response.StepUpRequiredError(maxAge)
+	}
+
+	return nil
+}
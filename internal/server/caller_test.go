@@ -0,0 +1,79 @@
+package server
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"testing"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func TestClientInfoTrustsForwardingHeadersOnlyFromProxies(t *testing.T) {
+	s := &userServiceServer{trustedProxies: []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}}
+
+	tests := []struct {
+		name          string
+		peer          string
+		md            metadata.MD
+		wantIP        string
+		wantUserAgent string
+	}{
+		{
+			name:          "untrusted peer forging headers",
+			peer:          "203.0.113.7",
+			md:            metadata.Pairs("x-forwarded-for", "198.51.100.1", "x-real-ip", "198.51.100.2", "x-user-agent", "forged", "user-agent", "grpc-go"),
+			wantIP:        "203.0.113.7",
+			wantUserAgent: "grpc-go",
+		},
+		{
+			name:          "trusted proxy",
+			peer:          "10.0.0.5",
+			md:            metadata.Pairs("x-forwarded-for", "198.51.100.1", "x-user-agent", "Mozilla/5.0", "user-agent", "envoy"),
+			wantIP:        "198.51.100.1",
+			wantUserAgent: "Mozilla/5.0",
+		},
+		{
+			name:          "client-supplied hop before the proxy chain",
+			peer:          "10.0.0.5",
+			md:            metadata.Pairs("x-forwarded-for", "192.0.2.99, 198.51.100.1, 10.0.0.9"),
+			wantIP:        "198.51.100.1",
+			wantUserAgent: "",
+		},
+		{
+			name:          "trusted proxy with x-real-ip",
+			peer:          "10.0.0.5",
+			md:            metadata.Pairs("x-real-ip", "198.51.100.2"),
+			wantIP:        "198.51.100.2",
+			wantUserAgent: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), tt.md)
+			ctx = peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(tt.peer), Port: 4242}})
+
+			ip, userAgent := s.clientInfo(ctx)
+			if ip != tt.wantIP || userAgent != tt.wantUserAgent {
+				t.Errorf("clientInfo() = (%q, %q), want (%q, %q)", ip, userAgent, tt.wantIP, tt.wantUserAgent)
+			}
+		})
+	}
+}
+
+func TestRateLimitKey(t *testing.T) {
+	tests := map[string]string{
+		"203.0.113.7":          "203.0.113.7",
+		"::ffff:203.0.113.7":   "203.0.113.7",
+		"2001:db8:1:2:3:4:5:6": "2001:db8:1:2::/64",
+		"2001:db8:1:2:ffff::1": "2001:db8:1:2::/64",
+		"not an ip":            "not an ip",
+	}
+	for ip, want := range tests {
+		if got := rateLimitKey(ip); got != want {
+			t.Errorf("rateLimitKey(%q) = %q, want %q", ip, got, want)
+		}
+	}
+}
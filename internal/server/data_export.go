@@ -0,0 +1,453 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"time"
+
+	"github.com/thatlq1812/service-1-user/internal/repository"
+	"github.com/thatlq1812/service-1-user/internal/response"
+	pb "github.com/thatlq1812/service-1-user/proto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	// exportFormatVersion is bumped whenever the layout of exported files changes
+	exportFormatVersion = 1
+
+	// exportChunkSize is the maximum file content carried by one stream message
+	exportChunkSize = 64 * 1024
+
+	// exportPageSize is the page size used to read paginated sources
+	exportPageSize = 500
+)
+
+// exportManifest describes the files of an archive; it is always sent last
+type exportManifest struct {
+	FormatVersion int                 `json:"format_version"`
+	UserID        int32               `json:"user_id"`
+	RequestedBy   int32               `json:"requested_by"`
+	GeneratedAt   string              `json:"generated_at"`
+	Files         []exportManifestRef `json:"files"`
+	Notes         []string            `json:"notes,omitempty"`
+}
+
+type exportManifestRef struct {
+	Name    string `json:"name"`
+	Records int    `json:"records"`
+	Bytes   int    `json:"bytes"`
+	SHA256  string `json:"sha256"`
+}
+
+type exportProfile struct {
+	ID                  int32          `json:"id"`
+	Name                string         `json:"name"`
+	Email               string         `json:"email"`
+	Username            string         `json:"username,omitempty"`
+	Role                string         `json:"role"`
+	Status              string         `json:"status"`
+	StatusReason        string         `json:"status_reason,omitempty"`
+	SuspendedUntil      string         `json:"suspended_until,omitempty"`
+	CreatedAt           string         `json:"created_at"`
+	UpdatedAt           string         `json:"updated_at,omitempty"`
+	LastLoginAt         string         `json:"last_login_at,omitempty"`
+	DeletionScheduledAt string         `json:"deletion_scheduled_at,omitempty"`
+	Attributes          map[string]any `json:"attributes,omitempty"`
+	AvatarURL           string         `json:"avatar_url,omitempty"`
+}
+
+type exportLoginEvent struct {
+	EventType     string    `json:"event_type"`
+	Outcome       string    `json:"outcome"`
+	FailureReason string    `json:"failure_reason,omitempty"`
+	IPAddress     string    `json:"ip_address,omitempty"`
+	UserAgent     string    `json:"user_agent,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type exportExternalIdentity struct {
+	Provider string    `json:"provider"`
+	Subject  string    `json:"subject"`
+	LinkedAt time.Time `json:"linked_at"`
+}
+
+type exportDevice struct {
+	DeviceID    string    `json:"device_id,omitempty"`
+	UserAgent   string    `json:"user_agent,omitempty"`
+	IPPrefix    string    `json:"ip_prefix,omitempty"`
+	FirstSeenAt time.Time `json:"first_seen_at"`
+	LastSeenAt  time.Time `json:"last_seen_at"`
+}
+
+type exportAuditEntry struct {
+	Action       string         `json:"action"`
+	ActorUserID  int32          `json:"actor_user_id,omitempty"`
+	TargetUserID int32          `json:"target_user_id,omitempty"`
+	Reason       string         `json:"reason,omitempty"`
+	Metadata     map[string]any `json:"metadata,omitempty"`
+	CreatedAt    time.Time      `json:"created_at"`
+}
+
+// ExportUserData streams a machine-readable archive of everything this service stores about
+// a user (data subject access request). Each file is streamed in chunks of at most 64 KiB
+// while it is read, followed by manifest.json listing every file with its record count and SHA-256.
+func (s *userServiceServer) ExportUserData(req *pb.ExportUserDataRequest, stream grpc.ServerStreamingServer[pb.ExportUserDataResponse]) error {
+	ctx := stream.Context()
+
+	if req.UserId <= 0 {
+		return response.GRPCError(codes.InvalidArgument, "User ID must be positive")
+	}
+
+	caller, err := s.authenticate(ctx)
+	if err != nil {
+		return err
+	}
+	if caller.UserID != req.UserId && (!caller.IsAdmin() || caller.Act != nil) {
+		return response.GRPCError(codes.PermissionDenied, "Not allowed to export this user's data.")
+	}
+	if err := s.requireFreshAuth(ctx, "ExportUserData", req.UserId); err != nil {
+		return err
+	}
+
+	user, err := s.repo.GetByID(ctx, req.UserId)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return response.GRPCError(codes.NotFound, "User not found")
+		}
+		return response.GRPCError(codes.Internal, "Failed to get user")
+	}
+
+	// Record the export before handing out any data
+	if s.audit != nil {
+		entry := &repository.AuditEntry{
+			ActorUserID:  caller.UserID,
+			Action:       repository.AuditActionExport,
+			TargetUserID: user.Id,
+		}
+		if err := s.audit.Record(ctx, entry); err != nil {
+			return response.GRPCError(codes.Internal, "Failed to record export audit entry")
+		}
+	}
+
+	archive := &exportArchive{stream: stream}
+	notes, err := s.writeExportFiles(ctx, archive, user)
+	if err != nil {
+		if archive.sendErr != nil {
+			return archive.sendErr
+		}
+		return response.GRPCError(codes.Internal, "Failed to collect user data")
+	}
+
+	manifest := exportManifest{
+		FormatVersion: exportFormatVersion,
+		UserID:        user.Id,
+		RequestedBy:   caller.UserID,
+		GeneratedAt:   time.Now().UTC().Format(time.RFC3339),
+		Files:         archive.files,
+		Notes:         notes,
+	}
+	if err := archive.writeDocument("manifest.json", len(manifest.Files), manifest, true); err != nil {
+		if archive.sendErr != nil {
+			return archive.sendErr
+		}
+		return response.GRPCError(codes.Internal, "Failed to build export manifest")
+	}
+	return nil
+}
+
+// writeExportFiles streams every source holding data about the user, reading paginated
+// sources page by page. Sources that are disabled in this deployment are reported in the notes.
+func (s *userServiceServer) writeExportFiles(ctx context.Context, archive *exportArchive, user *pb.User) ([]string, error) {
+	var notes []string
+
+	// Profile, role and account status
+	profile := exportProfile{
+		ID:                  user.Id,
+		Name:                user.Name,
+		Email:               user.Email,
+		Username:            user.Username,
+		Role:                user.Role,
+		Status:              user.Status.String(),
+		StatusReason:        user.StatusReason,
+		SuspendedUntil:      user.SuspendedUntil,
+		CreatedAt:           user.CreatedAt,
+		UpdatedAt:           user.UpdatedAt,
+		LastLoginAt:         user.LastLoginAt,
+		DeletionScheduledAt: user.DeletionScheduledAt,
+		Attributes:          user.GetAttributes().AsMap(),
+		AvatarURL:           user.AvatarUrl,
+	}
+	if err := archive.writeDocument("profile.json", 1, profile, false); err != nil {
+		return nil, err
+	}
+
+	// Login history, including token refreshes. Pages are read by ID keyset, so logins during
+	// the export neither repeat nor shift events; they start after the snapshot and are left out.
+	file := archive.create("login_history.json")
+	if s.loginEvents != nil {
+		for beforeID := int64(0); ; {
+			page, err := s.loginEvents.ListByUserBefore(ctx, user.Id, beforeID, exportPageSize)
+			if err != nil {
+				return nil, err
+			}
+			for _, event := range page {
+				record := exportLoginEvent{
+					EventType:     event.EventType,
+					Outcome:       event.Outcome,
+					FailureReason: event.FailureReason,
+					IPAddress:     event.IPAddress,
+					UserAgent:     event.UserAgent,
+					CreatedAt:     event.CreatedAt,
+				}
+				if err := file.writeRecord(record); err != nil {
+					return nil, err
+				}
+			}
+			if len(page) < exportPageSize {
+				break
+			}
+			beforeID = page[len(page)-1].ID
+		}
+	} else {
+		notes = append(notes, "Login history is not enabled in this deployment.")
+	}
+	if err := file.closeArray(); err != nil {
+		return nil, err
+	}
+
+	// Sessions are stateless signed tokens, so there is nothing more to export about them
+	notes = append(notes, "Sessions are not exported: refresh tokens are self-contained and not stored by this service. Every login and token refresh is listed in login_history.json.")
+
+	// Known devices (clients the user has signed in from); a user has only a handful
+	file = archive.create("devices.json")
+	if s.devices != nil {
+		list, err := s.devices.ListByUser(ctx, user.Id)
+		if err != nil {
+			return nil, err
+		}
+		for _, device := range list {
+			record := exportDevice{
+				DeviceID:    device.DeviceID,
+				UserAgent:   device.UserAgent,
+				IPPrefix:    device.IPPrefix,
+				FirstSeenAt: device.FirstSeenAt,
+				LastSeenAt:  device.LastSeenAt,
+			}
+			if err := file.writeRecord(record); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		notes = append(notes, "Device tracking is not enabled in this deployment.")
+	}
+	if err := file.closeArray(); err != nil {
+		return nil, err
+	}
+
+	// Accounts at external identity providers linked to the user
+	file = archive.create("external_identities.json")
+	if s.externalIdentities != nil {
+		list, err := s.externalIdentities.ListByUser(ctx, user.Id)
+		if err != nil {
+			return nil, err
+		}
+		for _, identity := range list {
+			record := exportExternalIdentity{
+				Provider: identity.Provider,
+				Subject:  identity.Subject,
+				LinkedAt: identity.CreatedAt,
+			}
+			if err := file.writeRecord(record); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		notes = append(notes, "External identities are not enabled in this deployment.")
+	}
+	if err := file.closeArray(); err != nil {
+		return nil, err
+	}
+
+	// Password changes, bounded by the history depth; hashes are security data and never exported
+	file = archive.create("password_changes.json")
+	if s.passwordHistory != nil {
+		changes, err := s.passwordHistory.ListChanges(ctx, user.Id)
+		if err != nil {
+			return nil, err
+		}
+		for _, change := range changes {
+			if err := file.writeRecord(change); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := file.closeArray(); err != nil {
+		return nil, err
+	}
+
+	// Audit entries where the user acted or was acted upon
+	file = archive.create("audit_log.json")
+	if s.audit != nil {
+		var afterID int64
+		for {
+			entries, err := s.audit.ListByUser(ctx, user.Id, afterID, exportPageSize)
+			if err != nil {
+				return nil, err
+			}
+			for _, entry := range entries {
+				record := exportAuditEntry{
+					Action:       entry.Action,
+					ActorUserID:  entry.ActorUserID,
+					TargetUserID: entry.TargetUserID,
+					Reason:       entry.Reason,
+					Metadata:     entry.Metadata,
+					CreatedAt:    entry.CreatedAt,
+				}
+				if err := file.writeRecord(record); err != nil {
+					return nil, err
+				}
+				afterID = entry.ID
+			}
+			if len(entries) < exportPageSize {
+				break
+			}
+		}
+	} else {
+		notes = append(notes, "The audit trail is not enabled in this deployment.")
+	}
+	if err := file.closeArray(); err != nil {
+		return nil, err
+	}
+
+	// Consents are not collected by this service; the empty file keeps the archive layout stable
+	if err := archive.writeDocument("consents.json", 0, []any{}, false); err != nil {
+		return nil, err
+	}
+	notes = append(notes, "This service does not record consents; consents.json is always empty.")
+
+	return notes, nil
+}
+
+// exportArchive streams archive files and collects their manifest entries
+type exportArchive struct {
+	stream   grpc.ServerStreamingServer[pb.ExportUserDataResponse]
+	sequence int32
+	files    []exportManifestRef
+
+	// sendErr is the stream error that aborted the export, returned to gRPC as is
+	sendErr error
+}
+
+// exportFileWriter streams one archive file: content is sent in chunks as soon as a chunk
+// is full and hashed on the way, so no file is ever held in memory as a whole
+type exportFileWriter struct {
+	archive *exportArchive
+	name    string
+	pending []byte
+	hash    hash.Hash
+	bytes   int
+	records int
+}
+
+// create starts a new file of the archive
+func (a *exportArchive) create(name string) *exportFileWriter {
+	return &exportFileWriter{archive: a, name: name, hash: sha256.New()}
+}
+
+// writeDocument streams value as a complete indented JSON file
+func (a *exportArchive) writeDocument(name string, records int, value any, endOfArchive bool) error {
+	content, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode %s: %w", name, err)
+	}
+
+	file := a.create(name)
+	if err := file.write(content); err != nil {
+		return err
+	}
+	file.records = records
+	return file.close(endOfArchive)
+}
+
+// writeRecord appends value to the JSON array the file holds, indented like json.MarshalIndent
+func (w *exportFileWriter) writeRecord(value any) error {
+	content, err := json.MarshalIndent(value, "  ", "  ")
+	if err != nil {
+		return fmt.Errorf("encode %s: %w", w.name, err)
+	}
+
+	separator := ",\n  "
+	if w.records == 0 {
+		separator = "[\n  "
+	}
+	if err := w.write([]byte(separator)); err != nil {
+		return err
+	}
+	if err := w.write(content); err != nil {
+		return err
+	}
+	w.records++
+	return nil
+}
+
+// closeArray ends the JSON array written with writeRecord and closes the file
+func (w *exportFileWriter) closeArray() error {
+	end := "\n]"
+	if w.records == 0 {
+		end = "[]"
+	}
+	if err := w.write([]byte(end)); err != nil {
+		return err
+	}
+	return w.close(false)
+}
+
+// write hashes p and sends every full chunk, keeping the remainder until more content
+// follows or the file is closed, so the final chunk can be flagged as last
+func (w *exportFileWriter) write(p []byte) error {
+	w.hash.Write(p)
+	w.bytes += len(p)
+	w.pending = append(w.pending, p...)
+
+	for len(w.pending) > exportChunkSize {
+		if err := w.send(w.pending[:exportChunkSize], false, false); err != nil {
+			return err
+		}
+		w.pending = append(w.pending[:0], w.pending[exportChunkSize:]...)
+	}
+	return nil
+}
+
+// close sends the last chunk and adds the file to the manifest; endOfArchive marks the last file
+func (w *exportFileWriter) close(endOfArchive bool) error {
+	if err := w.send(w.pending, true, endOfArchive); err != nil {
+		return err
+	}
+	w.pending = nil
+
+	w.archive.files = append(w.archive.files, exportManifestRef{
+		Name:    w.name,
+		Records: w.records,
+		Bytes:   w.bytes,
+		SHA256:  hex.EncodeToString(w.hash.Sum(nil)),
+	})
+	return nil
+}
+
+// send streams one chunk of the file
+func (w *exportFileWriter) send(chunk []byte, last, endOfArchive bool) error {
+	a := w.archive
+	if err := a.stream.Send(response.ExportUserDataChunk(w.name, chunk, a.sequence, last, endOfArchive)); err != nil {
+		a.sendErr = err
+		return err
+	}
+	a.sequence++
+	return nil
+}
@@ -0,0 +1,87 @@
+package server
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	pb "github.com/thatlq1812/service-1-user/proto"
+
+	"google.golang.org/grpc"
+)
+
+// recordingStream collects the messages of a server stream
+type recordingStream struct {
+	grpc.ServerStream
+	chunks []*pb.ExportUserDataChunk
+}
+
+func (r *recordingStream) Send(resp *pb.ExportUserDataResponse) error {
+	chunk := resp.Data
+	chunk.Content = bytes.Clone(chunk.Content)
+	r.chunks = append(r.chunks, chunk)
+	return nil
+}
+
+func TestExportFileWriterStreamsChunks(t *testing.T) {
+	stream := &recordingStream{}
+	archive := &exportArchive{stream: stream}
+
+	type record struct {
+		N    int    `json:"n"`
+		Text string `json:"text"`
+	}
+	var records []record
+	file := archive.create("records.json")
+	for i := range 2000 {
+		rec := record{N: i, Text: strings.Repeat("x", 100)}
+		records = append(records, rec)
+		if err := file.writeRecord(rec); err != nil {
+			t.Fatalf("writeRecord: %v", err)
+		}
+	}
+	if err := file.closeArray(); err != nil {
+		t.Fatalf("closeArray: %v", err)
+	}
+	if err := archive.create("empty.json").closeArray(); err != nil {
+		t.Fatalf("closeArray: %v", err)
+	}
+
+	want, _ := json.MarshalIndent(records, "", "  ")
+	contents := map[string][]byte{}
+	for i, chunk := range stream.chunks {
+		if chunk.Sequence != int32(i) {
+			t.Errorf("chunk %d has sequence %d", i, chunk.Sequence)
+		}
+		if len(chunk.Content) > exportChunkSize {
+			t.Errorf("chunk %d has %d bytes, more than %d", i, len(chunk.Content), exportChunkSize)
+		}
+		contents[chunk.FileName] = append(contents[chunk.FileName], chunk.Content...)
+	}
+
+	if !bytes.Equal(contents["records.json"], want) {
+		t.Errorf("records.json differs from json.MarshalIndent output")
+	}
+	if got := string(contents["empty.json"]); got != "[]" {
+		t.Errorf("empty.json = %q, want []", got)
+	}
+
+	var lastChunks int
+	for _, chunk := range stream.chunks {
+		if chunk.LastChunk {
+			lastChunks++
+		}
+	}
+	if lastChunks != 2 || !stream.chunks[len(stream.chunks)-1].LastChunk {
+		t.Errorf("got %d last chunks, want one at the end of each file", lastChunks)
+	}
+
+	sum := sha256.Sum256(want)
+	ref := archive.files[0]
+	if ref.Name != "records.json" || ref.Records != len(records) || ref.Bytes != len(want) || ref.SHA256 != hex.EncodeToString(sum[:]) {
+		t.Errorf("manifest entry = %+v, want %d records, %d bytes, sha256 %x", ref, len(records), len(want), sum)
+	}
+}
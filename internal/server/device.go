@@ -0,0 +1,110 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"net"
+	"time"
+
+	"github.com/thatlq1812/service-1-user/internal/notify"
+	"github.com/thatlq1812/service-1-user/internal/repository"
+	pb "github.com/thatlq1812/service-1-user/proto"
+)
+
+// deviceIDMetadataKey carries an optional stable device identifier set by the client app
+const deviceIDMetadataKey = "x-device-id"
+
+// clientDevice fingerprints the calling client. A client-provided device ID is used as is;
+// otherwise the user agent combined with the IP network prefix identifies the device,
+// so address changes within the same network are not reported as new devices.
+func (s *userServiceServer) clientDevice(ctx context.Context, userID int32) *repository.Device {
+	ip, userAgent := s.clientInfo(ctx)
+	device := &repository.Device{
+		UserID:    userID,
+		DeviceID:  metadataValue(ctx, deviceIDMetadataKey),
+		UserAgent: userAgent,
+		IPPrefix:  ipPrefix(ip),
+	}
+
+	source := "ua:" + device.UserAgent + "|net:" + device.IPPrefix
+	if device.DeviceID != "" {
+		source = "device:" + device.DeviceID
+	}
+	sum := sha256.Sum256([]byte(source))
+	device.Fingerprint = hex.EncodeToString(sum[:])
+
+	return device
+}
+
+// ipPrefix returns the /24 network of an IPv4 address or the /48 network of an IPv6 address
+func ipPrefix(ip string) string {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return ip
+	}
+	if v4 := parsed.To4(); v4 != nil {
+		return (&net.IPNet{IP: v4.Mask(net.CIDRMask(24, 32)), Mask: net.CIDRMask(24, 32)}).String()
+	}
+	return (&net.IPNet{IP: parsed.Mask(net.CIDRMask(48, 128)), Mask: net.CIDRMask(48, 128)}).String()
+}
+
+// checkLoginDevice compares the client with the user's known devices after a successful login.
+// A new device (other than the user's very first) is recorded as a security event and the
+// user is notified. Failures are logged only, so detection never blocks authentication.
+func (s *userServiceServer) checkLoginDevice(ctx context.Context, user *pb.User) {
+	if s.devices == nil {
+		return
+	}
+
+	device := s.clientDevice(ctx, user.Id)
+	isNew, knownDevices, err := s.devices.Touch(ctx, device)
+	if err != nil {
+		log.Printf("Failed to check login device for user %d: %v", user.Id, err)
+		return
+	}
+	if !isNew || knownDevices == 0 {
+		return
+	}
+
+	s.recordLoginEvent(ctx, user.Id, user.Email, repository.LoginEventNewDevice, repository.LoginOutcomeSuccess, "")
+
+	if s.notifier == nil {
+		return
+	}
+
+	ip, _ := s.clientInfo(ctx)
+	notification := &notify.Notification{
+		Type:    notify.TypeNewDeviceLogin,
+		UserID:  user.Id,
+		Email:   user.Email,
+		Subject: "New sign-in to your account",
+		Body: fmt.Sprintf(
+			"Your account was signed in from a new device (%s, IP %s) at %s. If this was not you, change your password immediately.",
+			deviceLabel(device), ip, device.FirstSeenAt.UTC().Format(time.RFC1123),
+		),
+		Metadata: map[string]string{
+			"fingerprint": device.Fingerprint,
+			"device_id":   device.DeviceID,
+			"user_agent":  device.UserAgent,
+			"ip_address":  ip,
+			"ip_prefix":   device.IPPrefix,
+		},
+	}
+	if err := s.notifier.Notify(ctx, notification); err != nil {
+		log.Printf("Failed to send new device notification to user %d: %v", user.Id, err)
+	}
+}
+
+// deviceLabel describes a device for humans
+func deviceLabel(device *repository.Device) string {
+	if device.UserAgent != "" {
+		return device.UserAgent
+	}
+	if device.DeviceID != "" {
+		return "device " + device.DeviceID
+	}
+	return "unknown device"
+}
@@ -0,0 +1,212 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/thatlq1812/service-1-user/internal/auth"
+	"github.com/thatlq1812/service-1-user/internal/notify"
+	"github.com/thatlq1812/service-1-user/internal/repository"
+	"github.com/thatlq1812/service-1-user/internal/response"
+	pb "github.com/thatlq1812/service-1-user/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// defaultEmailChangeTokenTTL is how long an email change confirmation token stays valid
+const defaultEmailChangeTokenTTL = 24 * time.Hour
+
+// newEmailChangeToken returns a random confirmation token and the hash stored in its place
+func newEmailChangeToken() (token, hash string, err error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", fmt.Errorf("generate email change token: %w", err)
+	}
+	token = base64.RawURLEncoding.EncodeToString(b)
+	return token, hashEmailChangeToken(token), nil
+}
+
+// hashEmailChangeToken hashes a confirmation token, so a database leak does not expose usable tokens
+func hashEmailChangeToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// RequestEmailChange starts a verified email change for the caller. The new address only
+// replaces the current one once the token sent to it is confirmed; the current address is told
+// about the request.
+func (s *userServiceServer) RequestEmailChange(ctx context.Context, req *pb.RequestEmailChangeRequest) (*pb.RequestEmailChangeResponse, error) {
+	if s.notifier == nil {
+		return nil, response.GRPCError(codes.FailedPrecondition, "Email change is not available")
+	}
+
+	caller, err := s.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if caller.Act != nil {
+		return nil, response.GRPCError(codes.PermissionDenied, "Delegated tokens cannot change the email address.")
+	}
+	if err := s.requireFreshAuth(ctx, "RequestEmailChange", caller.UserID); err != nil {
+		return nil, err
+	}
+
+	newEmail, err := s.parseEmail(req.NewEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.repo.GetByID(ctx, caller.UserID)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, response.GRPCError(codes.NotFound, "User not found")
+		}
+		return nil, response.GRPCError(codes.Internal, "Failed to get user")
+	}
+	if current, err := s.emails.Parse(user.Email); err == nil && current.Key == newEmail.Key {
+		return nil, response.GRPCError(codes.InvalidArgument, "New email is the same as the current email")
+	}
+
+	// Early duplicate check for a helpful error; ConfirmEmailChange checks again atomically.
+	// Silent signup hides whether an address is registered, so it only fails at confirmation.
+	if !s.silentSignup {
+		if _, err := s.repo.GetByEmail(ctx, newEmail); err == nil {
+			return nil, response.GRPCError(codes.AlreadyExists, "Email is already registered")
+		} else if !errors.Is(err, repository.ErrUserNotFound) {
+			return nil, response.GRPCError(codes.Internal, "Failed to check email")
+		}
+	}
+
+	token, tokenHash, err := newEmailChangeToken()
+	if err != nil {
+		return nil, response.GRPCError(codes.Internal, "Failed to create confirmation token")
+	}
+	expiresAt := time.Now().Add(s.emailChangeTokenTTL)
+
+	user, err = s.repo.RequestEmailChange(ctx, user.Id, newEmail, tokenHash, expiresAt)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, response.GRPCError(codes.NotFound, "User not found")
+		}
+		return nil, response.GRPCError(codes.Internal, "Failed to request email change")
+	}
+
+	confirmation := &notify.Notification{
+		Type:    notify.TypeEmailChangeConfirmation,
+		UserID:  user.Id,
+		Email:   newEmail.Address,
+		Subject: "Confirm your new email address",
+		Body: fmt.Sprintf(
+			"Use this code to confirm %s as the new email address of your account: %s. It expires at %s.",
+			newEmail.Address, token, expiresAt.Format(time.RFC3339),
+		),
+		Metadata: map[string]string{
+			"token":      token,
+			"expires_at": expiresAt.Format(time.RFC3339),
+		},
+	}
+	if err := s.notifier.Notify(ctx, confirmation); err != nil {
+		log.Printf("Failed to send email change confirmation to user %d: %v", user.Id, err)
+		return nil, response.GRPCError(codes.Unavailable, "Failed to send the confirmation email; retry the request")
+	}
+
+	notice := &notify.Notification{
+		Type:    notify.TypeEmailChangeRequested,
+		UserID:  user.Id,
+		Email:   user.Email,
+		Subject: "Email change requested",
+		Body: fmt.Sprintf(
+			"A change of your account's email address to %s was requested. If this wasn't you, change your password now; the address is not changed until the new one is confirmed.",
+			newEmail.Address,
+		),
+		Metadata: map[string]string{
+			"pending_email": newEmail.Address,
+		},
+	}
+	if err := s.notifier.Notify(ctx, notice); err != nil {
+		log.Printf("Failed to send email change notice to user %d: %v", user.Id, err)
+	}
+
+	return response.RequestEmailChangeSuccess(newEmail.Address, expiresAt), nil
+}
+
+// redactPendingEmails clears the unconfirmed email of users returned by public RPCs unless the
+// caller is that user or an admin acting as itself. A missing or invalid token counts as anonymous.
+func (s *userServiceServer) redactPendingEmails(ctx context.Context, users ...*pb.User) {
+	var caller *auth.Claims
+	if bearerToken(ctx) != "" {
+		caller, _ = s.authenticate(ctx)
+	}
+	if caller != nil && caller.IsAdmin() && caller.Act == nil {
+		return
+	}
+
+	for _, user := range users {
+		if caller == nil || user.Id != caller.UserID {
+			user.PendingEmail = ""
+		}
+	}
+}
+
+// ConfirmEmailChange applies a pending email change. Possession of the token proves control of
+// the new address, so no access token is needed. All existing sessions of the user end.
+func (s *userServiceServer) ConfirmEmailChange(ctx context.Context, req *pb.ConfirmEmailChangeRequest) (*pb.ConfirmEmailChangeResponse, error) {
+	token := strings.TrimSpace(req.Token)
+	if token == "" {
+		return nil, response.GRPCError(codes.InvalidArgument, "Token is required")
+	}
+
+	// End all sessions before the change is committed; if that fails nothing changes
+	// and the token stays valid for a retry
+	revokeSessions := func(user *pb.User) error {
+		if err := s.tokenManager.RevokeUserTokens(ctx, user.Id); err != nil {
+			log.Printf("Failed to revoke tokens of user %d for email change: %v", user.Id, err)
+			return response.GRPCError(codes.Unavailable, "Failed to end existing sessions; the email was not changed, retry the request")
+		}
+		return nil
+	}
+
+	user, previousEmail, err := s.repo.ConfirmEmailChange(ctx, hashEmailChangeToken(token), revokeSessions)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, response.GRPCError(codes.InvalidArgument, "Invalid or expired email change token")
+		}
+		if errors.Is(err, repository.ErrEmailDuplicate) {
+			return nil, response.GRPCError(codes.AlreadyExists, "Email is already registered")
+		}
+		// Session revocation errors are already gRPC errors
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, response.GRPCError(codes.Internal, "Failed to confirm email change")
+	}
+
+	log.Printf("User %d changed email address", user.Id)
+
+	if s.notifier != nil {
+		notice := &notify.Notification{
+			Type:    notify.TypeEmailChanged,
+			UserID:  user.Id,
+			Email:   previousEmail,
+			Subject: "Your email address was changed",
+			Body:    fmt.Sprintf("The email address of your account was changed to %s.", user.Email),
+			Metadata: map[string]string{
+				"new_email": user.Email,
+			},
+		}
+		if err := s.notifier.Notify(ctx, notice); err != nil {
+			log.Printf("Failed to send email changed notice to user %d: %v", user.Id, err)
+		}
+	}
+
+	return response.ConfirmEmailChangeSuccess(user), nil
+}
@@ -0,0 +1,145 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/thatlq1812/service-1-user/internal/repository"
+	"github.com/thatlq1812/service-1-user/internal/response"
+	pb "github.com/thatlq1812/service-1-user/proto"
+
+	"google.golang.org/grpc/codes"
+)
+
+const maxExternalSubjectLength = 255
+
+// externalProviderPattern matches normalized identity provider names
+var externalProviderPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{0,63}$`)
+
+// parseExternalIdentity validates an identity and returns its lower-cased provider and exact subject
+func parseExternalIdentity(identity *pb.ExternalIdentity) (provider, subject string, err error) {
+	if identity == nil {
+		return "", "", response.GRPCError(codes.InvalidArgument, "External identity is required")
+	}
+
+	provider = strings.ToLower(strings.TrimSpace(identity.Provider))
+	if !externalProviderPattern.MatchString(provider) {
+		return "", "", response.GRPCError(codes.InvalidArgument, "Provider must be 1-64 letters, digits, dots, dashes or underscores")
+	}
+
+	// Subjects are opaque provider IDs, so only surrounding whitespace is dropped
+	subject = strings.TrimSpace(identity.Subject)
+	if subject == "" {
+		return "", "", response.GRPCError(codes.InvalidArgument, "Subject is required")
+	}
+	if len(subject) > maxExternalSubjectLength {
+		return "", "", response.GRPCError(codes.InvalidArgument, "Subject is too long")
+	}
+
+	return provider, subject, nil
+}
+
+// LinkExternalIdentity links an account at an external identity provider to a user,
+// so LookupUser can find the user by it
+func (s *userServiceServer) LinkExternalIdentity(ctx context.Context, req *pb.LinkExternalIdentityRequest) (*pb.LinkExternalIdentityResponse, error) {
+	admin, err := s.requireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	user, provider, subject, err := s.externalIdentityTarget(ctx, req.UserId, req.Identity)
+	if err != nil {
+		return nil, err
+	}
+	if user.AnonymizedAt != "" {
+		return nil, response.GRPCError(codes.FailedPrecondition, "User has been anonymized and can no longer be changed")
+	}
+
+	if err := s.externalIdentities.Link(ctx, user.Id, provider, subject); err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, response.GRPCError(codes.NotFound, "User not found")
+		}
+		if errors.Is(err, repository.ErrExternalIdentityLinked) {
+			return nil, response.GRPCError(codes.AlreadyExists, "External identity is linked to another user")
+		}
+		return nil, response.GRPCError(codes.Internal, "Failed to link external identity")
+	}
+
+	s.recordIdentityAudit(ctx, admin.UserID, repository.AuditActionIdentityLink, user.Id, provider, req.Reason)
+	log.Printf("Admin %d linked a %s identity to user %d", admin.UserID, provider, user.Id)
+
+	return response.LinkExternalIdentitySuccess(user), nil
+}
+
+// UnlinkExternalIdentity removes an external identity from a user
+func (s *userServiceServer) UnlinkExternalIdentity(ctx context.Context, req *pb.UnlinkExternalIdentityRequest) (*pb.UnlinkExternalIdentityResponse, error) {
+	admin, err := s.requireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	user, provider, subject, err := s.externalIdentityTarget(ctx, req.UserId, req.Identity)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.externalIdentities.Unlink(ctx, user.Id, provider, subject); err != nil {
+		if errors.Is(err, repository.ErrExternalIdentityNotFound) {
+			return nil, response.GRPCError(codes.NotFound, "External identity is not linked to this user")
+		}
+		return nil, response.GRPCError(codes.Internal, "Failed to unlink external identity")
+	}
+
+	s.recordIdentityAudit(ctx, admin.UserID, repository.AuditActionIdentityUnlink, user.Id, provider, req.Reason)
+	log.Printf("Admin %d unlinked a %s identity from user %d", admin.UserID, provider, user.Id)
+
+	return response.UnlinkExternalIdentitySuccess(user), nil
+}
+
+// externalIdentityTarget validates a link or unlink request and loads its user
+func (s *userServiceServer) externalIdentityTarget(ctx context.Context, userID int32, identity *pb.ExternalIdentity) (*pb.User, string, string, error) {
+	if s.externalIdentities == nil {
+		return nil, "", "", response.GRPCError(codes.FailedPrecondition, "External identities are not enabled")
+	}
+	if userID <= 0 {
+		return nil, "", "", response.GRPCError(codes.InvalidArgument, "User ID must be positive")
+	}
+	provider, subject, err := parseExternalIdentity(identity)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	user, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, "", "", response.GRPCError(codes.NotFound, "User not found")
+		}
+		return nil, "", "", response.GRPCError(codes.Internal, "Failed to get user")
+	}
+
+	return user, provider, subject, nil
+}
+
+// recordIdentityAudit writes a link or unlink to the audit trail. The subject is left out,
+// since it identifies the user at the provider.
+func (s *userServiceServer) recordIdentityAudit(ctx context.Context, adminID int32, action string, userID int32, provider, reason string) {
+	if s.audit == nil {
+		return
+	}
+
+	entry := &repository.AuditEntry{
+		ActorUserID:  adminID,
+		Action:       action,
+		TargetUserID: userID,
+		Reason:       strings.TrimSpace(reason),
+		Metadata: map[string]any{
+			"provider": provider,
+		},
+	}
+	if err := s.audit.Record(ctx, entry); err != nil {
+		log.Printf("Failed to record %s audit entry for user %d: %v", action, userID, err)
+	}
+}
@@ -0,0 +1,104 @@
+package server
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/thatlq1812/service-1-user/internal/repository"
+	"github.com/thatlq1812/service-1-user/internal/response"
+	pb "github.com/thatlq1812/service-1-user/proto"
+
+	"google.golang.org/grpc/codes"
+)
+
+// Login failure reasons stored in login history
+const (
+	loginFailureUnknownEmail    = "unknown_email"
+	loginFailureInvalidPassword = "invalid_password"
+)
+
+// recordLoginEvent stores a login history entry with the client's IP and user agent.
+// Failures are logged only, so history problems never block authentication.
+func (s *userServiceServer) recordLoginEvent(ctx context.Context, userID int32, email, eventType, outcome, reason string) {
+	if s.loginEvents == nil {
+		return
+	}
+
+	ip, userAgent := s.clientInfo(ctx)
+	event := &repository.LoginEvent{
+		UserID:        userID,
+		Email:         email,
+		EventType:     eventType,
+		Outcome:       outcome,
+		FailureReason: reason,
+		IPAddress:     ip,
+		UserAgent:     userAgent,
+	}
+	if err := s.loginEvents.Record(ctx, event); err != nil {
+		log.Printf("Failed to record %s event for user %d: %v", eventType, userID, err)
+	}
+}
+
+// ListLoginEvents returns a user's login history, newest first.
+// Users can read their own history; admins can read anyone's.
+func (s *userServiceServer) ListLoginEvents(ctx context.Context, req *pb.ListLoginEventsRequest) (*pb.ListLoginEventsResponse, error) {
+	if req.UserId <= 0 {
+		return nil, response.GRPCError(codes.InvalidArgument, "User ID must be positive")
+	}
+
+	caller, err := s.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if caller.UserID != req.UserId && (!caller.IsAdmin() || caller.Act != nil) {
+		return nil, response.GRPCError(codes.PermissionDenied, "Not allowed to view this user's login history.")
+	}
+
+	if s.loginEvents == nil {
+		return nil, response.GRPCError(codes.FailedPrecondition, "Login history is not enabled")
+	}
+
+	// Validate and normalize pagination parameters
+	pageSize := req.PageSize
+	pageNumber := req.Page
+
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		return nil, response.GRPCError(codes.InvalidArgument, "Page_size too large")
+	}
+	if pageNumber < 0 {
+		return nil, response.GRPCError(codes.InvalidArgument, "Page_number must be non-negative")
+	}
+
+	offset := pageNumber * pageSize
+
+	events, total, err := s.loginEvents.ListByUser(ctx, req.UserId, pageSize, offset)
+	if err != nil {
+		return nil, response.GRPCError(codes.Internal, "Failed to list login events")
+	}
+
+	hasMore := int64(pageNumber+1)*int64(pageSize) < int64(total)
+
+	return response.ListLoginEventsSuccess(loginEventsToProto(events), int64(total), pageNumber, pageSize, hasMore), nil
+}
+
+// loginEventsToProto converts stored login events to their API representation
+func loginEventsToProto(events []*repository.LoginEvent) []*pb.LoginEvent {
+	items := make([]*pb.LoginEvent, 0, len(events))
+	for _, event := range events {
+		items = append(items, &pb.LoginEvent{
+			Id:            event.ID,
+			UserId:        event.UserID,
+			EventType:     event.EventType,
+			Outcome:       event.Outcome,
+			FailureReason: event.FailureReason,
+			IpAddress:     event.IPAddress,
+			UserAgent:     event.UserAgent,
+			CreatedAt:     event.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	return items
+}
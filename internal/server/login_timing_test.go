@@ -0,0 +1,237 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/thatlq1812/service-1-user/internal/auth"
+	"github.com/thatlq1812/service-1-user/internal/emailaddr"
+	"github.com/thatlq1812/service-1-user/internal/repository"
+	pb "github.com/thatlq1812/service-1-user/proto"
+
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// timingRuns is how many times each case runs; the median is compared
+	timingRuns = 7
+
+	// timingTolerance is the largest allowed ratio between the slowest and fastest case
+	timingTolerance = 1.5
+
+	testPassword  = "Correct1Horse"
+	wrongPassword = "Wrong1Battery"
+)
+
+// credentialsRepo is an in-memory UserRepository supporting the calls made by Login and CreateUser.
+// Other methods panic through the nil embedded interface.
+type credentialsRepo struct {
+	repository.UserRepository
+
+	mu     sync.Mutex
+	users  map[string]*repository.UserWithPassword
+	nextID int32
+}
+
+func newCredentialsRepo() *credentialsRepo {
+	return &credentialsRepo{users: make(map[string]*repository.UserWithPassword)}
+}
+
+func (r *credentialsRepo) add(email, passwordHash string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.nextID++
+	r.users[email] = &repository.UserWithPassword{
+		User:         &pb.User{Id: r.nextID, Email: email, Role: auth.RoleUser},
+		PasswordHash: passwordHash,
+	}
+}
+
+func (r *credentialsRepo) GetByEmailWithPassword(_ context.Context, email emailaddr.Address) (*repository.UserWithPassword, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	user, ok := r.users[email.Key]
+	if !ok {
+		return nil, repository.ErrUserNotFound
+	}
+	return user, nil
+}
+
+func (r *credentialsRepo) CreateWithPassword(_ context.Context, name string, email emailaddr.Address, _ string, passwordHash string) (*pb.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.users[email.Key]; ok {
+		return nil, repository.ErrEmailDuplicate
+	}
+	r.nextID++
+	user := &pb.User{Id: r.nextID, Name: name, Email: email.Address, Role: auth.RoleUser}
+	r.users[email.Key] = &repository.UserWithPassword{User: user, PasswordHash: passwordHash}
+	return user, nil
+}
+
+func (r *credentialsRepo) Create(ctx context.Context, name string, email emailaddr.Address, username string) (*pb.User, error) {
+	return r.CreateWithPassword(ctx, name, email, username, "")
+}
+
+// medianDuration runs f timingRuns times and returns the median duration
+func medianDuration(f func()) time.Duration {
+	durations := make([]time.Duration, timingRuns)
+	for i := range durations {
+		start := time.Now()
+		f()
+		durations[i] = time.Since(start)
+	}
+	slices.Sort(durations)
+	return durations[len(durations)/2]
+}
+
+// bcryptDuration measures one bcrypt comparison at the cost used for real password hashes
+func bcryptDuration(t *testing.T) time.Duration {
+	t.Helper()
+	hash, err := bcrypt.GenerateFromPassword([]byte(testPassword), bcrypt.DefaultCost)
+	if err != nil {
+		t.Fatalf("hash password: %v", err)
+	}
+	return medianDuration(func() {
+		_ = bcrypt.CompareHashAndPassword(hash, []byte(wrongPassword))
+	})
+}
+
+// assertTimingParity checks that every case did at least half the work of a bcrypt comparison
+// and that the slowest and fastest case are within timingTolerance of each other
+func assertTimingParity(t *testing.T, bcryptTime time.Duration, durations map[string]time.Duration) {
+	t.Helper()
+
+	var fastest, slowest time.Duration
+	for name, d := range durations {
+		t.Logf("%s: %v (bcrypt: %v)", name, d, bcryptTime)
+		if d < bcryptTime/2 {
+			t.Errorf("%s took %v, less than half a bcrypt comparison (%v): no bcrypt work was done", name, d, bcryptTime)
+		}
+		if fastest == 0 || d < fastest {
+			fastest = d
+		}
+		slowest = max(slowest, d)
+	}
+
+	if ratio := float64(slowest) / float64(fastest); ratio > timingTolerance {
+		t.Errorf("timing differs by a factor of %.2f between cases, want at most %.2f", ratio, timingTolerance)
+	}
+}
+
+func TestLoginTimingParity(t *testing.T) {
+	auth.WarmUp()
+
+	passwordHash, err := auth.HashPassword(testPassword)
+	if err != nil {
+		t.Fatalf("hash password: %v", err)
+	}
+
+	repo := newCredentialsRepo()
+	repo.add("known@example.com", passwordHash)
+	repo.add("legacy@example.com", "")
+
+	s := NewUserServiceServer(repo, auth.NewTokenManager("test-secret", time.Minute, time.Hour, nil))
+
+	cases := map[string]string{
+		"unknown email":  "unknown@example.com",
+		"wrong password": "known@example.com",
+		"legacy account": "legacy@example.com",
+	}
+
+	durations := make(map[string]time.Duration, len(cases))
+	for name, email := range cases {
+		req := &pb.LoginRequest{Email: email, Password: wrongPassword}
+		durations[name] = medianDuration(func() {
+			_, err := s.Login(context.Background(), req)
+			if status.Code(err) != codes.Unauthenticated {
+				t.Fatalf("%s: Login error = %v, want Unauthenticated", name, err)
+			}
+		})
+	}
+
+	assertTimingParity(t, bcryptDuration(t), durations)
+}
+
+func TestVerifyPasswordDoesBcryptWork(t *testing.T) {
+	auth.WarmUp()
+
+	passwordHash, err := auth.HashPassword(testPassword)
+	if err != nil {
+		t.Fatalf("hash password: %v", err)
+	}
+
+	cases := map[string]string{
+		"empty hash":     "",
+		"wrong password": passwordHash,
+	}
+
+	durations := make(map[string]time.Duration, len(cases))
+	for name, hash := range cases {
+		durations[name] = medianDuration(func() {
+			if auth.VerifyPassword(wrongPassword, hash) {
+				t.Fatalf("%s: VerifyPassword accepted a wrong password", name)
+			}
+		})
+	}
+
+	assertTimingParity(t, bcryptDuration(t), durations)
+}
+
+func TestCreateUserSilentSignupDuplicate(t *testing.T) {
+	for _, password := range []string{testPassword, ""} {
+		name := "with password"
+		if password == "" {
+			name = "legacy without password"
+		}
+
+		t.Run(name, func(t *testing.T) {
+			repo := newCredentialsRepo()
+			repo.add("taken@example.com", "")
+
+			s := NewUserServiceServer(repo, auth.NewTokenManager("test-secret", time.Minute, time.Hour, nil), WithSilentSignup(true))
+
+			var created, duplicate *pb.CreateUserResponse
+			var n int
+			createdTime := medianDuration(func() {
+				n++
+				req := &pb.CreateUserRequest{Name: "New", Email: fmt.Sprintf("new%d@example.com", n), Password: password}
+				resp, err := s.CreateUser(context.Background(), req)
+				if err != nil {
+					t.Fatalf("CreateUser(new email) error = %v", err)
+				}
+				created = resp
+			})
+			duplicateTime := medianDuration(func() {
+				req := &pb.CreateUserRequest{Name: "Dup", Email: "Taken@Example.com", Password: password}
+				resp, err := s.CreateUser(context.Background(), req)
+				if err != nil {
+					t.Fatalf("CreateUser(duplicate email) error = %v", err)
+				}
+				duplicate = resp
+			})
+
+			if !proto.Equal(created, duplicate) {
+				t.Errorf("duplicate email response = %v, want the same as for a new email %v", duplicate, created)
+			}
+			if created.GetData().GetUser() != nil {
+				t.Errorf("silent signup response reveals the created user: %v", created.GetData().GetUser())
+			}
+
+			// Both paths hash the password before the insert, so they cost the same bcrypt work
+			if password != "" {
+				assertTimingParity(t, bcryptDuration(t), map[string]time.Duration{
+					"new email":       createdTime,
+					"duplicate email": duplicateTime,
+				})
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package server
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+
+	"github.com/thatlq1812/service-1-user/internal/pagetoken"
+	"github.com/thatlq1812/service-1-user/internal/repository"
+	"github.com/thatlq1812/service-1-user/internal/response"
+	pb "github.com/thatlq1812/service-1-user/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
+)
+
+// listUsersPageToken is the state carried in ListUsers page tokens
+type listUsersPageToken struct {
+	// Query fingerprints the filters and order the token was issued for
+	Query string `json:"q"`
+	Key   string `json:"k,omitempty"`
+	ID    int32  `json:"id"`
+}
+
+// WithPageTokens signs list page tokens with a key derived from secret. Instances serving the
+// same clients need the same secret; without this option a random per-process key is used.
+func WithPageTokens(secret []byte) Option {
+	return func(s *userServiceServer) {
+		if len(secret) > 0 {
+			s.pageTokens = pagetoken.NewCodec(secret)
+		}
+	}
+}
+
+// randomPageTokenCodec returns a codec with a random key, valid only within this process
+func randomPageTokenCodec() *pagetoken.Codec {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		panic("read random page token key: " + err.Error())
+	}
+	return pagetoken.NewCodec(secret)
+}
+
+// listUsersQuery fingerprints everything in req that selects and orders users, so a page
+// token cannot be replayed against different filters
+func listUsersQuery(req *pb.ListUsersRequest) string {
+	query := proto.Clone(req).(*pb.ListUsersRequest)
+	query.Page, query.PageSize, query.PageToken, query.IncludeTotal = 0, 0, "", nil
+
+	data, _ := proto.MarshalOptions{Deterministic: true}.Marshal(query)
+	sum := sha256.Sum256(data)
+	return base64.RawURLEncoding.EncodeToString(sum[:16])
+}
+
+// decodeListUsersPageToken verifies a ListUsers page token and returns its cursor
+func (s *userServiceServer) decodeListUsersPageToken(req *pb.ListUsersRequest) (*repository.UserCursor, error) {
+	var state listUsersPageToken
+	if err := s.pageTokens.Decode(req.PageToken, &state); err != nil {
+		return nil, response.GRPCError(codes.InvalidArgument, "Invalid page_token")
+	}
+	if state.Query != listUsersQuery(req) {
+		return nil, response.GRPCError(codes.InvalidArgument, "Page_token was issued for different filters or order")
+	}
+	return &repository.UserCursor{Key: state.Key, ID: state.ID}, nil
+}
+
+// encodeListUsersPageToken returns the page token continuing after cursor ("" when cursor is nil)
+func (s *userServiceServer) encodeListUsersPageToken(req *pb.ListUsersRequest, cursor *repository.UserCursor) (string, error) {
+	if cursor == nil {
+		return "", nil
+	}
+
+	token, err := s.pageTokens.Encode(listUsersPageToken{
+		Query: listUsersQuery(req),
+		Key:   cursor.Key,
+		ID:    cursor.ID,
+	})
+	if err != nil {
+		return "", response.GRPCError(codes.Internal, "Failed to create page token")
+	}
+	return token, nil
+}
@@ -0,0 +1,117 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/thatlq1812/service-1-user/internal/auth"
+	"github.com/thatlq1812/service-1-user/internal/response"
+	pb "github.com/thatlq1812/service-1-user/proto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// ExchangeToken trades a subject access token for a narrower token meant for a single
+// downstream audience (RFC 8693), recording the caller as the newest actor in the chain
+func (s *userServiceServer) ExchangeToken(ctx context.Context, req *pb.ExchangeTokenRequest) (*pb.ExchangeTokenResponse, error) {
+	// Validate input
+	if req.SubjectToken == "" {
+		return nil, response.GRPCError(codes.InvalidArgument, "Subject token is required")
+	}
+	audience := strings.TrimSpace(req.Audience)
+	if audience == "" {
+		return nil, response.GRPCError(codes.InvalidArgument, "Audience is required")
+	}
+	if !slices.Contains(s.exchangeAudiences, audience) {
+		return nil, response.GRPCError(codes.PermissionDenied, "Token exchange is not allowed for this audience.")
+	}
+	if len(req.Scopes) == 0 {
+		return nil, response.GRPCError(codes.InvalidArgument, "At least one scope is required")
+	}
+	if req.ExpiresIn < 0 {
+		return nil, response.GRPCError(codes.InvalidArgument, "Expires_in must be non-negative")
+	}
+	if strings.TrimSpace(req.Actor) != "" {
+		return nil, response.GRPCError(codes.InvalidArgument, "Actor cannot be set; the authenticated calling service is the actor")
+	}
+
+	// The authenticated service performing the exchange becomes the actor
+	actor, err := s.authenticateExchangeClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	subject, err := s.tokenManager.ValidateToken(ctx, req.SubjectToken)
+	if err != nil {
+		return nil, response.GRPCError(codes.Unauthenticated, "Invalid or expired subject token")
+	}
+	if err := s.checkExchangeSubject(subject); err != nil {
+		return nil, err
+	}
+
+	// A DPoP-bound subject token can only be exchanged by its key holder
+	method, _ := grpc.Method(ctx)
+	if err := s.verifyTokenBinding(ctx, subject, dpopProof(ctx), dpopRPCMethod, method, req.SubjectToken); err != nil {
+		return nil, err
+	}
+
+	duration := s.exchangeMaxDuration
+	if requested := time.Duration(req.ExpiresIn) * time.Second; requested > 0 && requested < duration {
+		duration = requested
+	}
+
+	token, claims, err := s.tokenManager.ExchangeToken(subject, auth.ExchangeRequest{
+		Audience: audience,
+		Actor:    actor,
+		Scopes:   req.Scopes,
+		Duration: duration,
+	})
+	if err != nil {
+		if errors.Is(err, auth.ErrScopeNotAllowed) {
+			return nil, response.GRPCError(codes.PermissionDenied, "Requested scopes exceed the subject token's scopes.")
+		}
+		if errors.Is(err, auth.ErrSubjectTokenExpiring) {
+			return nil, response.GRPCError(codes.Unauthenticated, "Subject token is about to expire")
+		}
+		return nil, response.GRPCError(codes.Internal, "Failed to exchange token")
+	}
+
+	return response.ExchangeTokenSuccess(token, claims), nil
+}
+
+// checkExchangeSubject accepts only subject tokens issued for this service. Tokens exchanged
+// for another audience are rejected, and so are tokens that already carry an actor unless
+// chaining is allowed, so a downstream service cannot re-exchange a token it was given.
+func (s *userServiceServer) checkExchangeSubject(subject *auth.Claims) error {
+	if len(subject.Audience) > 0 && !subject.HasAudience(serviceAudience) {
+		return response.GRPCError(codes.PermissionDenied, "Subject token was not issued for this service.")
+	}
+	if subject.Act != nil && !s.exchangeChaining {
+		return response.GRPCError(codes.PermissionDenied, "Delegated tokens cannot be exchanged.")
+	}
+	return nil
+}
+
+// authenticateExchangeClient verifies the calling service's credentials ("x-service-id" and
+// "x-service-secret" metadata) and returns its ID. Only services that are themselves allowed
+// exchange audiences may exchange tokens.
+func (s *userServiceServer) authenticateExchangeClient(ctx context.Context) (string, error) {
+	serviceID := metadataValue(ctx, "x-service-id")
+	if serviceID == "" {
+		return "", response.GRPCError(codes.Unauthenticated, "Service credentials are required for token exchange")
+	}
+
+	secret, ok := s.exchangeClients[serviceID]
+	if !ok || !auth.VerifySecret(secret, metadataValue(ctx, "x-service-secret")) {
+		return "", response.GRPCError(codes.Unauthenticated, "Invalid service credentials")
+	}
+	if !slices.Contains(s.exchangeAudiences, serviceID) {
+		return "", response.GRPCError(codes.PermissionDenied, "Service is not allowed to exchange tokens.")
+	}
+
+	return serviceID, nil
+}
@@ -0,0 +1,59 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/thatlq1812/service-1-user/internal/auth"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCheckExchangeSubject(t *testing.T) {
+	tm := auth.NewTokenManager("test-secret", time.Minute, time.Hour, nil)
+	firstParty := &auth.Claims{UserID: 7, Role: auth.RoleUser, TokenType: auth.TokenTypeAccess}
+
+	exchange := func(subject *auth.Claims, audience, actor string) *auth.Claims {
+		t.Helper()
+		_, claims, err := tm.ExchangeToken(subject, auth.ExchangeRequest{
+			Audience: audience,
+			Actor:    actor,
+			Scopes:   []string{"profile:write"},
+			Duration: time.Minute,
+		})
+		if err != nil {
+			t.Fatalf("ExchangeToken(%s): %v", audience, err)
+		}
+		return claims
+	}
+
+	_, impersonation, err := tm.GenerateImpersonationToken(7, "user@example.com", auth.RoleUser, 1, "admin@example.com", time.Minute)
+	if err != nil {
+		t.Fatalf("GenerateImpersonationToken: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		subject  *auth.Claims
+		chaining bool
+		want     codes.Code
+	}{
+		{name: "first-party token", subject: firstParty, want: codes.OK},
+		{name: "re-exchange of a token for another service", subject: exchange(firstParty, "article-service", "article-service"), want: codes.PermissionDenied},
+		{name: "re-exchange for another service with chaining", subject: exchange(firstParty, "article-service", "article-service"), chaining: true, want: codes.PermissionDenied},
+		{name: "exchanged token for this service", subject: exchange(firstParty, serviceAudience, "gateway"), want: codes.PermissionDenied},
+		{name: "exchanged token for this service with chaining", subject: exchange(firstParty, serviceAudience, "gateway"), chaining: true, want: codes.OK},
+		{name: "impersonation token", subject: impersonation, want: codes.PermissionDenied},
+		{name: "impersonation token with chaining", subject: impersonation, chaining: true, want: codes.OK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &userServiceServer{exchangeChaining: tt.chaining}
+			if got := status.Code(s.checkExchangeSubject(tt.subject)); got != tt.want {
+				t.Errorf("checkExchangeSubject() code = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/thatlq1812/service-1-user/internal/auth"
+	"github.com/thatlq1812/service-1-user/internal/response"
+	pb "github.com/thatlq1812/service-1-user/proto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// maxValidateBatchSize caps the number of tokens in one ValidateTokens batch
+const maxValidateBatchSize = 100
+
+// tokenFailure describes why a presented token was rejected
+type tokenFailure struct {
+	reason pb.TokenFailureReason
+	err    error
+}
+
+// checkPresentedToken applies the audience and DPoP binding rules to an already verified token
+func (s *userServiceServer) checkPresentedToken(ctx context.Context, claims *auth.Claims, req *pb.ValidateTokenRequest) *tokenFailure {
+	// Audience-restricted (exchanged) tokens are only valid for the service they were issued to
+	if len(claims.Audience) > 0 && !claims.HasAudience(req.Audience) {
+		return &tokenFailure{
+			reason: pb.TokenFailureReason_TOKEN_FAILURE_REASON_AUDIENCE_MISMATCH,
+			err:    response.GRPCError(codes.Unauthenticated, "Token was not issued for this audience"),
+		}
+	}
+
+	// Sender-constrained tokens need a proof bound to the HTTP request or RPC being authorized
+	method := req.HttpMethod
+	if method == "" {
+		method = dpopRPCMethod
+	}
+	if err := s.verifyTokenBinding(ctx, claims, req.DpopProof, method, req.HttpUri, req.Token); err != nil {
+		return &tokenFailure{
+			reason: pb.TokenFailureReason_TOKEN_FAILURE_REASON_DPOP_PROOF_INVALID,
+			err:    err,
+		}
+	}
+
+	return nil
+}
+
+// tokenFailureReason maps token verification errors to failure reasons
+func tokenFailureReason(err error) pb.TokenFailureReason {
+	switch {
+	case errors.Is(err, auth.ErrTokenRevoked):
+		return pb.TokenFailureReason_TOKEN_FAILURE_REASON_REVOKED
+	case errors.Is(err, auth.ErrUserTokensRevoked):
+		return pb.TokenFailureReason_TOKEN_FAILURE_REASON_USER_REVOKED
+	case errors.Is(err, auth.ErrTokenExpired):
+		return pb.TokenFailureReason_TOKEN_FAILURE_REASON_EXPIRED
+	case errors.Is(err, auth.ErrTokenSignatureInvalid):
+		return pb.TokenFailureReason_TOKEN_FAILURE_REASON_INVALID_SIGNATURE
+	case errors.Is(err, auth.ErrInvalidTokenType):
+		return pb.TokenFailureReason_TOKEN_FAILURE_REASON_WRONG_TOKEN_TYPE
+	default:
+		return pb.TokenFailureReason_TOKEN_FAILURE_REASON_MALFORMED
+	}
+}
+
+// validateTokenBatch validates a batch of tokens with a single Redis round-trip for the blacklist
+func (s *userServiceServer) validateTokenBatch(ctx context.Context, req *pb.ValidateTokensRequest) (*pb.ValidateTokensResponse, error) {
+	if len(req.Tokens) == 0 {
+		return nil, response.GRPCError(codes.InvalidArgument, "At least one token is required")
+	}
+	if len(req.Tokens) > maxValidateBatchSize {
+		return nil, response.GRPCError(codes.InvalidArgument, "Too many tokens in one batch")
+	}
+
+	tokens := make([]string, len(req.Tokens))
+	for i, item := range req.Tokens {
+		tokens[i] = item.GetToken()
+	}
+
+	claims, errs, err := s.tokenManager.ValidateTokens(ctx, tokens)
+	if err != nil {
+		return nil, response.GRPCError(codes.Internal, "Failed to check token blacklist")
+	}
+
+	results := make([]*pb.TokenValidationResult, len(req.Tokens))
+	for i, item := range req.Tokens {
+		switch {
+		case item.GetToken() == "":
+			results[i] = response.TokenValidationFailure(i, pb.TokenFailureReason_TOKEN_FAILURE_REASON_EMPTY, "Token is required")
+		case errs[i] != nil:
+			results[i] = response.TokenValidationFailure(i, tokenFailureReason(errs[i]), "Invalid or expired token")
+		default:
+			if failure := s.checkPresentedToken(ctx, claims[i], item); failure != nil {
+				results[i] = response.TokenValidationFailure(i, failure.reason, response.ErrorMessage(failure.err))
+				continue
+			}
+			results[i] = response.TokenValidationSuccess(i, claims[i])
+		}
+	}
+
+	return response.ValidateTokensSuccess(req.RequestId, results), nil
+}
+
+// ValidateTokens validates many tokens in one call, returning per-token results in request order
+func (s *userServiceServer) ValidateTokens(ctx context.Context, req *pb.ValidateTokensRequest) (*pb.ValidateTokensResponse, error) {
+	return s.validateTokenBatch(ctx, req)
+}
+
+// StreamValidateTokens validates batches sent over a bidirectional stream.
+// Each received batch yields one response carrying the batch's request_id; a rejected
+// batch is reported in that response's code instead of ending the stream.
+func (s *userServiceServer) StreamValidateTokens(stream grpc.BidiStreamingServer[pb.ValidateTokensRequest, pb.ValidateTokensResponse]) error {
+	ctx := stream.Context()
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		resp, err := s.validateTokenBatch(ctx, req)
+		if err != nil {
+			resp = response.ValidateTokensError(req.RequestId, err)
+		}
+
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+}
@@ -3,14 +3,26 @@ package server
 import (
 	"context"
 	"errors"
+	"log"
+	"net/netip"
 	"strings"
+	"time"
 
+	"github.com/thatlq1812/service-1-user/internal/attributes"
 	"github.com/thatlq1812/service-1-user/internal/auth"
+	"github.com/thatlq1812/service-1-user/internal/emailaddr"
+	"github.com/thatlq1812/service-1-user/internal/events"
+	"github.com/thatlq1812/service-1-user/internal/notify"
+	"github.com/thatlq1812/service-1-user/internal/pagetoken"
+	"github.com/thatlq1812/service-1-user/internal/ratelimit"
 	"github.com/thatlq1812/service-1-user/internal/repository"
 	"github.com/thatlq1812/service-1-user/internal/response"
+	"github.com/thatlq1812/service-1-user/internal/storage"
+	"github.com/thatlq1812/service-1-user/internal/username"
 	pb "github.com/thatlq1812/service-1-user/proto"
 
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -18,6 +30,12 @@ const (
 	maxPageSize        = 100
 	errDuplicateKey    = "duplicate"
 	errUniqueViolation = "unique"
+
+	defaultImpersonationDuration = 10 * time.Minute
+	defaultExchangeMaxDuration   = 5 * time.Minute
+
+	// serviceAudience is the audience of exchanged tokens meant for this service
+	serviceAudience = "user-service"
 )
 
 // userServiceServer implements UserServiceServer interface
@@ -25,16 +43,255 @@ type userServiceServer struct {
 	pb.UnimplementedUserServiceServer
 	repo         repository.UserRepository
 	tokenManager *auth.TokenManager
+
+	// Optional breached-password checking (nil = disabled)
+	breachChecker      auth.BreachChecker
+	breachCheckOnLogin bool
+
+	// Optional password reuse prevention (nil = disabled)
+	passwordHistory      repository.PasswordHistoryRepository
+	passwordHistoryDepth int
+
+	// Audit trail for admin actions (nil = disabled, impersonation unavailable)
+	audit                 repository.AuditRepository
+	impersonationDuration time.Duration
+
+	// Maximum authentication age per RPC name for step-up checks (empty = disabled)
+	stepUpPolicy map[string]time.Duration
+
+	// DPoP proof verification for sender-constrained tokens (nil = bearer only)
+	dpop *auth.DPoPVerifier
+
+	// Token exchange: allowed downstream audiences (empty = disabled), secrets of the services
+	// allowed to exchange tokens, and maximum token lifetime
+	exchangeAudiences   []string
+	exchangeClients     map[string]string
+	exchangeMaxDuration time.Duration
+
+	// Allow exchanging subject tokens that already carry an "act" chain
+	exchangeChaining bool
+
+	// Silent signup hides whether an email is already registered from CreateUser callers
+	silentSignup bool
+
+	// Networks of proxies allowed to forward the client IP and user agent
+	trustedProxies []netip.Prefix
+
+	// Login history (nil = disabled)
+	loginEvents repository.LoginEventRepository
+
+	// New device detection (nil = disabled)
+	devices repository.DeviceRepository
+
+	// Accounts at external identity providers (nil = disabled)
+	externalIdentities repository.ExternalIdentityRepository
+
+	// User notifications (nil = not sent)
+	notifier notify.Notifier
+
+	// Grace period between a self-service deletion request and the erase (0 = disabled)
+	deletionGracePeriod time.Duration
+
+	// Domain events for other services (nil = not published)
+	events events.Publisher
+
+	// Custom profile attribute schemas (nil = attributes disabled)
+	attributeSchemas *attributes.Registry
+
+	// Email parsing and identity normalization
+	emails emailaddr.Normalizer
+
+	// Lifetime of email change confirmation tokens
+	emailChangeTokenTTL time.Duration
+
+	// Avatar image storage (nil = avatars disabled) and upload size limit in bytes
+	avatars       storage.BlobStore
+	avatarMaxSize int
+
+	// Rate limit of CheckAvailability per client IP (nil = unlimited)
+	availabilityLimiter *ratelimit.Limiter
+
+	// Signs list page tokens
+	pageTokens *pagetoken.Codec
 }
 
-// NewUserServiceServer create server
-func NewUserServiceServer(repo repository.UserRepository, tokenManager *auth.TokenManager) pb.UserServiceServer {
-	return &userServiceServer{
-		repo:         repo,
-		tokenManager: tokenManager,
+// Option configures optional features of the user service server
+type Option func(*userServiceServer)
+
+// WithBreachChecker rejects breached passwords on every password change path.
+// When checkOnLogin is true, existing accounts logging in with a breached password are flagged for rotation.
+func WithBreachChecker(checker auth.BreachChecker, checkOnLogin bool) Option {
+	return func(s *userServiceServer) {
+		s.breachChecker = checker
+		s.breachCheckOnLogin = checkOnLogin
 	}
 }
 
+// WithPasswordHistory rejects password changes reusing any of the last depth passwords
+func WithPasswordHistory(history repository.PasswordHistoryRepository, depth int) Option {
+	return func(s *userServiceServer) {
+		if depth > 0 {
+			s.passwordHistory = history
+			s.passwordHistoryDepth = depth
+		}
+	}
+}
+
+// WithAuditLog records admin actions such as impersonation in the audit trail
+func WithAuditLog(audit repository.AuditRepository) Option {
+	return func(s *userServiceServer) {
+		s.audit = audit
+	}
+}
+
+// WithImpersonationDuration sets the lifetime of impersonation access tokens
+func WithImpersonationDuration(duration time.Duration) Option {
+	return func(s *userServiceServer) {
+		if duration > 0 {
+			s.impersonationDuration = duration
+		}
+	}
+}
+
+// WithStepUpPolicy requires callers of the listed RPCs (e.g. "DeleteUser") to present
+// an access token whose auth_time is no older than the given max age
+func WithStepUpPolicy(policy map[string]time.Duration) Option {
+	return func(s *userServiceServer) {
+		s.stepUpPolicy = policy
+	}
+}
+
+// WithDPoP enables sender-constrained tokens for clients sending DPoP proofs
+func WithDPoP(verifier *auth.DPoPVerifier) Option {
+	return func(s *userServiceServer) {
+		s.dpop = verifier
+	}
+}
+
+// WithTokenExchange allows ExchangeToken for the given downstream audiences, issuing tokens
+// that live at most maxDuration. Callers authenticate with the secret of their service in clients.
+func WithTokenExchange(audiences []string, clients map[string]string, maxDuration time.Duration) Option {
+	return func(s *userServiceServer) {
+		s.exchangeAudiences = audiences
+		s.exchangeClients = clients
+		if maxDuration > 0 {
+			s.exchangeMaxDuration = maxDuration
+		}
+	}
+}
+
+// WithExchangeChaining allows ExchangeToken to accept delegated subject tokens (impersonation
+// or earlier exchanges for this service), nesting their actors under the caller
+func WithExchangeChaining(allowed bool) Option {
+	return func(s *userServiceServer) {
+		s.exchangeChaining = allowed
+	}
+}
+
+// WithSilentSignup makes CreateUser answer identically for new and already registered
+// emails, so signup cannot be used to discover accounts
+func WithSilentSignup(enabled bool) Option {
+	return func(s *userServiceServer) {
+		s.silentSignup = enabled
+	}
+}
+
+// WithExternalIdentities enables linking users to external identity providers and looking them up by those accounts
+func WithExternalIdentities(identities repository.ExternalIdentityRepository) Option {
+	return func(s *userServiceServer) {
+		s.externalIdentities = identities
+	}
+}
+
+// WithLoginEvents records logins, refreshes and failed login attempts
+func WithLoginEvents(events repository.LoginEventRepository) Option {
+	return func(s *userServiceServer) {
+		s.loginEvents = events
+	}
+}
+
+// WithDeviceTracking enables new-device detection on login. Logins from a device the
+// user has not used before are recorded as security events and reported to the user.
+func WithDeviceTracking(devices repository.DeviceRepository) Option {
+	return func(s *userServiceServer) {
+		s.devices = devices
+	}
+}
+
+// WithNotifier sets where security and account notifications for users are sent
+func WithNotifier(notifier notify.Notifier) Option {
+	return func(s *userServiceServer) {
+		s.notifier = notifier
+	}
+}
+
+// WithAccountDeletion lets users delete their own account; the erase happens after gracePeriod
+func WithAccountDeletion(gracePeriod time.Duration) Option {
+	return func(s *userServiceServer) {
+		s.deletionGracePeriod = gracePeriod
+	}
+}
+
+// WithEventPublisher publishes domain events such as anonymization tombstones
+func WithEventPublisher(publisher events.Publisher) Option {
+	return func(s *userServiceServer) {
+		s.events = publisher
+	}
+}
+
+// WithAttributeSchemas enables custom profile attributes validated against the admin-managed schema
+func WithAttributeSchemas(schemas repository.AttributeSchemaRepository) Option {
+	return func(s *userServiceServer) {
+		s.attributeSchemas = attributes.NewRegistry(schemas)
+	}
+}
+
+// WithEmailCanonicalization applies provider-specific rules (e.g. Gmail dots and "+tag" suffixes)
+// to email identity, so aliases of one mailbox cannot register separate accounts
+func WithEmailCanonicalization(enabled bool) Option {
+	return func(s *userServiceServer) {
+		s.emails.CanonicalizeProviders = enabled
+	}
+}
+
+// WithEmailChange sets how long email change confirmation tokens stay valid
+func WithEmailChange(tokenTTL time.Duration) Option {
+	return func(s *userServiceServer) {
+		if tokenTTL > 0 {
+			s.emailChangeTokenTTL = tokenTTL
+		}
+	}
+}
+
+// WithAvatars enables avatar uploads stored in store; uploads larger than maxSize bytes are rejected
+func WithAvatars(store storage.BlobStore, maxSize int) Option {
+	return func(s *userServiceServer) {
+		s.avatars = store
+		if maxSize > 0 {
+			s.avatarMaxSize = maxSize
+		}
+	}
+}
+
+// NewUserServiceServer create server
+func NewUserServiceServer(repo repository.UserRepository, tokenManager *auth.TokenManager, opts ...Option) pb.UserServiceServer {
+	s := &userServiceServer{
+		repo:                  repo,
+		tokenManager:          tokenManager,
+		impersonationDuration: defaultImpersonationDuration,
+		exchangeMaxDuration:   defaultExchangeMaxDuration,
+		emailChangeTokenTTL:   defaultEmailChangeTokenTTL,
+		avatarMaxSize:         defaultAvatarMaxSize,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.pageTokens == nil {
+		s.pageTokens = randomPageTokenCodec()
+	}
+	return s
+}
+
 // GetUser retrieves a user by ID
 func (s *userServiceServer) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.GetUserResponse, error) {
 	if req.Id <= 0 {
@@ -49,6 +306,7 @@ func (s *userServiceServer) GetUser(ctx context.Context, req *pb.GetUserRequest)
 		return nil, response.GRPCError(codes.Internal, "Failed to get user")
 	}
 
+	s.redactPendingEmails(ctx, user)
 	return response.GetUserSuccess(user), nil
 }
 
@@ -63,14 +321,27 @@ func (s *userServiceServer) CreateUser(ctx context.Context, req *pb.CreateUserRe
 		return nil, response.GRPCError(codes.InvalidArgument, "Email is required. Provide a valid email address.")
 	}
 
-	if !isValidEmail(req.Email) {
-		return nil, response.GRPCError(codes.InvalidArgument, "Invalid email format. Ensure the email contains '@' and a domain.")
+	address, err := s.parseEmail(req.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	// Username is optional
+	var name string
+	if req.Username != "" {
+		name, err = parseUsername(req.Username)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	var user *pb.User
-	var err error
 
 	if req.Password != "" {
+		if err := s.checkBreachedPassword(ctx, req.Password); err != nil {
+			return nil, err
+		}
+
 		passwordHash, err := auth.HashPassword(req.Password)
 		if err != nil {
 			if strings.Contains(err.Error(), "password must") {
@@ -79,24 +350,45 @@ func (s *userServiceServer) CreateUser(ctx context.Context, req *pb.CreateUserRe
 			return nil, response.GRPCError(codes.Internal, "Failed to hash password")
 		}
 
-		user, err = s.repo.CreateWithPassword(ctx, req.Name, req.Email, passwordHash)
+		user, err = s.repo.CreateWithPassword(ctx, req.Name, address, name, passwordHash)
 		if err != nil {
+			// Usernames are public, so a taken one is reported even with silent signup
+			if errors.Is(err, repository.ErrUsernameDuplicate) {
+				return nil, response.GRPCError(codes.AlreadyExists, "Username is already taken. Choose a different username.")
+			}
 			if isDuplicateError(err) {
+				if s.silentSignup {
+					return response.CreateUserAccepted(), nil
+				}
 				return nil, response.GRPCError(codes.AlreadyExists, "Email is already registered. Please use a different email address.")
 			}
 			return nil, response.GRPCError(codes.Internal, "Failed to create user"+err.Error())
 		}
+
+		s.recordPasswordHistory(ctx, user.Id, passwordHash)
 	} else {
 		// Create user without password (legacy support)
-		user, err = s.repo.Create(ctx, req.Name, req.Email)
+		user, err = s.repo.Create(ctx, req.Name, address, name)
 		if err != nil {
+			// Usernames are public, so a taken one is reported even with silent signup
+			if errors.Is(err, repository.ErrUsernameDuplicate) {
+				return nil, response.GRPCError(codes.AlreadyExists, "Username is already taken. Choose a different username.")
+			}
 			if isDuplicateError(err) {
+				if s.silentSignup {
+					return response.CreateUserAccepted(), nil
+				}
 				return nil, response.GRPCError(codes.AlreadyExists, "Email is already registered. Please use a different email address.")
 			}
 			return nil, response.GRPCError(codes.Internal, "Failed to create user")
 		}
 	}
 
+	// Same answer as the duplicate path so callers cannot tell the cases apart
+	if s.silentSignup {
+		return response.CreateUserAccepted(), nil
+	}
+
 	return response.CreateUserSuccess(user), nil
 }
 
@@ -114,13 +406,99 @@ func isDuplicateError(err error) bool {
 	return strings.Contains(errMsg, errDuplicateKey) || strings.Contains(errMsg, errUniqueViolation) || strings.Contains(errMsg, "email already exists")
 }
 
-// isValidEmail validates email format using regex
-func isValidEmail(email string) bool {
-	if len(email) == 0 {
-		return false
+// checkBreachedPassword rejects passwords found in the breach dataset (no-op when disabled)
+func (s *userServiceServer) checkBreachedPassword(ctx context.Context, password string) error {
+	if s.breachChecker == nil {
+		return nil
+	}
+
+	breached, err := s.breachChecker.IsBreached(ctx, password)
+	if err != nil {
+		return response.GRPCError(codes.Internal, "Failed to check password against breach list")
+	}
+	if breached {
+		return response.GRPCError(codes.InvalidArgument, "Password has appeared in a known data breach. Choose a different password.")
+	}
+
+	return nil
+}
+
+// checkPasswordReuse rejects a new password matching one of the user's recent passwords (no-op when disabled)
+func (s *userServiceServer) checkPasswordReuse(ctx context.Context, userID int32, password string) error {
+	if s.passwordHistory == nil {
+		return nil
+	}
+
+	hashes, err := s.passwordHistory.GetRecent(ctx, userID, s.passwordHistoryDepth)
+	if err != nil {
+		return response.GRPCError(codes.Internal, "Failed to check password history")
+	}
+
+	for _, hash := range hashes {
+		if auth.CheckPassword(password, hash) {
+			return response.GRPCError(codes.InvalidArgument, "Password was used recently. Choose a password you have not used before.")
+		}
+	}
+
+	return nil
+}
+
+// recordPasswordHistory stores a newly set password hash, pruning history beyond the configured depth
+func (s *userServiceServer) recordPasswordHistory(ctx context.Context, userID int32, passwordHash string) {
+	if s.passwordHistory == nil {
+		return
+	}
+
+	// The password is already changed at this point, so only log failures
+	if err := s.passwordHistory.Add(ctx, userID, passwordHash, s.passwordHistoryDepth); err != nil {
+		log.Printf("Failed to record password history for user %d: %v", userID, err)
+	}
+}
+
+// tokenScheme returns the token type for tokens bound to the given DPoP thumbprint
+func tokenScheme(jkt string) string {
+	if jkt != "" {
+		return auth.TokenTypeDPoP
+	}
+	return auth.TokenTypeBearer
+}
+
+// parseEmail parses and normalizes a client-supplied email address
+func (s *userServiceServer) parseEmail(raw string) (emailaddr.Address, error) {
+	address, err := s.emails.Parse(raw)
+	if err != nil {
+		return emailaddr.Address{}, response.GRPCError(codes.InvalidArgument, "Invalid email format: "+strings.TrimPrefix(err.Error(), emailaddr.ErrInvalid.Error()+": "))
+	}
+	return address, nil
+}
+
+// findCredentials looks up a user with password hash by a client-supplied email or username;
+// identifiers without "@" are usernames. Unparseable identifiers are treated like unknown ones,
+// so callers keep their constant-work checks.
+func (s *userServiceServer) findCredentials(ctx context.Context, identifier string) (*repository.UserWithPassword, error) {
+	var userWithPassword *repository.UserWithPassword
+	var err error
+
+	if strings.Contains(identifier, "@") {
+		address, parseErr := s.emails.Parse(identifier)
+		if parseErr != nil {
+			return nil, nil
+		}
+		userWithPassword, err = s.repo.GetByEmailWithPassword(ctx, address)
+	} else {
+		name, parseErr := username.Normalize(identifier)
+		if parseErr != nil {
+			return nil, nil
+		}
+		userWithPassword, err = s.repo.GetByUsernameWithPassword(ctx, name)
+	}
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, nil
+		}
+		return nil, err
 	}
-	// Simple email validation
-	return strings.Contains(email, "@") && strings.Contains(email, ".")
+	return userWithPassword, nil
 }
 
 // UpdateUser updates user information (partial update supported)
@@ -132,28 +510,68 @@ func (s *userServiceServer) UpdateUser(ctx context.Context, req *pb.UpdateUserRe
 	}
 
 	// At least one field must be provided
-	if req.Name == nil && req.Email == nil && req.Password == nil {
+	if req.Name == nil && req.Username == nil && req.Email == nil && req.Password == nil && req.Attributes == nil {
 		return nil, response.GRPCError(codes.InvalidArgument, "At least one field must be provided for update")
 	}
 
+	// Users change their email through the verified RequestEmailChange flow; only admins set it directly
+	if req.Email != nil {
+		caller, err := s.authenticate(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !caller.IsAdmin() || caller.Act != nil {
+			return nil, response.GRPCError(codes.FailedPrecondition, "Email changes must be confirmed. Use RequestEmailChange.")
+		}
+	}
+
+	// Changing credentials requires a recent login
+	if req.Email != nil || req.Password != nil {
+		if err := s.requireFreshAuth(ctx, "UpdateUser", req.Id); err != nil {
+			return nil, err
+		}
+	}
+
 	// Prepare pointers for partial update
-	var name, email, passwordHash *string
+	var name, handle, passwordHash *string
+	var email *emailaddr.Address
 
 	// Process name
 	if req.Name != nil {
 		name = req.Name
 	}
 
+	// Process username (empty removes it)
+	if req.Username != nil {
+		normalized := ""
+		if *req.Username != "" {
+			var err error
+			normalized, err = parseUsername(*req.Username)
+			if err != nil {
+				return nil, err
+			}
+		}
+		handle = &normalized
+	}
+
 	// Process email
 	if req.Email != nil {
-		if !isValidEmail(*req.Email) {
-			return nil, response.GRPCError(codes.InvalidArgument, "Invalid email format")
+		address, err := s.parseEmail(*req.Email)
+		if err != nil {
+			return nil, err
 		}
-		email = req.Email
+		email = &address
 	}
 
 	// Process password
 	if req.Password != nil {
+		if err := s.checkBreachedPassword(ctx, *req.Password); err != nil {
+			return nil, err
+		}
+		if err := s.checkPasswordReuse(ctx, req.Id, *req.Password); err != nil {
+			return nil, err
+		}
+
 		hash, err := auth.HashPassword(*req.Password)
 		if err != nil {
 			return nil, response.GRPCError(codes.Internal, "Failed to hash password")
@@ -161,18 +579,42 @@ func (s *userServiceServer) UpdateUser(ctx context.Context, req *pb.UpdateUserRe
 		passwordHash = &hash
 	}
 
+	// Process attributes (merge patch applied to the locked row, validated as a whole)
+	var mergeAttributes repository.AttributesMerge
+	if req.Attributes != nil {
+		var err error
+		mergeAttributes, err = s.attributesMerge(ctx, req.Attributes)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Update user with only provided fields
-	user, err := s.repo.PartialUpdate(ctx, req.Id, name, email, passwordHash)
+	user, err := s.repo.PartialUpdate(ctx, req.Id, name, handle, email, passwordHash, mergeAttributes)
 	if err != nil {
+		// Attribute merge errors are already gRPC errors
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
 		if errors.Is(err, repository.ErrUserNotFound) {
 			return nil, response.GRPCError(codes.NotFound, "User not found")
 		}
+		if errors.Is(err, repository.ErrUserAnonymized) {
+			return nil, response.GRPCError(codes.FailedPrecondition, "User has been anonymized and can no longer be changed")
+		}
 		if errors.Is(err, repository.ErrEmailDuplicate) {
 			return nil, response.GRPCError(codes.AlreadyExists, "Email is already registered")
 		}
+		if errors.Is(err, repository.ErrUsernameDuplicate) {
+			return nil, response.GRPCError(codes.AlreadyExists, "Username is already taken")
+		}
 		return nil, response.GRPCError(codes.Internal, "Failed to update user")
 	}
 
+	if passwordHash != nil {
+		s.recordPasswordHistory(ctx, user.Id, *passwordHash)
+	}
+
 	return response.UpdateUserSuccess(user), nil
 }
 
@@ -183,7 +625,11 @@ func (s *userServiceServer) DeleteUser(ctx context.Context, req *pb.DeleteUserRe
 		return nil, response.GRPCError(codes.InvalidArgument, "User ID must be positive")
 	}
 
-	// Delete user
+	if err := s.requireFreshAuth(ctx, "DeleteUser", req.Id); err != nil {
+		return nil, err
+	}
+
+	// Soft delete user; the row is purged after the retention period
 	err := s.repo.Delete(ctx, req.Id)
 	if err != nil {
 		if errors.Is(err, repository.ErrUserNotFound) {
@@ -192,6 +638,11 @@ func (s *userServiceServer) DeleteUser(ctx context.Context, req *pb.DeleteUserRe
 		return nil, response.GRPCError(codes.Internal, "Failed to delete user")
 	}
 
+	// Sessions of a deleted user end immediately
+	if err := s.tokenManager.RevokeUserTokens(ctx, req.Id); err != nil {
+		log.Printf("Failed to revoke tokens of deleted user %d: %v", req.Id, err)
+	}
+
 	return response.DeleteUserSuccess(), nil
 }
 
@@ -211,59 +662,194 @@ func (s *userServiceServer) ListUsers(ctx context.Context, req *pb.ListUsersRequ
 		return nil, response.GRPCError(codes.InvalidArgument, "Page_number must be non-negative")
 	}
 
-	// Calculate offset (page_number 0 = first page with offset 0)
-	offset := pageNumber * pageSize
+	// Keyset pagination continues after the page token; page numbers use an offset
+	// (page_number 0 = first page with offset 0)
+	page := repository.UserPage{Limit: pageSize}
+	if req.PageToken != "" {
+		if pageNumber != 0 {
+			return nil, response.GRPCError(codes.InvalidArgument, "Page_number cannot be combined with page_token")
+		}
+		cursor, err := s.decodeListUsersPageToken(req)
+		if err != nil {
+			return nil, err
+		}
+		page.After = cursor
+	} else {
+		page.Offset = pageNumber * pageSize
+	}
+
+	filter, err := listUsersFilter(req)
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := repository.ParseUserOrder(req.OrderBy)
+	if err != nil {
+		return nil, response.GRPCError(codes.InvalidArgument, "Invalid order_by: "+strings.TrimPrefix(err.Error(), repository.ErrInvalidOrder.Error()+": "))
+	}
 
 	// Retrieve users from repository
-	users, total, err := s.repo.List(ctx, pageSize, offset)
+	users, next, err := s.repo.List(ctx, filter, order, page)
 	if err != nil {
 		return nil, response.GRPCError(codes.Internal, "Failed to list users")
 	}
 
-	// Calculate has_more
-	hasMore := int64(pageNumber+1)*int64(pageSize) < int64(total)
+	nextPageToken, err := s.encodeListUsersPageToken(req, next)
+	if err != nil {
+		return nil, err
+	}
+
+	// Exact counts scan every matching row; clients may opt out and accept an estimate
+	estimateTotal := req.IncludeTotal != nil && !*req.IncludeTotal
+	var total int64
+	if estimateTotal {
+		total, err = s.repo.EstimateCount(ctx, filter)
+	} else {
+		total, err = s.repo.Count(ctx, filter)
+	}
+	if err != nil {
+		return nil, response.GRPCError(codes.Internal, "Failed to count users")
+	}
+
+	s.redactPendingEmails(ctx, users...)
 
 	// Build and return response
-	return response.ListUsersSuccess(users, int64(total), pageNumber, pageSize, hasMore), nil
+	return response.ListUsersSuccess(users, total, estimateTotal, pageNumber, pageSize, nextPageToken), nil
+}
+
+// listUsersFilter validates the filters of a ListUsers request
+func listUsersFilter(req *pb.ListUsersRequest) (repository.UserFilter, error) {
+	filter := repository.UserFilter{
+		Attributes: req.GetAttributes().AsMap(),
+		Name:       strings.TrimSpace(req.Name),
+		Email:      strings.TrimSpace(req.Email),
+		Status:     req.Status,
+		Role:       req.Role,
+	}
+
+	ranges := []struct {
+		field string
+		value string
+		dest  *time.Time
+	}{
+		{"Created_after", req.CreatedAfter, &filter.CreatedAfter},
+		{"Created_before", req.CreatedBefore, &filter.CreatedBefore},
+		{"Updated_after", req.UpdatedAfter, &filter.UpdatedAfter},
+		{"Updated_before", req.UpdatedBefore, &filter.UpdatedBefore},
+	}
+	for _, r := range ranges {
+		if r.value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, r.value)
+		if err != nil {
+			return repository.UserFilter{}, response.GRPCError(codes.InvalidArgument, r.field+" must be an RFC3339 timestamp")
+		}
+		*r.dest = parsed
+	}
+
+	if _, ok := pb.UserStatus_name[int32(req.Status)]; !ok {
+		return repository.UserFilter{}, response.GRPCError(codes.InvalidArgument, "Unknown status")
+	}
+	if req.Role != "" && req.Role != auth.RoleUser && req.Role != auth.RoleAdmin {
+		return repository.UserFilter{}, response.GRPCError(codes.InvalidArgument, "Role must be \"user\" or \"admin\"")
+	}
+
+	return filter, nil
 }
 
 // Login authenticates a user and returns JWT tokens
 func (s *userServiceServer) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResponse, error) {
 	// Validate input
 	if req.Email == "" {
-		return nil, response.GRPCError(codes.InvalidArgument, "Email is required")
+		return nil, response.GRPCError(codes.InvalidArgument, "Email or username is required")
 	}
 	if req.Password == "" {
 		return nil, response.GRPCError(codes.InvalidArgument, "Password is required")
 	}
 
-	// Get user by email with password hash
-	userWithPassword, err := s.repo.GetByEmailWithPassword(ctx, req.Email)
+	// Optional DPoP proof binds the issued tokens to the client's key
+	jkt, err := s.verifyDPoPForRPC(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get user by normalized email or username with password hash
+	userWithPassword, err := s.findCredentials(ctx, req.Email)
 	if err != nil {
-		if errors.Is(err, repository.ErrUserNotFound) {
-			return nil, response.GRPCError(codes.Unauthenticated, "Invalid email or password")
-		}
 		return nil, response.GRPCError(codes.Internal, "Failed to get user")
 	}
 
-	// Verify password
-	if !auth.CheckPassword(req.Password, userWithPassword.PasswordHash) {
+	// Verify password with the same bcrypt work whether or not the email is registered,
+	// so response time does not reveal registered emails or password-less legacy accounts
+	passwordHash := ""
+	if userWithPassword != nil {
+		passwordHash = userWithPassword.PasswordHash
+	}
+	if !auth.VerifyPassword(req.Password, passwordHash) {
+		var userID int32
+		reason := loginFailureUnknownEmail
+		if userWithPassword != nil {
+			userID = userWithPassword.User.Id
+			reason = loginFailureInvalidPassword
+		}
+		s.recordLoginEvent(ctx, userID, req.Email, repository.LoginEventLogin, repository.LoginOutcomeFailure, reason)
 		return nil, response.GRPCError(codes.Unauthenticated, "Invalid email or password")
 	}
 
+	// Only active accounts may log in; the status is revealed only after a correct password
+	if err := checkAccountActive(userWithPassword.User); err != nil {
+		s.recordLoginEvent(ctx, userWithPassword.User.Id, req.Email, repository.LoginEventLogin, repository.LoginOutcomeFailure, loginFailureAccountInactive)
+		return nil, err
+	}
+
+	// Flag accounts still using a breached password for forced rotation
+	rotationRequired := userWithPassword.PasswordRotationRequired
+	if !rotationRequired && s.breachChecker != nil && s.breachCheckOnLogin {
+		breached, err := s.breachChecker.IsBreached(ctx, req.Password)
+		if err != nil {
+			// Do not block login when the breach dataset is unavailable
+			log.Printf("Breach check failed for user %d: %v", userWithPassword.User.Id, err)
+		} else if breached {
+			if err := s.repo.SetPasswordRotationRequired(ctx, userWithPassword.User.Id, true); err != nil {
+				return nil, response.GRPCError(codes.Internal, "Failed to flag password rotation")
+			}
+			rotationRequired = true
+		}
+	}
+
+	// Record when and how the user authenticated for step-up checks
+	// and start a session whose lifetime follows the client's (or remember-me) policy
+	session := s.tokenManager.StartSession(auth.Session{
+		UserID:     userWithPassword.User.Id,
+		Email:      userWithPassword.User.Email,
+		Role:       userWithPassword.User.Role,
+		AuthTime:   time.Now(),
+		AMR:        []string{auth.AMRPassword},
+		JKT:        jkt,
+		ClientID:   req.ClientId,
+		RememberMe: req.RememberMe,
+
+		// Client policies apply only to clients authenticating with their secret
+		ClientVerified: req.ClientId != "" && s.tokenManager.VerifyClient(req.ClientId, metadataValue(ctx, "x-client-secret")),
+	})
+
 	// Generate access and refresh tokens
-	accessToken, err := s.tokenManager.GenerateToken(userWithPassword.User.Id, userWithPassword.User.Email)
+	accessToken, err := s.tokenManager.GenerateToken(session)
 	if err != nil {
 		return nil, response.GRPCError(codes.Internal, "Failed to generate access token")
 	}
 
-	refreshToken, err := s.tokenManager.GenerateRefreshToken(userWithPassword.User.Id, userWithPassword.User.Email)
+	refreshToken, err := s.tokenManager.GenerateRefreshToken(session)
 	if err != nil {
 		return nil, response.GRPCError(codes.Internal, "Failed to generate refresh token")
 	}
 
+	s.recordLoginEvent(ctx, session.UserID, session.Email, repository.LoginEventLogin, repository.LoginOutcomeSuccess, "")
+	s.checkLoginDevice(ctx, userWithPassword.User)
+
 	// Return successful login response
-	return response.LoginSuccess(accessToken, refreshToken), nil
+	return response.LoginSuccess(accessToken, refreshToken, tokenScheme(jkt), session.ExpiresAt, rotationRequired), nil
 }
 
 // ValidateToken verifies JWT token validity and returns claims
@@ -279,8 +865,13 @@ func (s *userServiceServer) ValidateToken(ctx context.Context, req *pb.ValidateT
 		return nil, response.GRPCError(codes.Unauthenticated, "Invalid or expired token")
 	}
 
-	// Return validation result with claims
-	return response.ValidateTokenSuccess(true, int64(claims.UserID), claims.Email), nil
+	// Audience and DPoP binding of the presented token
+	if failure := s.checkPresentedToken(ctx, claims, req); failure != nil {
+		return nil, failure.err
+	}
+
+	// Return validation result with claims (including any impersonating actor)
+	return response.ValidateTokenSuccess(true, claims), nil
 }
 
 // RefreshToken generates new access and refresh tokens using a valid refresh token
@@ -293,28 +884,65 @@ func (s *userServiceServer) RefreshToken(ctx context.Context, req *pb.RefreshTok
 	// Validate refresh token
 	claims, err := s.tokenManager.ValidateRefreshToken(ctx, req.RefreshToken)
 	if err != nil {
+		if errors.Is(err, auth.ErrSessionExpired) {
+			return nil, response.GRPCError(codes.Unauthenticated, "Session has expired. Log in again.")
+		}
+		if errors.Is(err, auth.ErrUserTokensRevoked) {
+			return nil, response.GRPCError(codes.Unauthenticated, "Session has been revoked. Log in again.")
+		}
 		return nil, response.GRPCError(codes.Unauthenticated, "Invalid or expired refresh token")
 	}
 
+	// The account must still be active to extend the session
+	user, err := s.repo.GetByID(ctx, claims.UserID)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, response.GRPCError(codes.Unauthenticated, "Invalid or expired refresh token")
+		}
+		return nil, response.GRPCError(codes.Internal, "Failed to get user")
+	}
+	if err := checkAccountActive(user); err != nil {
+		return nil, err
+	}
+
+	// A bound refresh token must be presented with a proof from the same key
+	jkt, err := s.verifyDPoPForRPC(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Cnf != nil && jkt != claims.Cnf.JKT {
+		return nil, response.GRPCError(codes.Unauthenticated, "Refresh token requires a DPoP proof from the bound key")
+	}
+
 	// Optional: Invalidate old refresh token (token rotation for security)
 	err = s.tokenManager.InvalidateToken(ctx, req.RefreshToken)
 	if err != nil {
 		return nil, response.GRPCError(codes.Internal, "Failed to invalidate old token")
 	}
 
+	// Keep the original auth_time and amr so refreshing never counts as re-authentication;
+	// sessions from before absolute lifetimes existed get one now
+	session := s.tokenManager.ContinueSession(claims.Session())
+	session.JKT = jkt
+
 	// Generate new access token
-	newAccessToken, err := s.tokenManager.GenerateToken(claims.UserID, claims.Email)
+	newAccessToken, err := s.tokenManager.GenerateToken(session)
 	if err != nil {
 		return nil, response.GRPCError(codes.Internal, "Failed to generate access token")
 	}
 
-	// Generate new refresh token (token rotation)
-	newRefreshToken, err := s.tokenManager.GenerateRefreshToken(claims.UserID, claims.Email)
+	// Generate new refresh token (token rotation, sliding idle expiry)
+	newRefreshToken, err := s.tokenManager.GenerateRefreshToken(session)
 	if err != nil {
+		if errors.Is(err, auth.ErrSessionExpired) {
+			return nil, response.GRPCError(codes.Unauthenticated, "Session has expired. Log in again.")
+		}
 		return nil, response.GRPCError(codes.Internal, "Failed to generate refresh token")
 	}
 
-	return response.RefreshTokenSuccess(newAccessToken, newRefreshToken), nil
+	s.recordLoginEvent(ctx, session.UserID, session.Email, repository.LoginEventRefresh, repository.LoginOutcomeSuccess, "")
+
+	return response.RefreshTokenSuccess(newAccessToken, newRefreshToken, tokenScheme(jkt), session.ExpiresAt), nil
 }
 
 // Logout handles user logout with token blacklist
@@ -0,0 +1,159 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/netip"
+	"strings"
+
+	"github.com/thatlq1812/service-1-user/internal/emailaddr"
+	"github.com/thatlq1812/service-1-user/internal/ratelimit"
+	"github.com/thatlq1812/service-1-user/internal/repository"
+	"github.com/thatlq1812/service-1-user/internal/response"
+	"github.com/thatlq1812/service-1-user/internal/username"
+	pb "github.com/thatlq1812/service-1-user/proto"
+
+	"google.golang.org/grpc/codes"
+)
+
+// Reasons an identifier is unavailable in CheckAvailability
+const (
+	availabilityTaken    = "taken"
+	availabilityReserved = "reserved"
+	availabilityInvalid  = "invalid"
+)
+
+// WithAvailabilityRateLimit limits CheckAvailability calls per client IP
+func WithAvailabilityRateLimit(limiter *ratelimit.Limiter) Option {
+	return func(s *userServiceServer) {
+		s.availabilityLimiter = limiter
+	}
+}
+
+// parseUsername validates a client-supplied username and returns its case-folded form
+func parseUsername(raw string) (string, error) {
+	name, err := username.Normalize(raw)
+	if err != nil {
+		if errors.Is(err, username.ErrReserved) {
+			return "", response.GRPCError(codes.InvalidArgument, "Username is reserved. Choose a different username.")
+		}
+		return "", response.GRPCError(codes.InvalidArgument, "Invalid username: "+strings.TrimPrefix(err.Error(), username.ErrInvalid.Error()+": "))
+	}
+	return name, nil
+}
+
+// GetUserByUsername retrieves a user by username (case-insensitive)
+func (s *userServiceServer) GetUserByUsername(ctx context.Context, req *pb.GetUserByUsernameRequest) (*pb.GetUserByUsernameResponse, error) {
+	if req.Username == "" {
+		return nil, response.GRPCError(codes.InvalidArgument, "Username is required")
+	}
+
+	// A username that could never be registered cannot exist
+	name, err := username.Normalize(req.Username)
+	if err != nil {
+		return nil, response.GRPCError(codes.NotFound, "User not found. Verify the username exists.")
+	}
+
+	user, err := s.repo.GetByUsername(ctx, name)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, response.GRPCError(codes.NotFound, "User not found. Verify the username exists.")
+		}
+		return nil, response.GRPCError(codes.Internal, "Failed to get user")
+	}
+
+	s.redactPendingEmails(ctx, user)
+	return response.GetUserByUsernameSuccess(user), nil
+}
+
+// CheckAvailability reports whether an email or username can be used for a new account.
+// Calls are rate limited per client IP so the RPC cannot be used to enumerate accounts quickly.
+func (s *userServiceServer) CheckAvailability(ctx context.Context, req *pb.CheckAvailabilityRequest) (*pb.CheckAvailabilityResponse, error) {
+	if req.Identifier == nil {
+		return nil, response.GRPCError(codes.InvalidArgument, "Email or username is required")
+	}
+
+	// With silent signup, answering email checks would reveal what CreateUser hides
+	if _, ok := req.Identifier.(*pb.CheckAvailabilityRequest_Email); ok && s.silentSignup {
+		return nil, response.GRPCError(codes.FailedPrecondition, "Email availability checks are disabled")
+	}
+
+	if err := s.checkAvailabilityRateLimit(ctx); err != nil {
+		return nil, err
+	}
+
+	switch identifier := req.Identifier.(type) {
+	case *pb.CheckAvailabilityRequest_Email:
+		address, err := s.emails.Parse(identifier.Email)
+		if err != nil {
+			detail := strings.TrimPrefix(err.Error(), emailaddr.ErrInvalid.Error()+": ")
+			return response.CheckAvailabilitySuccess(false, availabilityInvalid, detail), nil
+		}
+
+		_, err = s.repo.GetByEmail(ctx, address)
+		return s.availabilityResult(err)
+
+	case *pb.CheckAvailabilityRequest_Username:
+		name, err := username.Normalize(identifier.Username)
+		if err != nil {
+			if errors.Is(err, username.ErrReserved) {
+				return response.CheckAvailabilitySuccess(false, availabilityReserved, ""), nil
+			}
+			detail := strings.TrimPrefix(err.Error(), username.ErrInvalid.Error()+": ")
+			return response.CheckAvailabilitySuccess(false, availabilityInvalid, detail), nil
+		}
+
+		_, err = s.repo.GetByUsername(ctx, name)
+		return s.availabilityResult(err)
+	}
+
+	return nil, response.GRPCError(codes.InvalidArgument, "Email or username is required")
+}
+
+// availabilityResult turns the lookup result of an identifier into a CheckAvailability answer
+func (s *userServiceServer) availabilityResult(err error) (*pb.CheckAvailabilityResponse, error) {
+	if err == nil {
+		return response.CheckAvailabilitySuccess(false, availabilityTaken, ""), nil
+	}
+	if errors.Is(err, repository.ErrUserNotFound) {
+		return response.CheckAvailabilitySuccess(true, "", ""), nil
+	}
+	return nil, response.GRPCError(codes.Internal, "Failed to check availability")
+}
+
+// checkAvailabilityRateLimit counts a CheckAvailability call against the client IP (no-op when disabled).
+// The IP is the peer address unless the peer is a trusted proxy, so forged forwarding headers
+// cannot spread calls over many keys.
+func (s *userServiceServer) checkAvailabilityRateLimit(ctx context.Context) error {
+	if s.availabilityLimiter == nil {
+		return nil
+	}
+
+	ip, _ := s.clientInfo(ctx)
+	key := rateLimitKey(ip)
+	allowed, retryAfter, err := s.availabilityLimiter.Allow(ctx, key)
+	if err != nil {
+		// Unlimited checks would allow enumeration, so fail closed
+		log.Printf("Failed to apply availability check rate limit for %s: %v", key, err)
+		return response.GRPCError(codes.Internal, "Failed to check availability")
+	}
+	if !allowed {
+		return response.RateLimitedError(retryAfter)
+	}
+	return nil
+}
+
+// rateLimitKey returns the rate limit key of a client IP. IPv6 clients usually control a whole
+// /64 network, so its addresses share one key.
+func rateLimitKey(ip string) string {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return ip
+	}
+	addr = addr.Unmap()
+	if addr.Is4() {
+		return addr.String()
+	}
+	return netip.PrefixFrom(addr, 64).Masked().String()
+}
@@ -0,0 +1,17 @@
+package storage
+
+import (
+	"context"
+)
+
+// BlobStore stores binary objects such as avatar images under slash-separated keys
+type BlobStore interface {
+	// Put stores data under key, replacing any existing object
+	Put(ctx context.Context, key, contentType string, data []byte) error
+
+	// DeletePrefix removes every object whose key starts with prefix (which must end with "/")
+	DeletePrefix(ctx context.Context, prefix string) error
+
+	// URL returns the public URL an object is served from
+	URL(key string) string
+}
@@ -0,0 +1,90 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// localStore keeps objects as files below a directory, which is expected to be
+// served by a static file server or CDN under baseURL
+type localStore struct {
+	dir     string
+	baseURL string
+}
+
+// NewLocalStore creates a blob store writing to dir; URLs are baseURL + "/" + key
+func NewLocalStore(dir, baseURL string) BlobStore {
+	return &localStore{
+		dir:     dir,
+		baseURL: strings.TrimRight(baseURL, "/"),
+	}
+}
+
+// Put writes the object to a temporary file and renames it, so readers never see partial files
+func (s *localStore) Put(ctx context.Context, key, contentType string, data []byte) error {
+	target, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return fmt.Errorf("create blob directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(target), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("create blob file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write blob %s: %w", key, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write blob %s: %w", key, err)
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return fmt.Errorf("write blob %s: %w", key, err)
+	}
+
+	if err := os.Rename(tmp.Name(), target); err != nil {
+		return fmt.Errorf("store blob %s: %w", key, err)
+	}
+	return nil
+}
+
+// DeletePrefix removes the directory holding the objects under prefix
+func (s *localStore) DeletePrefix(ctx context.Context, prefix string) error {
+	if !strings.HasSuffix(prefix, "/") {
+		return fmt.Errorf("blob prefix %q must end with /", prefix)
+	}
+
+	dir, err := s.path(strings.TrimSuffix(prefix, "/"))
+	if err != nil {
+		return err
+	}
+
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("delete blobs %s: %w", prefix, err)
+	}
+	return nil
+}
+
+// URL returns the public URL of key
+func (s *localStore) URL(key string) string {
+	return s.baseURL + "/" + key
+}
+
+// path maps a key to a file below the store directory, rejecting keys that would escape it
+func (s *localStore) path(key string) (string, error) {
+	clean := path.Clean("/" + key)
+	if key == "" || clean == "/" || clean[1:] != key {
+		return "", fmt.Errorf("invalid blob key %q", key)
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(key)), nil
+}
@@ -0,0 +1,76 @@
+package username
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Length limits of usernames
+const (
+	MinLength = 3
+	MaxLength = 30
+)
+
+var (
+	// ErrInvalid is returned for usernames breaking the character or length rules
+	ErrInvalid = errors.New("invalid username")
+
+	// ErrReserved is returned for usernames that could be mistaken for the service itself
+	ErrReserved = errors.New("username is reserved")
+)
+
+// reserved usernames, compared after normalization
+var reserved = map[string]bool{
+	"abuse": true, "account": true, "accounts": true, "admin": true, "administrator": true,
+	"anonymous": true, "api": true, "auth": true, "billing": true, "help": true,
+	"hostmaster": true, "info": true, "login": true, "logout": true, "me": true,
+	"moderator": true, "noreply": true, "no-reply": true, "null": true, "official": true,
+	"postmaster": true, "root": true, "security": true, "settings": true, "signup": true,
+	"staff": true, "support": true, "system": true, "undefined": true, "user": true,
+	"users": true, "webmaster": true, "www": true,
+}
+
+// Normalize validates a username and returns its case-folded form, which is stored and compared.
+// Usernames are 3-30 characters of a-z, 0-9, "_", "." and "-", start and end with a letter or
+// digit, have no two separators in a row and are not all digits (so they cannot be confused with IDs).
+func Normalize(raw string) (string, error) {
+	name := strings.ToLower(strings.TrimSpace(raw))
+
+	if len(name) < MinLength || len(name) > MaxLength {
+		return "", fmt.Errorf("%w: must be %d to %d characters", ErrInvalid, MinLength, MaxLength)
+	}
+
+	allDigits := true
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		switch {
+		case c >= '0' && c <= '9':
+		case c >= 'a' && c <= 'z':
+			allDigits = false
+		case c == '_' || c == '.' || c == '-':
+			allDigits = false
+			if i == 0 || i == len(name)-1 {
+				return "", fmt.Errorf("%w: must start and end with a letter or digit", ErrInvalid)
+			}
+			if isSeparator(name[i-1]) {
+				return "", fmt.Errorf("%w: separators cannot follow each other", ErrInvalid)
+			}
+		default:
+			return "", fmt.Errorf("%w: only letters, digits, '_', '.' and '-' are allowed", ErrInvalid)
+		}
+	}
+	if allDigits {
+		return "", fmt.Errorf("%w: cannot consist of digits only", ErrInvalid)
+	}
+
+	if reserved[name] {
+		return "", ErrReserved
+	}
+
+	return name, nil
+}
+
+func isSeparator(c byte) bool {
+	return c == '_' || c == '.' || c == '-'
+}
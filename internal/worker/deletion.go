@@ -0,0 +1,103 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/thatlq1812/service-1-user/internal/events"
+	"github.com/thatlq1812/service-1-user/internal/repository"
+	"github.com/thatlq1812/service-1-user/internal/storage"
+	pb "github.com/thatlq1812/service-1-user/proto"
+)
+
+// DeletionWorker erases accounts whose self-service deletion grace period has ended
+// and publishes a user.deleted event for each of them
+type DeletionWorker struct {
+	repo      repository.UserRepository
+	publisher events.Publisher
+	avatars   storage.BlobStore
+	interval  time.Duration
+	batchSize int32
+}
+
+// NewDeletionWorker creates a worker that runs every interval (one hour when non-positive);
+// publisher and avatars may be nil
+func NewDeletionWorker(repo repository.UserRepository, publisher events.Publisher, avatars storage.BlobStore, interval time.Duration, batchSize int32) *DeletionWorker {
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+	if interval <= 0 {
+		log.Printf("Invalid account deletion interval %s, using %s", interval, defaultInterval)
+		interval = defaultInterval
+	}
+	return &DeletionWorker{
+		repo:      repo,
+		publisher: publisher,
+		avatars:   avatars,
+		interval:  interval,
+		batchSize: batchSize,
+	}
+}
+
+// Run erases due accounts immediately and then on every tick until ctx is cancelled
+func (w *DeletionWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.erase(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// erase deletes due accounts in batches. Each deletion event is published before the rows are
+// deleted, so a publish failure leaves the batch to be retried on the next run; consumers may
+// therefore see an event more than once.
+func (w *DeletionWorker) erase(ctx context.Context) {
+	for ctx.Err() == nil {
+		users, err := w.repo.EraseScheduled(ctx, time.Now(), w.batchSize, func(user *pb.User) error {
+			return w.publishDeleted(ctx, user.Id, user.DeletionScheduledAt)
+		})
+		if err != nil {
+			log.Printf("Erase of scheduled account deletions failed: %v", err)
+			return
+		}
+
+		for _, user := range users {
+			log.Printf("Erased user %d (deletion scheduled for %s)", user.Id, user.DeletionScheduledAt)
+			deleteAvatarFiles(ctx, w.avatars, user.Id)
+		}
+
+		if len(users) < int(w.batchSize) {
+			return
+		}
+	}
+}
+
+// publishDeleted emits the user.deleted event
+func (w *DeletionWorker) publishDeleted(ctx context.Context, userID int32, scheduledAt string) error {
+	if w.publisher == nil {
+		return nil
+	}
+
+	event := &events.Event{
+		Type:       events.TypeUserDeleted,
+		UserID:     userID,
+		OccurredAt: time.Now(),
+		Data: map[string]string{
+			"reason":       "self_service",
+			"scheduled_at": scheduledAt,
+		},
+	}
+	if err := w.publisher.Publish(ctx, event); err != nil {
+		return fmt.Errorf("publish deletion event for user %d: %w", userID, err)
+	}
+	return nil
+}
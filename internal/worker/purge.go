@@ -0,0 +1,94 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/thatlq1812/service-1-user/internal/avatar"
+	"github.com/thatlq1812/service-1-user/internal/repository"
+	"github.com/thatlq1812/service-1-user/internal/storage"
+)
+
+// defaultInterval is used when a worker is configured with a non-positive interval
+const defaultInterval = time.Hour
+
+// PurgeWorker permanently deletes users once they have been soft-deleted for longer than the retention period
+type PurgeWorker struct {
+	repo      repository.UserRepository
+	avatars   storage.BlobStore
+	retention time.Duration
+	interval  time.Duration
+	batchSize int32
+}
+
+// NewPurgeWorker creates a worker that runs every interval, deleting at most batchSize users per statement.
+// Avatar files of purged users are removed from avatars, which may be nil.
+// A non-positive interval falls back to one hour.
+func NewPurgeWorker(repo repository.UserRepository, avatars storage.BlobStore, retention, interval time.Duration, batchSize int32) *PurgeWorker {
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+	if interval <= 0 {
+		log.Printf("Invalid purge interval %s, using %s", interval, defaultInterval)
+		interval = defaultInterval
+	}
+	return &PurgeWorker{
+		repo:      repo,
+		avatars:   avatars,
+		retention: retention,
+		interval:  interval,
+		batchSize: batchSize,
+	}
+}
+
+// Run purges immediately and then on every tick until ctx is cancelled
+func (w *PurgeWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.purge(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// purge deletes expired users in batches so a large backlog does not hold long locks
+func (w *PurgeWorker) purge(ctx context.Context) {
+	cutoff := time.Now().Add(-w.retention)
+
+	var total int
+	for ctx.Err() == nil {
+		purged, err := w.repo.PurgeDeleted(ctx, cutoff, w.batchSize)
+		if err != nil {
+			log.Printf("Purge of deleted users failed: %v", err)
+			break
+		}
+		for _, userID := range purged {
+			deleteAvatarFiles(ctx, w.avatars, userID)
+		}
+		total += len(purged)
+		if len(purged) < int(w.batchSize) {
+			break
+		}
+	}
+
+	if total > 0 {
+		log.Printf("Purged %d users deleted before %s", total, cutoff.Format(time.RFC3339))
+	}
+}
+
+// deleteAvatarFiles removes every avatar file of a permanently deleted user
+func deleteAvatarFiles(ctx context.Context, avatars storage.BlobStore, userID int32) {
+	if avatars == nil {
+		return
+	}
+	if err := avatars.DeletePrefix(ctx, avatar.UserPrefix(userID)); err != nil {
+		log.Printf("Failed to delete avatar files of user %d: %v", userID, err)
+	}
+}
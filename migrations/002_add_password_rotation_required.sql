@@ -0,0 +1,5 @@
+-- Flag accounts whose current password was found in the breached-password dataset
+ALTER TABLE users ADD COLUMN IF NOT EXISTS password_rotation_required BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Rollback:
+-- ALTER TABLE users DROP COLUMN IF EXISTS password_rotation_required;
@@ -0,0 +1,18 @@
+-- Previous password hashes per user, used to prevent password reuse
+CREATE TABLE IF NOT EXISTS password_history (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    password_hash VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_password_history_user_created ON password_history(user_id, created_at DESC);
+
+-- Seed history with each user's current password
+INSERT INTO password_history (user_id, password_hash)
+SELECT id, password_hash FROM users
+WHERE password_hash IS NOT NULL
+  AND NOT EXISTS (SELECT 1 FROM password_history ph WHERE ph.user_id = users.id);
+
+-- Rollback:
+-- DROP TABLE IF EXISTS password_history;
@@ -0,0 +1,5 @@
+-- Role used for authorization of admin-only operations ('user' or 'admin')
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role VARCHAR(32) NOT NULL DEFAULT 'user';
+
+-- Rollback:
+-- ALTER TABLE users DROP COLUMN IF EXISTS role;
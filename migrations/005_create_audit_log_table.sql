@@ -0,0 +1,16 @@
+-- Audit trail of security-relevant actions (impersonation, moderation, ...)
+CREATE TABLE IF NOT EXISTS audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    actor_user_id INTEGER,
+    action VARCHAR(64) NOT NULL,
+    target_user_id INTEGER,
+    reason TEXT NOT NULL DEFAULT '',
+    metadata JSONB NOT NULL DEFAULT '{}'::jsonb,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_target_user ON audit_log(target_user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_log_actor_user ON audit_log(actor_user_id, created_at DESC);
+
+-- Rollback:
+-- DROP TABLE IF EXISTS audit_log;
@@ -0,0 +1,21 @@
+-- Last successful interactive login per user
+ALTER TABLE users ADD COLUMN IF NOT EXISTS last_login_at TIMESTAMP;
+
+-- History of logins, token refreshes and failed login attempts
+CREATE TABLE IF NOT EXISTS login_events (
+    id BIGSERIAL PRIMARY KEY,
+    user_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL DEFAULT '',
+    event_type VARCHAR(32) NOT NULL,
+    outcome VARCHAR(16) NOT NULL,
+    failure_reason VARCHAR(64) NOT NULL DEFAULT '',
+    ip_address VARCHAR(64) NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_login_events_user_created ON login_events(user_id, created_at DESC);
+
+-- Rollback:
+-- DROP TABLE IF EXISTS login_events;
+-- ALTER TABLE users DROP COLUMN IF EXISTS last_login_at;
@@ -0,0 +1,15 @@
+-- Devices a user has successfully logged in from, identified by a client fingerprint
+CREATE TABLE IF NOT EXISTS user_devices (
+    id BIGSERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    fingerprint VARCHAR(64) NOT NULL,
+    device_id VARCHAR(255) NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    ip_prefix VARCHAR(64) NOT NULL DEFAULT '',
+    first_seen_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    last_seen_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, fingerprint)
+);
+
+-- Rollback:
+-- DROP TABLE IF EXISTS user_devices;
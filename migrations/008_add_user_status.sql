@@ -0,0 +1,19 @@
+-- Account status lifecycle: active, suspended (optionally until status_expires_at), disabled, pending
+ALTER TABLE users ADD COLUMN IF NOT EXISTS status VARCHAR(16) NOT NULL DEFAULT 'active';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS status_reason TEXT NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS status_expires_at TIMESTAMP;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS status_changed_at TIMESTAMP;
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_status_check;
+ALTER TABLE users ADD CONSTRAINT users_status_check
+    CHECK (status IN ('active', 'suspended', 'disabled', 'pending'));
+
+CREATE INDEX IF NOT EXISTS idx_users_status ON users(status);
+
+-- Rollback:
+-- DROP INDEX IF EXISTS idx_users_status;
+-- ALTER TABLE users DROP CONSTRAINT IF EXISTS users_status_check;
+-- ALTER TABLE users DROP COLUMN IF EXISTS status_changed_at;
+-- ALTER TABLE users DROP COLUMN IF EXISTS status_expires_at;
+-- ALTER TABLE users DROP COLUMN IF EXISTS status_reason;
+-- ALTER TABLE users DROP COLUMN IF EXISTS status;
@@ -0,0 +1,14 @@
+-- Soft delete: deleted users keep their row until the purge job removes them
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+
+-- Emails only need to be unique among users that are not deleted
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_email_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email_active ON users(email) WHERE deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_users_deleted_at ON users(deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- Rollback (purge or restore soft-deleted duplicates first):
+-- DROP INDEX IF EXISTS idx_users_deleted_at;
+-- DROP INDEX IF EXISTS idx_users_email_active;
+-- ALTER TABLE users ADD CONSTRAINT users_email_key UNIQUE (email);
+-- ALTER TABLE users DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,10 @@
+-- Self-service deletion: the account is erased at deletion_scheduled_at unless the user cancels
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deletion_requested_at TIMESTAMP;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deletion_scheduled_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_users_deletion_scheduled_at ON users(deletion_scheduled_at) WHERE deletion_scheduled_at IS NOT NULL;
+
+-- Rollback:
+-- DROP INDEX IF EXISTS idx_users_deletion_scheduled_at;
+-- ALTER TABLE users DROP COLUMN IF EXISTS deletion_scheduled_at;
+-- ALTER TABLE users DROP COLUMN IF EXISTS deletion_requested_at;
@@ -0,0 +1,5 @@
+-- Right to erasure: PII of anonymized users is replaced by placeholders, the row and ID are kept
+ALTER TABLE users ADD COLUMN IF NOT EXISTS anonymized_at TIMESTAMP;
+
+-- Rollback:
+-- ALTER TABLE users DROP COLUMN IF EXISTS anonymized_at;
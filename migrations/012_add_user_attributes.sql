@@ -0,0 +1,18 @@
+-- Custom profile attributes, validated against the current attribute schema
+ALTER TABLE users ADD COLUMN IF NOT EXISTS attributes JSONB NOT NULL DEFAULT '{}'::jsonb;
+
+-- Containment filters (attributes @> '{"plan": "pro"}') in ListUsers
+CREATE INDEX IF NOT EXISTS idx_users_attributes ON users USING GIN (attributes jsonb_path_ops);
+
+-- Admin-managed JSON Schemas for attributes; the highest version is current
+CREATE TABLE IF NOT EXISTS attribute_schemas (
+    version SERIAL PRIMARY KEY,
+    schema JSONB NOT NULL,
+    created_by INTEGER,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Rollback:
+-- DROP TABLE IF EXISTS attribute_schemas;
+-- DROP INDEX IF EXISTS idx_users_attributes;
+-- ALTER TABLE users DROP COLUMN IF EXISTS attributes;
@@ -0,0 +1,8 @@
+-- Profile pictures stored in the blob store
+-- avatar_key is the key prefix of the current avatar's renditions, avatar_url the public URL of the largest one
+ALTER TABLE users ADD COLUMN IF NOT EXISTS avatar_key VARCHAR(255);
+ALTER TABLE users ADD COLUMN IF NOT EXISTS avatar_url TEXT;
+
+-- Rollback:
+-- ALTER TABLE users DROP COLUMN IF EXISTS avatar_url;
+-- ALTER TABLE users DROP COLUMN IF EXISTS avatar_key;
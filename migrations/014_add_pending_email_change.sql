@@ -0,0 +1,13 @@
+-- Verified email change: the new address is kept pending until the emailed token is confirmed
+ALTER TABLE users ADD COLUMN IF NOT EXISTS pending_email VARCHAR(255);
+ALTER TABLE users ADD COLUMN IF NOT EXISTS pending_email_token_hash VARCHAR(64);
+ALTER TABLE users ADD COLUMN IF NOT EXISTS pending_email_expires_at TIMESTAMP;
+
+-- Confirmation looks users up by the SHA-256 of the token
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_pending_email_token ON users(pending_email_token_hash) WHERE pending_email_token_hash IS NOT NULL;
+
+-- Rollback:
+-- DROP INDEX IF EXISTS idx_users_pending_email_token;
+-- ALTER TABLE users DROP COLUMN IF EXISTS pending_email_expires_at;
+-- ALTER TABLE users DROP COLUMN IF EXISTS pending_email_token_hash;
+-- ALTER TABLE users DROP COLUMN IF EXISTS pending_email;
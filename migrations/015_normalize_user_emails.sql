@@ -0,0 +1,42 @@
+-- Case-insensitive email identity: email keeps the address as entered, email_normalized
+-- holds the identity key computed by the service (lower-cased, IDN domains in punycode)
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email_normalized VARCHAR(255);
+ALTER TABLE users ADD COLUMN IF NOT EXISTS pending_email_normalized VARCHAR(255);
+
+-- A SQL approximation of the key, needed for the NOT NULL constraint; the service recomputes
+-- the exact keys (punycode domains, provider rules) at startup, see 018_create_email_key_state.sql
+UPDATE users SET email_normalized = lower(trim(email)) WHERE email_normalized IS NULL;
+UPDATE users SET pending_email_normalized = lower(trim(pending_email))
+WHERE pending_email IS NOT NULL AND pending_email_normalized IS NULL;
+
+-- Existing accounts differing only in capitalization must be merged or renamed first
+DO $$
+DECLARE
+    collisions TEXT;
+BEGIN
+    SELECT string_agg(format('%s (user ids %s)', email_normalized, ids), '; ')
+    INTO collisions
+    FROM (
+        SELECT email_normalized, string_agg(id::text, ', ' ORDER BY id) AS ids
+        FROM users
+        WHERE deleted_at IS NULL
+        GROUP BY email_normalized
+        HAVING COUNT(*) > 1
+    ) duplicates;
+
+    IF collisions IS NOT NULL THEN
+        RAISE EXCEPTION 'Case-insensitive email collisions must be resolved before migrating: %', collisions;
+    END IF;
+END $$;
+
+ALTER TABLE users ALTER COLUMN email_normalized SET NOT NULL;
+
+-- Uniqueness and lookups move from email to email_normalized
+DROP INDEX IF EXISTS idx_users_email_active;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email_normalized_active ON users(email_normalized) WHERE deleted_at IS NULL;
+
+-- Rollback:
+-- DROP INDEX IF EXISTS idx_users_email_normalized_active;
+-- CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email_active ON users(email) WHERE deleted_at IS NULL;
+-- ALTER TABLE users DROP COLUMN IF EXISTS pending_email_normalized;
+-- ALTER TABLE users DROP COLUMN IF EXISTS email_normalized;
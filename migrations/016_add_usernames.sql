@@ -0,0 +1,9 @@
+-- Optional unique handles, stored case-folded (lower-case)
+ALTER TABLE users ADD COLUMN IF NOT EXISTS username VARCHAR(30);
+
+-- Unique among active users, like email; a deleted user's username becomes available
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_username_active ON users(username) WHERE deleted_at IS NULL AND username IS NOT NULL;
+
+-- Rollback:
+-- DROP INDEX IF EXISTS idx_users_username_active;
+-- ALTER TABLE users DROP COLUMN IF EXISTS username;
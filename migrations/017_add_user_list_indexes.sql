@@ -0,0 +1,22 @@
+-- Indexes for ListUsers filters and sort orders over active users
+
+-- Trigram indexes serve the case-insensitive substring filters (ILIKE '%...%')
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+CREATE INDEX IF NOT EXISTS idx_users_name_trgm ON users USING GIN (name gin_trgm_ops) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_users_email_trgm ON users USING GIN (email gin_trgm_ops) WHERE deleted_at IS NULL;
+
+-- Sort orders (ID is the tie-breaker) and time range filters; email uses idx_users_email_normalized_active
+CREATE INDEX IF NOT EXISTS idx_users_name_id ON users(name, id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_users_created_at_id ON users(created_at, id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_users_updated_at_id ON users(updated_at, id) WHERE deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_users_role ON users(role) WHERE deleted_at IS NULL;
+
+-- Rollback:
+-- DROP INDEX IF EXISTS idx_users_role;
+-- DROP INDEX IF EXISTS idx_users_updated_at_id;
+-- DROP INDEX IF EXISTS idx_users_created_at_id;
+-- DROP INDEX IF EXISTS idx_users_name_id;
+-- DROP INDEX IF EXISTS idx_users_email_trgm;
+-- DROP INDEX IF EXISTS idx_users_name_trgm;
+-- DROP EXTENSION IF EXISTS pg_trgm;
@@ -0,0 +1,14 @@
+-- Records which normalization rules the stored email keys (email_normalized, pending_email_normalized)
+-- were computed with. The service recomputes every key at startup when its rules differ, e.g. after
+-- EMAIL_CANONICALIZE_PROVIDERS is toggled. The empty initial value re-keys the SQL backfill of 015,
+-- which does not match the service's keys for IDN domains.
+CREATE TABLE IF NOT EXISTS email_key_state (
+    id         BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+    rules      VARCHAR(50) NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO email_key_state (rules) VALUES ('') ON CONFLICT (id) DO NOTHING;
+
+-- Rollback:
+-- DROP TABLE IF EXISTS email_key_state;
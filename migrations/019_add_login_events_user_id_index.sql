@@ -0,0 +1,5 @@
+-- Keyset pagination of a user's login events by ID (ExportUserData reads them newest first)
+CREATE INDEX IF NOT EXISTS idx_login_events_user_id ON login_events(user_id, id DESC);
+
+-- Rollback:
+-- DROP INDEX IF EXISTS idx_login_events_user_id;
@@ -0,0 +1,14 @@
+-- Accounts of users at external identity providers, used to look users up by the provider's ID.
+-- A provider account belongs to at most one user; erasing the user removes its identities.
+CREATE TABLE IF NOT EXISTS user_external_identities (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    provider VARCHAR(64) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (provider, subject)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_external_identities_user ON user_external_identities(user_id);
+
+-- Rollback:
+-- DROP TABLE IF EXISTS user_external_identities;
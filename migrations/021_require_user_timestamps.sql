@@ -0,0 +1,11 @@
+-- created_at and updated_at were nullable since 001. Users read them as non-null, and ListUsers
+-- sorts and paginates by them, where a NULL row would fail the scan or drop out of keyset pages.
+UPDATE users SET created_at = COALESCE(updated_at, CURRENT_TIMESTAMP) WHERE created_at IS NULL;
+UPDATE users SET updated_at = created_at WHERE updated_at IS NULL;
+
+ALTER TABLE users ALTER COLUMN created_at SET NOT NULL;
+ALTER TABLE users ALTER COLUMN updated_at SET NOT NULL;
+
+-- Rollback:
+-- ALTER TABLE users ALTER COLUMN updated_at DROP NOT NULL;
+-- ALTER TABLE users ALTER COLUMN created_at DROP NOT NULL;
@@ -9,6 +9,8 @@ package proto
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	structpb "google.golang.org/protobuf/types/known/structpb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -21,15 +23,152 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type UserStatus int32
+
+const (
+	UserStatus_USER_STATUS_UNSPECIFIED UserStatus = 0
+	UserStatus_USER_STATUS_ACTIVE      UserStatus = 1
+	UserStatus_USER_STATUS_SUSPENDED   UserStatus = 2 // Temporarily blocked, optionally until suspended_until
+	UserStatus_USER_STATUS_DISABLED    UserStatus = 3 // Blocked until an admin reactivates the account
+	UserStatus_USER_STATUS_PENDING     UserStatus = 4 // Not yet activated
+)
+
+// Enum value maps for UserStatus.
+var (
+	UserStatus_name = map[int32]string{
+		0: "USER_STATUS_UNSPECIFIED",
+		1: "USER_STATUS_ACTIVE",
+		2: "USER_STATUS_SUSPENDED",
+		3: "USER_STATUS_DISABLED",
+		4: "USER_STATUS_PENDING",
+	}
+	UserStatus_value = map[string]int32{
+		"USER_STATUS_UNSPECIFIED": 0,
+		"USER_STATUS_ACTIVE":      1,
+		"USER_STATUS_SUSPENDED":   2,
+		"USER_STATUS_DISABLED":    3,
+		"USER_STATUS_PENDING":     4,
+	}
+)
+
+func (x UserStatus) Enum() *UserStatus {
+	p := new(UserStatus)
+	*p = x
+	return p
+}
+
+func (x UserStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (UserStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_user_service_proto_enumTypes[0].Descriptor()
+}
+
+func (UserStatus) Type() protoreflect.EnumType {
+	return &file_proto_user_service_proto_enumTypes[0]
+}
+
+func (x UserStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use UserStatus.Descriptor instead.
+func (UserStatus) EnumDescriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{0}
+}
+
+type TokenFailureReason int32
+
+const (
+	TokenFailureReason_TOKEN_FAILURE_REASON_UNSPECIFIED        TokenFailureReason = 0
+	TokenFailureReason_TOKEN_FAILURE_REASON_EMPTY              TokenFailureReason = 1
+	TokenFailureReason_TOKEN_FAILURE_REASON_MALFORMED          TokenFailureReason = 2
+	TokenFailureReason_TOKEN_FAILURE_REASON_EXPIRED            TokenFailureReason = 3
+	TokenFailureReason_TOKEN_FAILURE_REASON_REVOKED            TokenFailureReason = 4
+	TokenFailureReason_TOKEN_FAILURE_REASON_INVALID_SIGNATURE  TokenFailureReason = 5
+	TokenFailureReason_TOKEN_FAILURE_REASON_WRONG_TOKEN_TYPE   TokenFailureReason = 6
+	TokenFailureReason_TOKEN_FAILURE_REASON_AUDIENCE_MISMATCH  TokenFailureReason = 7
+	TokenFailureReason_TOKEN_FAILURE_REASON_DPOP_PROOF_INVALID TokenFailureReason = 8
+	TokenFailureReason_TOKEN_FAILURE_REASON_USER_REVOKED       TokenFailureReason = 9 // All tokens of the user were revoked (e.g. account suspended)
+)
+
+// Enum value maps for TokenFailureReason.
+var (
+	TokenFailureReason_name = map[int32]string{
+		0: "TOKEN_FAILURE_REASON_UNSPECIFIED",
+		1: "TOKEN_FAILURE_REASON_EMPTY",
+		2: "TOKEN_FAILURE_REASON_MALFORMED",
+		3: "TOKEN_FAILURE_REASON_EXPIRED",
+		4: "TOKEN_FAILURE_REASON_REVOKED",
+		5: "TOKEN_FAILURE_REASON_INVALID_SIGNATURE",
+		6: "TOKEN_FAILURE_REASON_WRONG_TOKEN_TYPE",
+		7: "TOKEN_FAILURE_REASON_AUDIENCE_MISMATCH",
+		8: "TOKEN_FAILURE_REASON_DPOP_PROOF_INVALID",
+		9: "TOKEN_FAILURE_REASON_USER_REVOKED",
+	}
+	TokenFailureReason_value = map[string]int32{
+		"TOKEN_FAILURE_REASON_UNSPECIFIED":        0,
+		"TOKEN_FAILURE_REASON_EMPTY":              1,
+		"TOKEN_FAILURE_REASON_MALFORMED":          2,
+		"TOKEN_FAILURE_REASON_EXPIRED":            3,
+		"TOKEN_FAILURE_REASON_REVOKED":            4,
+		"TOKEN_FAILURE_REASON_INVALID_SIGNATURE":  5,
+		"TOKEN_FAILURE_REASON_WRONG_TOKEN_TYPE":   6,
+		"TOKEN_FAILURE_REASON_AUDIENCE_MISMATCH":  7,
+		"TOKEN_FAILURE_REASON_DPOP_PROOF_INVALID": 8,
+		"TOKEN_FAILURE_REASON_USER_REVOKED":       9,
+	}
+)
+
+func (x TokenFailureReason) Enum() *TokenFailureReason {
+	p := new(TokenFailureReason)
+	*p = x
+	return p
+}
+
+func (x TokenFailureReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TokenFailureReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_user_service_proto_enumTypes[1].Descriptor()
+}
+
+func (TokenFailureReason) Type() protoreflect.EnumType {
+	return &file_proto_user_service_proto_enumTypes[1]
+}
+
+func (x TokenFailureReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TokenFailureReason.Descriptor instead.
+func (TokenFailureReason) EnumDescriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{1}
+}
+
 type User struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     string                 `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Id                  int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name                string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Email               string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	CreatedAt           string                 `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt           string                 `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Role                string                 `protobuf:"bytes,6,opt,name=role,proto3" json:"role,omitempty"`
+	LastLoginAt         string                 `protobuf:"bytes,7,opt,name=last_login_at,json=lastLoginAt,proto3" json:"last_login_at,omitempty"` // Last successful login (RFC3339, empty if never)
+	Status              UserStatus             `protobuf:"varint,8,opt,name=status,proto3,enum=user.UserStatus" json:"status,omitempty"`
+	StatusReason        string                 `protobuf:"bytes,9,opt,name=status_reason,json=statusReason,proto3" json:"status_reason,omitempty"`                         // Set while the account is not active
+	SuspendedUntil      string                 `protobuf:"bytes,10,opt,name=suspended_until,json=suspendedUntil,proto3" json:"suspended_until,omitempty"`                  // End of a temporary suspension (RFC3339, empty = indefinite)
+	DeletedAt           string                 `protobuf:"bytes,11,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`                                 // Set for soft-deleted users (RFC3339)
+	DeletionScheduledAt string                 `protobuf:"bytes,12,opt,name=deletion_scheduled_at,json=deletionScheduledAt,proto3" json:"deletion_scheduled_at,omitempty"` // Requested self-service deletion takes effect at this time (RFC3339)
+	AnonymizedAt        string                 `protobuf:"bytes,13,opt,name=anonymized_at,json=anonymizedAt,proto3" json:"anonymized_at,omitempty"`                        // Set once the user's PII has been erased (RFC3339)
+	Attributes          *structpb.Struct       `protobuf:"bytes,14,opt,name=attributes,proto3" json:"attributes,omitempty"`                                                // Custom profile attributes, valid against the attribute schema
+	AvatarUrl           string                 `protobuf:"bytes,15,opt,name=avatar_url,json=avatarUrl,proto3" json:"avatar_url,omitempty"`                                 // Public URL of the profile picture (empty if none)
+	PendingEmail        string                 `protobuf:"bytes,16,opt,name=pending_email,json=pendingEmail,proto3" json:"pending_email,omitempty"`                        // New email awaiting confirmation (empty if none; only shown to the user and admins)
+	Username            string                 `protobuf:"bytes,17,opt,name=username,proto3" json:"username,omitempty"`                                                    // Unique handle, lower-case (empty if none)
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
 }
 
 func (x *User) Reset() {
@@ -97,11 +236,96 @@ func (x *User) GetUpdatedAt() string {
 	return ""
 }
 
+func (x *User) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *User) GetLastLoginAt() string {
+	if x != nil {
+		return x.LastLoginAt
+	}
+	return ""
+}
+
+func (x *User) GetStatus() UserStatus {
+	if x != nil {
+		return x.Status
+	}
+	return UserStatus_USER_STATUS_UNSPECIFIED
+}
+
+func (x *User) GetStatusReason() string {
+	if x != nil {
+		return x.StatusReason
+	}
+	return ""
+}
+
+func (x *User) GetSuspendedUntil() string {
+	if x != nil {
+		return x.SuspendedUntil
+	}
+	return ""
+}
+
+func (x *User) GetDeletedAt() string {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return ""
+}
+
+func (x *User) GetDeletionScheduledAt() string {
+	if x != nil {
+		return x.DeletionScheduledAt
+	}
+	return ""
+}
+
+func (x *User) GetAnonymizedAt() string {
+	if x != nil {
+		return x.AnonymizedAt
+	}
+	return ""
+}
+
+func (x *User) GetAttributes() *structpb.Struct {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *User) GetAvatarUrl() string {
+	if x != nil {
+		return x.AvatarUrl
+	}
+	return ""
+}
+
+func (x *User) GetPendingEmail() string {
+	if x != nil {
+		return x.PendingEmail
+	}
+	return ""
+}
+
+func (x *User) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
 type CreateUserRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
 	Password      string                 `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+	Username      string                 `protobuf:"bytes,4,opt,name=username,proto3" json:"username,omitempty"` // Optional unique handle
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -157,6 +381,13 @@ func (x *CreateUserRequest) GetPassword() string {
 	return ""
 }
 
+func (x *CreateUserRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
 type CreateUserResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
@@ -409,30 +640,28 @@ func (x *GetUserData) GetUser() *User {
 	return nil
 }
 
-type UpdateUserRequest struct {
+type BatchGetUsersRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          *string                `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
-	Email         *string                `protobuf:"bytes,3,opt,name=email,proto3,oneof" json:"email,omitempty"`
-	Password      *string                `protobuf:"bytes,4,opt,name=password,proto3,oneof" json:"password,omitempty"`
+	Ids           []int32                `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`                      // At most 100 IDs; duplicates are returned once
+	FieldMask     *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=field_mask,json=fieldMask,proto3" json:"field_mask,omitempty"` // Top-level User fields to return (id is always set); empty = all fields
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateUserRequest) Reset() {
-	*x = UpdateUserRequest{}
+func (x *BatchGetUsersRequest) Reset() {
+	*x = BatchGetUsersRequest{}
 	mi := &file_proto_user_service_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateUserRequest) String() string {
+func (x *BatchGetUsersRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateUserRequest) ProtoMessage() {}
+func (*BatchGetUsersRequest) ProtoMessage() {}
 
-func (x *UpdateUserRequest) ProtoReflect() protoreflect.Message {
+func (x *BatchGetUsersRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_user_service_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -444,62 +673,48 @@ func (x *UpdateUserRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateUserRequest.ProtoReflect.Descriptor instead.
-func (*UpdateUserRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use BatchGetUsersRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetUsersRequest) Descriptor() ([]byte, []int) {
 	return file_proto_user_service_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *UpdateUserRequest) GetId() int32 {
+func (x *BatchGetUsersRequest) GetIds() []int32 {
 	if x != nil {
-		return x.Id
+		return x.Ids
 	}
-	return 0
-}
-
-func (x *UpdateUserRequest) GetName() string {
-	if x != nil && x.Name != nil {
-		return *x.Name
-	}
-	return ""
-}
-
-func (x *UpdateUserRequest) GetEmail() string {
-	if x != nil && x.Email != nil {
-		return *x.Email
-	}
-	return ""
+	return nil
 }
 
-func (x *UpdateUserRequest) GetPassword() string {
-	if x != nil && x.Password != nil {
-		return *x.Password
+func (x *BatchGetUsersRequest) GetFieldMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.FieldMask
 	}
-	return ""
+	return nil
 }
 
-type UpdateUserResponse struct {
+type BatchGetUsersResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
 	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Data          *UpdateUserData        `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	Data          *BatchGetUsersData     `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateUserResponse) Reset() {
-	*x = UpdateUserResponse{}
+func (x *BatchGetUsersResponse) Reset() {
+	*x = BatchGetUsersResponse{}
 	mi := &file_proto_user_service_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateUserResponse) String() string {
+func (x *BatchGetUsersResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateUserResponse) ProtoMessage() {}
+func (*BatchGetUsersResponse) ProtoMessage() {}
 
-func (x *UpdateUserResponse) ProtoReflect() protoreflect.Message {
+func (x *BatchGetUsersResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_user_service_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -511,53 +726,54 @@ func (x *UpdateUserResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateUserResponse.ProtoReflect.Descriptor instead.
-func (*UpdateUserResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use BatchGetUsersResponse.ProtoReflect.Descriptor instead.
+func (*BatchGetUsersResponse) Descriptor() ([]byte, []int) {
 	return file_proto_user_service_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *UpdateUserResponse) GetCode() string {
+func (x *BatchGetUsersResponse) GetCode() string {
 	if x != nil {
 		return x.Code
 	}
 	return ""
 }
 
-func (x *UpdateUserResponse) GetMessage() string {
+func (x *BatchGetUsersResponse) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-func (x *UpdateUserResponse) GetData() *UpdateUserData {
+func (x *BatchGetUsersResponse) GetData() *BatchGetUsersData {
 	if x != nil {
 		return x.Data
 	}
 	return nil
 }
 
-type UpdateUserData struct {
+type BatchGetUsersData struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	Users         []*User                `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`                                     // Found users in request order
+	MissingIds    []int32                `protobuf:"varint,2,rep,packed,name=missing_ids,json=missingIds,proto3" json:"missing_ids,omitempty"` // Requested IDs without an active user
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateUserData) Reset() {
-	*x = UpdateUserData{}
+func (x *BatchGetUsersData) Reset() {
+	*x = BatchGetUsersData{}
 	mi := &file_proto_user_service_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateUserData) String() string {
+func (x *BatchGetUsersData) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateUserData) ProtoMessage() {}
+func (*BatchGetUsersData) ProtoMessage() {}
 
-func (x *UpdateUserData) ProtoReflect() protoreflect.Message {
+func (x *BatchGetUsersData) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_user_service_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -569,39 +785,46 @@ func (x *UpdateUserData) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateUserData.ProtoReflect.Descriptor instead.
-func (*UpdateUserData) Descriptor() ([]byte, []int) {
+// Deprecated: Use BatchGetUsersData.ProtoReflect.Descriptor instead.
+func (*BatchGetUsersData) Descriptor() ([]byte, []int) {
 	return file_proto_user_service_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *UpdateUserData) GetUser() *User {
+func (x *BatchGetUsersData) GetUsers() []*User {
 	if x != nil {
-		return x.User
+		return x.Users
 	}
 	return nil
 }
 
-type DeleteUserRequest struct {
+func (x *BatchGetUsersData) GetMissingIds() []int32 {
+	if x != nil {
+		return x.MissingIds
+	}
+	return nil
+}
+
+type GetUserByUsernameRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteUserRequest) Reset() {
-	*x = DeleteUserRequest{}
+func (x *GetUserByUsernameRequest) Reset() {
+	*x = GetUserByUsernameRequest{}
 	mi := &file_proto_user_service_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteUserRequest) String() string {
+func (x *GetUserByUsernameRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteUserRequest) ProtoMessage() {}
+func (*GetUserByUsernameRequest) ProtoMessage() {}
 
-func (x *DeleteUserRequest) ProtoReflect() protoreflect.Message {
+func (x *GetUserByUsernameRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_user_service_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -613,41 +836,41 @@ func (x *DeleteUserRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteUserRequest.ProtoReflect.Descriptor instead.
-func (*DeleteUserRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetUserByUsernameRequest.ProtoReflect.Descriptor instead.
+func (*GetUserByUsernameRequest) Descriptor() ([]byte, []int) {
 	return file_proto_user_service_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *DeleteUserRequest) GetId() int32 {
+func (x *GetUserByUsernameRequest) GetUsername() string {
 	if x != nil {
-		return x.Id
+		return x.Username
 	}
-	return 0
+	return ""
 }
 
-type DeleteUserResponse struct {
+type GetUserByUsernameResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
 	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Data          *DeleteUserData        `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	Data          *GetUserByUsernameData `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteUserResponse) Reset() {
-	*x = DeleteUserResponse{}
+func (x *GetUserByUsernameResponse) Reset() {
+	*x = GetUserByUsernameResponse{}
 	mi := &file_proto_user_service_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteUserResponse) String() string {
+func (x *GetUserByUsernameResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteUserResponse) ProtoMessage() {}
+func (*GetUserByUsernameResponse) ProtoMessage() {}
 
-func (x *DeleteUserResponse) ProtoReflect() protoreflect.Message {
+func (x *GetUserByUsernameResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_user_service_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -659,53 +882,53 @@ func (x *DeleteUserResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteUserResponse.ProtoReflect.Descriptor instead.
-func (*DeleteUserResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetUserByUsernameResponse.ProtoReflect.Descriptor instead.
+func (*GetUserByUsernameResponse) Descriptor() ([]byte, []int) {
 	return file_proto_user_service_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *DeleteUserResponse) GetCode() string {
+func (x *GetUserByUsernameResponse) GetCode() string {
 	if x != nil {
 		return x.Code
 	}
 	return ""
 }
 
-func (x *DeleteUserResponse) GetMessage() string {
+func (x *GetUserByUsernameResponse) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-func (x *DeleteUserResponse) GetData() *DeleteUserData {
+func (x *GetUserByUsernameResponse) GetData() *GetUserByUsernameData {
 	if x != nil {
 		return x.Data
 	}
 	return nil
 }
 
-type DeleteUserData struct {
+type GetUserByUsernameData struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteUserData) Reset() {
-	*x = DeleteUserData{}
+func (x *GetUserByUsernameData) Reset() {
+	*x = GetUserByUsernameData{}
 	mi := &file_proto_user_service_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteUserData) String() string {
+func (x *GetUserByUsernameData) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteUserData) ProtoMessage() {}
+func (*GetUserByUsernameData) ProtoMessage() {}
 
-func (x *DeleteUserData) ProtoReflect() protoreflect.Message {
+func (x *GetUserByUsernameData) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_user_service_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -717,40 +940,43 @@ func (x *DeleteUserData) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteUserData.ProtoReflect.Descriptor instead.
-func (*DeleteUserData) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetUserByUsernameData.ProtoReflect.Descriptor instead.
+func (*GetUserByUsernameData) Descriptor() ([]byte, []int) {
 	return file_proto_user_service_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *DeleteUserData) GetSuccess() bool {
+func (x *GetUserByUsernameData) GetUser() *User {
 	if x != nil {
-		return x.Success
+		return x.User
 	}
-	return false
+	return nil
 }
 
-type ListUsersRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
-	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+type CheckAvailabilityRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Identifier:
+	//
+	//	*CheckAvailabilityRequest_Email
+	//	*CheckAvailabilityRequest_Username
+	Identifier    isCheckAvailabilityRequest_Identifier `protobuf_oneof:"identifier"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListUsersRequest) Reset() {
-	*x = ListUsersRequest{}
+func (x *CheckAvailabilityRequest) Reset() {
+	*x = CheckAvailabilityRequest{}
 	mi := &file_proto_user_service_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListUsersRequest) String() string {
+func (x *CheckAvailabilityRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListUsersRequest) ProtoMessage() {}
+func (*CheckAvailabilityRequest) ProtoMessage() {}
 
-func (x *ListUsersRequest) ProtoReflect() protoreflect.Message {
+func (x *CheckAvailabilityRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_user_service_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -762,48 +988,75 @@ func (x *ListUsersRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListUsersRequest.ProtoReflect.Descriptor instead.
-func (*ListUsersRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use CheckAvailabilityRequest.ProtoReflect.Descriptor instead.
+func (*CheckAvailabilityRequest) Descriptor() ([]byte, []int) {
 	return file_proto_user_service_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *ListUsersRequest) GetPage() int32 {
+func (x *CheckAvailabilityRequest) GetIdentifier() isCheckAvailabilityRequest_Identifier {
 	if x != nil {
-		return x.Page
+		return x.Identifier
 	}
-	return 0
+	return nil
 }
 
-func (x *ListUsersRequest) GetPageSize() int32 {
+func (x *CheckAvailabilityRequest) GetEmail() string {
 	if x != nil {
-		return x.PageSize
+		if x, ok := x.Identifier.(*CheckAvailabilityRequest_Email); ok {
+			return x.Email
+		}
 	}
-	return 0
+	return ""
 }
 
-type ListUsersResponse struct {
+func (x *CheckAvailabilityRequest) GetUsername() string {
+	if x != nil {
+		if x, ok := x.Identifier.(*CheckAvailabilityRequest_Username); ok {
+			return x.Username
+		}
+	}
+	return ""
+}
+
+type isCheckAvailabilityRequest_Identifier interface {
+	isCheckAvailabilityRequest_Identifier()
+}
+
+type CheckAvailabilityRequest_Email struct {
+	Email string `protobuf:"bytes,1,opt,name=email,proto3,oneof"`
+}
+
+type CheckAvailabilityRequest_Username struct {
+	Username string `protobuf:"bytes,2,opt,name=username,proto3,oneof"`
+}
+
+func (*CheckAvailabilityRequest_Email) isCheckAvailabilityRequest_Identifier() {}
+
+func (*CheckAvailabilityRequest_Username) isCheckAvailabilityRequest_Identifier() {}
+
+type CheckAvailabilityResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
 	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Data          *ListUsersData         `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	Data          *CheckAvailabilityData `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListUsersResponse) Reset() {
-	*x = ListUsersResponse{}
+func (x *CheckAvailabilityResponse) Reset() {
+	*x = CheckAvailabilityResponse{}
 	mi := &file_proto_user_service_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListUsersResponse) String() string {
+func (x *CheckAvailabilityResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListUsersResponse) ProtoMessage() {}
+func (*CheckAvailabilityResponse) ProtoMessage() {}
 
-func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
+func (x *CheckAvailabilityResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_user_service_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -815,57 +1068,55 @@ func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListUsersResponse.ProtoReflect.Descriptor instead.
-func (*ListUsersResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use CheckAvailabilityResponse.ProtoReflect.Descriptor instead.
+func (*CheckAvailabilityResponse) Descriptor() ([]byte, []int) {
 	return file_proto_user_service_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *ListUsersResponse) GetCode() string {
+func (x *CheckAvailabilityResponse) GetCode() string {
 	if x != nil {
 		return x.Code
 	}
 	return ""
 }
 
-func (x *ListUsersResponse) GetMessage() string {
+func (x *CheckAvailabilityResponse) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-func (x *ListUsersResponse) GetData() *ListUsersData {
+func (x *CheckAvailabilityResponse) GetData() *CheckAvailabilityData {
 	if x != nil {
 		return x.Data
 	}
 	return nil
 }
 
-type ListUsersData struct {
+type CheckAvailabilityData struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Users         []*User                `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
-	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
-	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
-	Size          int32                  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
-	HasMore       bool                   `protobuf:"varint,5,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+	Available     bool                   `protobuf:"varint,1,opt,name=available,proto3" json:"available,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"` // Why the identifier is unavailable: "taken", "reserved" or "invalid"
+	Detail        string                 `protobuf:"bytes,3,opt,name=detail,proto3" json:"detail,omitempty"` // Human-readable explanation for "invalid"
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListUsersData) Reset() {
-	*x = ListUsersData{}
+func (x *CheckAvailabilityData) Reset() {
+	*x = CheckAvailabilityData{}
 	mi := &file_proto_user_service_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListUsersData) String() string {
+func (x *CheckAvailabilityData) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListUsersData) ProtoMessage() {}
+func (*CheckAvailabilityData) ProtoMessage() {}
 
-func (x *ListUsersData) ProtoReflect() protoreflect.Message {
+func (x *CheckAvailabilityData) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_user_service_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -877,68 +1128,58 @@ func (x *ListUsersData) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListUsersData.ProtoReflect.Descriptor instead.
-func (*ListUsersData) Descriptor() ([]byte, []int) {
+// Deprecated: Use CheckAvailabilityData.ProtoReflect.Descriptor instead.
+func (*CheckAvailabilityData) Descriptor() ([]byte, []int) {
 	return file_proto_user_service_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *ListUsersData) GetUsers() []*User {
-	if x != nil {
-		return x.Users
-	}
-	return nil
-}
-
-func (x *ListUsersData) GetTotal() int64 {
-	if x != nil {
-		return x.Total
-	}
-	return 0
-}
-
-func (x *ListUsersData) GetPage() int32 {
+func (x *CheckAvailabilityData) GetAvailable() bool {
 	if x != nil {
-		return x.Page
+		return x.Available
 	}
-	return 0
+	return false
 }
 
-func (x *ListUsersData) GetSize() int32 {
+func (x *CheckAvailabilityData) GetReason() string {
 	if x != nil {
-		return x.Size
+		return x.Reason
 	}
-	return 0
+	return ""
 }
 
-func (x *ListUsersData) GetHasMore() bool {
+func (x *CheckAvailabilityData) GetDetail() string {
 	if x != nil {
-		return x.HasMore
+		return x.Detail
 	}
-	return false
+	return ""
 }
 
-type LoginRequest struct {
+type UpdateUserRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
-	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          *string                `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	Email         *string                `protobuf:"bytes,3,opt,name=email,proto3,oneof" json:"email,omitempty"`
+	Password      *string                `protobuf:"bytes,4,opt,name=password,proto3,oneof" json:"password,omitempty"`
+	Attributes    *structpb.Struct       `protobuf:"bytes,5,opt,name=attributes,proto3" json:"attributes,omitempty"`   // JSON merge patch (RFC 7396) applied to the current attributes; null removes a key
+	Username      *string                `protobuf:"bytes,6,opt,name=username,proto3,oneof" json:"username,omitempty"` // Empty string removes the username
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *LoginRequest) Reset() {
-	*x = LoginRequest{}
+func (x *UpdateUserRequest) Reset() {
+	*x = UpdateUserRequest{}
 	mi := &file_proto_user_service_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LoginRequest) String() string {
+func (x *UpdateUserRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LoginRequest) ProtoMessage() {}
+func (*UpdateUserRequest) ProtoMessage() {}
 
-func (x *LoginRequest) ProtoReflect() protoreflect.Message {
+func (x *UpdateUserRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_user_service_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -950,48 +1191,76 @@ func (x *LoginRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LoginRequest.ProtoReflect.Descriptor instead.
-func (*LoginRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use UpdateUserRequest.ProtoReflect.Descriptor instead.
+func (*UpdateUserRequest) Descriptor() ([]byte, []int) {
 	return file_proto_user_service_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *LoginRequest) GetEmail() string {
+func (x *UpdateUserRequest) GetId() int32 {
 	if x != nil {
-		return x.Email
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateUserRequest) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
 	}
 	return ""
 }
 
-func (x *LoginRequest) GetPassword() string {
+func (x *UpdateUserRequest) GetEmail() string {
+	if x != nil && x.Email != nil {
+		return *x.Email
+	}
+	return ""
+}
+
+func (x *UpdateUserRequest) GetPassword() string {
+	if x != nil && x.Password != nil {
+		return *x.Password
+	}
+	return ""
+}
+
+func (x *UpdateUserRequest) GetAttributes() *structpb.Struct {
 	if x != nil {
-		return x.Password
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *UpdateUserRequest) GetUsername() string {
+	if x != nil && x.Username != nil {
+		return *x.Username
 	}
 	return ""
 }
 
-type LoginResponse struct {
+type UpdateUserResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
 	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Data          *LoginData             `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	Data          *UpdateUserData        `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *LoginResponse) Reset() {
-	*x = LoginResponse{}
+func (x *UpdateUserResponse) Reset() {
+	*x = UpdateUserResponse{}
 	mi := &file_proto_user_service_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LoginResponse) String() string {
+func (x *UpdateUserResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LoginResponse) ProtoMessage() {}
+func (*UpdateUserResponse) ProtoMessage() {}
 
-func (x *LoginResponse) ProtoReflect() protoreflect.Message {
+func (x *UpdateUserResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_user_service_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1003,54 +1272,53 @@ func (x *LoginResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LoginResponse.ProtoReflect.Descriptor instead.
-func (*LoginResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use UpdateUserResponse.ProtoReflect.Descriptor instead.
+func (*UpdateUserResponse) Descriptor() ([]byte, []int) {
 	return file_proto_user_service_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *LoginResponse) GetCode() string {
+func (x *UpdateUserResponse) GetCode() string {
 	if x != nil {
 		return x.Code
 	}
 	return ""
 }
 
-func (x *LoginResponse) GetMessage() string {
+func (x *UpdateUserResponse) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-func (x *LoginResponse) GetData() *LoginData {
+func (x *UpdateUserResponse) GetData() *UpdateUserData {
 	if x != nil {
 		return x.Data
 	}
 	return nil
 }
 
-type LoginData struct {
+type UpdateUserData struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
-	RefreshToken  string                 `protobuf:"bytes,2,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *LoginData) Reset() {
-	*x = LoginData{}
+func (x *UpdateUserData) Reset() {
+	*x = UpdateUserData{}
 	mi := &file_proto_user_service_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LoginData) String() string {
+func (x *UpdateUserData) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LoginData) ProtoMessage() {}
+func (*UpdateUserData) ProtoMessage() {}
 
-func (x *LoginData) ProtoReflect() protoreflect.Message {
+func (x *UpdateUserData) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_user_service_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1062,46 +1330,39 @@ func (x *LoginData) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LoginData.ProtoReflect.Descriptor instead.
-func (*LoginData) Descriptor() ([]byte, []int) {
+// Deprecated: Use UpdateUserData.ProtoReflect.Descriptor instead.
+func (*UpdateUserData) Descriptor() ([]byte, []int) {
 	return file_proto_user_service_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *LoginData) GetAccessToken() string {
-	if x != nil {
-		return x.AccessToken
-	}
-	return ""
-}
-
-func (x *LoginData) GetRefreshToken() string {
+func (x *UpdateUserData) GetUser() *User {
 	if x != nil {
-		return x.RefreshToken
+		return x.User
 	}
-	return ""
+	return nil
 }
 
-type ValidateTokenRequest struct {
+type DeleteUserRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ValidateTokenRequest) Reset() {
-	*x = ValidateTokenRequest{}
+func (x *DeleteUserRequest) Reset() {
+	*x = DeleteUserRequest{}
 	mi := &file_proto_user_service_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ValidateTokenRequest) String() string {
+func (x *DeleteUserRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ValidateTokenRequest) ProtoMessage() {}
+func (*DeleteUserRequest) ProtoMessage() {}
 
-func (x *ValidateTokenRequest) ProtoReflect() protoreflect.Message {
+func (x *DeleteUserRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_user_service_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1113,41 +1374,41 @@ func (x *ValidateTokenRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ValidateTokenRequest.ProtoReflect.Descriptor instead.
-func (*ValidateTokenRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use DeleteUserRequest.ProtoReflect.Descriptor instead.
+func (*DeleteUserRequest) Descriptor() ([]byte, []int) {
 	return file_proto_user_service_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *ValidateTokenRequest) GetToken() string {
+func (x *DeleteUserRequest) GetId() int32 {
 	if x != nil {
-		return x.Token
+		return x.Id
 	}
-	return ""
+	return 0
 }
 
-type ValidateTokenResponse struct {
+type DeleteUserResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
 	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Data          *ValidateTokenData     `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	Data          *DeleteUserData        `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ValidateTokenResponse) Reset() {
-	*x = ValidateTokenResponse{}
+func (x *DeleteUserResponse) Reset() {
+	*x = DeleteUserResponse{}
 	mi := &file_proto_user_service_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ValidateTokenResponse) String() string {
+func (x *DeleteUserResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ValidateTokenResponse) ProtoMessage() {}
+func (*DeleteUserResponse) ProtoMessage() {}
 
-func (x *ValidateTokenResponse) ProtoReflect() protoreflect.Message {
+func (x *DeleteUserResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_user_service_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1159,55 +1420,53 @@ func (x *ValidateTokenResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ValidateTokenResponse.ProtoReflect.Descriptor instead.
-func (*ValidateTokenResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use DeleteUserResponse.ProtoReflect.Descriptor instead.
+func (*DeleteUserResponse) Descriptor() ([]byte, []int) {
 	return file_proto_user_service_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *ValidateTokenResponse) GetCode() string {
+func (x *DeleteUserResponse) GetCode() string {
 	if x != nil {
 		return x.Code
 	}
 	return ""
 }
 
-func (x *ValidateTokenResponse) GetMessage() string {
+func (x *DeleteUserResponse) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-func (x *ValidateTokenResponse) GetData() *ValidateTokenData {
+func (x *DeleteUserResponse) GetData() *DeleteUserData {
 	if x != nil {
 		return x.Data
 	}
 	return nil
 }
 
-type ValidateTokenData struct {
+type DeleteUserData struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Valid         bool                   `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
-	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ValidateTokenData) Reset() {
-	*x = ValidateTokenData{}
+func (x *DeleteUserData) Reset() {
+	*x = DeleteUserData{}
 	mi := &file_proto_user_service_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ValidateTokenData) String() string {
+func (x *DeleteUserData) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ValidateTokenData) ProtoMessage() {}
+func (*DeleteUserData) ProtoMessage() {}
 
-func (x *ValidateTokenData) ProtoReflect() protoreflect.Message {
+func (x *DeleteUserData) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_user_service_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1219,54 +1478,52 @@ func (x *ValidateTokenData) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ValidateTokenData.ProtoReflect.Descriptor instead.
-func (*ValidateTokenData) Descriptor() ([]byte, []int) {
+// Deprecated: Use DeleteUserData.ProtoReflect.Descriptor instead.
+func (*DeleteUserData) Descriptor() ([]byte, []int) {
 	return file_proto_user_service_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *ValidateTokenData) GetValid() bool {
+func (x *DeleteUserData) GetSuccess() bool {
 	if x != nil {
-		return x.Valid
+		return x.Success
 	}
 	return false
 }
 
-func (x *ValidateTokenData) GetUserId() int64 {
-	if x != nil {
-		return x.UserId
-	}
-	return 0
-}
-
-func (x *ValidateTokenData) GetEmail() string {
-	if x != nil {
-		return x.Email
-	}
-	return ""
-}
-
-type LogoutRequest struct {
+type ListUsersRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`                                   // Access token (required)
-	RefreshToken  string                 `protobuf:"bytes,2,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"` // Refresh token (optional but recommended)
+	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Attributes    *structpb.Struct       `protobuf:"bytes,3,opt,name=attributes,proto3" json:"attributes,omitempty"`                                 // Only users whose attributes contain this document
+	Name          string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`                                             // Case-insensitive substring of the name
+	Email         string                 `protobuf:"bytes,5,opt,name=email,proto3" json:"email,omitempty"`                                           // Case-insensitive substring of the email
+	CreatedAfter  string                 `protobuf:"bytes,6,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`         // RFC3339, inclusive
+	CreatedBefore string                 `protobuf:"bytes,7,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`      // RFC3339, exclusive
+	UpdatedAfter  string                 `protobuf:"bytes,8,opt,name=updated_after,json=updatedAfter,proto3" json:"updated_after,omitempty"`         // RFC3339, inclusive
+	UpdatedBefore string                 `protobuf:"bytes,9,opt,name=updated_before,json=updatedBefore,proto3" json:"updated_before,omitempty"`      // RFC3339, exclusive
+	Status        UserStatus             `protobuf:"varint,10,opt,name=status,proto3,enum=user.UserStatus" json:"status,omitempty"`                  // Unspecified = any status
+	Role          string                 `protobuf:"bytes,11,opt,name=role,proto3" json:"role,omitempty"`                                            // "user" or "admin"; empty = any role
+	OrderBy       string                 `protobuf:"bytes,12,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`                       // "<field> [asc|desc]" with field id, name, email, created_at or updated_at; default "id"
+	PageToken     string                 `protobuf:"bytes,13,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`                 // next_page_token of the previous page (keyset pagination); page must then be 0
+	IncludeTotal  *bool                  `protobuf:"varint,14,opt,name=include_total,json=includeTotal,proto3,oneof" json:"include_total,omitempty"` // Unset or true counts matching users exactly; false returns a cheaper planner estimate
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *LogoutRequest) Reset() {
-	*x = LogoutRequest{}
+func (x *ListUsersRequest) Reset() {
+	*x = ListUsersRequest{}
 	mi := &file_proto_user_service_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LogoutRequest) String() string {
+func (x *ListUsersRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LogoutRequest) ProtoMessage() {}
+func (*ListUsersRequest) ProtoMessage() {}
 
-func (x *LogoutRequest) ProtoReflect() protoreflect.Message {
+func (x *ListUsersRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_user_service_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1278,49 +1535,4929 @@ func (x *LogoutRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LogoutRequest.ProtoReflect.Descriptor instead.
-func (*LogoutRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListUsersRequest.ProtoReflect.Descriptor instead.
+func (*ListUsersRequest) Descriptor() ([]byte, []int) {
 	return file_proto_user_service_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *LogoutRequest) GetToken() string {
+func (x *ListUsersRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListUsersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListUsersRequest) GetAttributes() *structpb.Struct {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *ListUsersRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ListUsersRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *ListUsersRequest) GetCreatedAfter() string {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return ""
+}
+
+func (x *ListUsersRequest) GetCreatedBefore() string {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return ""
+}
+
+func (x *ListUsersRequest) GetUpdatedAfter() string {
+	if x != nil {
+		return x.UpdatedAfter
+	}
+	return ""
+}
+
+func (x *ListUsersRequest) GetUpdatedBefore() string {
+	if x != nil {
+		return x.UpdatedBefore
+	}
+	return ""
+}
+
+func (x *ListUsersRequest) GetStatus() UserStatus {
+	if x != nil {
+		return x.Status
+	}
+	return UserStatus_USER_STATUS_UNSPECIFIED
+}
+
+func (x *ListUsersRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *ListUsersRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+func (x *ListUsersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListUsersRequest) GetIncludeTotal() bool {
+	if x != nil && x.IncludeTotal != nil {
+		return *x.IncludeTotal
+	}
+	return false
+}
+
+type ListUsersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Data          *ListUsersData         `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersResponse) Reset() {
+	*x = ListUsersResponse{}
+	mi := &file_proto_user_service_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersResponse) ProtoMessage() {}
+
+func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersResponse.ProtoReflect.Descriptor instead.
+func (*ListUsersResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListUsersResponse) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *ListUsersResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ListUsersResponse) GetData() *ListUsersData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type ListUsersData struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Users          []*User                `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	Total          int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page           int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	Size           int32                  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	HasMore        bool                   `protobuf:"varint,5,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+	NextPageToken  string                 `protobuf:"bytes,6,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`   // Token of the next page; empty on the last page
+	TotalEstimated bool                   `protobuf:"varint,7,opt,name=total_estimated,json=totalEstimated,proto3" json:"total_estimated,omitempty"` // total is an estimate (include_total was false)
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListUsersData) Reset() {
+	*x = ListUsersData{}
+	mi := &file_proto_user_service_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersData) ProtoMessage() {}
+
+func (x *ListUsersData) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersData.ProtoReflect.Descriptor instead.
+func (*ListUsersData) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListUsersData) GetUsers() []*User {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *ListUsersData) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListUsersData) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListUsersData) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *ListUsersData) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+func (x *ListUsersData) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListUsersData) GetTotalEstimated() bool {
+	if x != nil {
+		return x.TotalEstimated
+	}
+	return false
+}
+
+type LoginRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"` // Email or username
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	RememberMe    bool                   `protobuf:"varint,3,opt,name=remember_me,json=rememberMe,proto3" json:"remember_me,omitempty"` // Opt in to the longer "remember me" session profile
+	ClientId      string                 `protobuf:"bytes,4,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`        // Client application, selects its session policy (optional)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginRequest) Reset() {
+	*x = LoginRequest{}
+	mi := &file_proto_user_service_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginRequest) ProtoMessage() {}
+
+func (x *LoginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginRequest.ProtoReflect.Descriptor instead.
+func (*LoginRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *LoginRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *LoginRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *LoginRequest) GetRememberMe() bool {
+	if x != nil {
+		return x.RememberMe
+	}
+	return false
+}
+
+func (x *LoginRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+type LoginResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Data          *LoginData             `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginResponse) Reset() {
+	*x = LoginResponse{}
+	mi := &file_proto_user_service_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginResponse) ProtoMessage() {}
+
+func (x *LoginResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginResponse.ProtoReflect.Descriptor instead.
+func (*LoginResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *LoginResponse) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *LoginResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *LoginResponse) GetData() *LoginData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type LoginData struct {
+	state                    protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken              string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	RefreshToken             string                 `protobuf:"bytes,2,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	PasswordRotationRequired bool                   `protobuf:"varint,3,opt,name=password_rotation_required,json=passwordRotationRequired,proto3" json:"password_rotation_required,omitempty"` // Current password was found in a breach and must be changed
+	TokenType                string                 `protobuf:"bytes,4,opt,name=token_type,json=tokenType,proto3" json:"token_type,omitempty"`                                                 // "DPoP" when bound to the key of the "dpop" metadata proof, otherwise "Bearer"
+	SessionExpiresAt         string                 `protobuf:"bytes,5,opt,name=session_expires_at,json=sessionExpiresAt,proto3" json:"session_expires_at,omitempty"`                          // Absolute session end (RFC3339, empty = unlimited)
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *LoginData) Reset() {
+	*x = LoginData{}
+	mi := &file_proto_user_service_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginData) ProtoMessage() {}
+
+func (x *LoginData) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginData.ProtoReflect.Descriptor instead.
+func (*LoginData) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *LoginData) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *LoginData) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+func (x *LoginData) GetPasswordRotationRequired() bool {
+	if x != nil {
+		return x.PasswordRotationRequired
+	}
+	return false
+}
+
+func (x *LoginData) GetTokenType() string {
+	if x != nil {
+		return x.TokenType
+	}
+	return ""
+}
+
+func (x *LoginData) GetSessionExpiresAt() string {
+	if x != nil {
+		return x.SessionExpiresAt
+	}
+	return ""
+}
+
+type ValidateTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	DpopProof     string                 `protobuf:"bytes,2,opt,name=dpop_proof,json=dpopProof,proto3" json:"dpop_proof,omitempty"`    // Required for DPoP-bound tokens
+	HttpMethod    string                 `protobuf:"bytes,3,opt,name=http_method,json=httpMethod,proto3" json:"http_method,omitempty"` // Method of the request being authorized (defaults to POST for RPC binding)
+	HttpUri       string                 `protobuf:"bytes,4,opt,name=http_uri,json=httpUri,proto3" json:"http_uri,omitempty"`          // URI of the request being authorized, or the gRPC method name ("/package.Service/Method")
+	Audience      string                 `protobuf:"bytes,5,opt,name=audience,proto3" json:"audience,omitempty"`                       // Name of the validating service; required for audience-restricted (exchanged) tokens
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateTokenRequest) Reset() {
+	*x = ValidateTokenRequest{}
+	mi := &file_proto_user_service_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateTokenRequest) ProtoMessage() {}
+
+func (x *ValidateTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateTokenRequest.ProtoReflect.Descriptor instead.
+func (*ValidateTokenRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ValidateTokenRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ValidateTokenRequest) GetDpopProof() string {
+	if x != nil {
+		return x.DpopProof
+	}
+	return ""
+}
+
+func (x *ValidateTokenRequest) GetHttpMethod() string {
+	if x != nil {
+		return x.HttpMethod
+	}
+	return ""
+}
+
+func (x *ValidateTokenRequest) GetHttpUri() string {
+	if x != nil {
+		return x.HttpUri
+	}
+	return ""
+}
+
+func (x *ValidateTokenRequest) GetAudience() string {
+	if x != nil {
+		return x.Audience
+	}
+	return ""
+}
+
+type ValidateTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Data          *ValidateTokenData     `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateTokenResponse) Reset() {
+	*x = ValidateTokenResponse{}
+	mi := &file_proto_user_service_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateTokenResponse) ProtoMessage() {}
+
+func (x *ValidateTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateTokenResponse.ProtoReflect.Descriptor instead.
+func (*ValidateTokenResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ValidateTokenResponse) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *ValidateTokenResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ValidateTokenResponse) GetData() *ValidateTokenData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type ValidateTokenData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Valid         bool                   `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	Role          string                 `protobuf:"bytes,4,opt,name=role,proto3" json:"role,omitempty"`
+	Actors        []*TokenActor          `protobuf:"bytes,5,rep,name=actors,proto3" json:"actors,omitempty"`                        // RFC 8693 "act" chain, current actor first (empty = not delegated)
+	AuthTime      string                 `protobuf:"bytes,6,opt,name=auth_time,json=authTime,proto3" json:"auth_time,omitempty"`    // When the user last authenticated interactively (RFC3339, empty if unknown)
+	Amr           []string               `protobuf:"bytes,7,rep,name=amr,proto3" json:"amr,omitempty"`                              // Authentication methods used: pwd, otp, webauthn
+	TokenType     string                 `protobuf:"bytes,8,opt,name=token_type,json=tokenType,proto3" json:"token_type,omitempty"` // "DPoP" or "Bearer"
+	Audience      []string               `protobuf:"bytes,9,rep,name=audience,proto3" json:"audience,omitempty"`                    // Services the token is meant for (empty = first-party token)
+	Scopes        []string               `protobuf:"bytes,10,rep,name=scopes,proto3" json:"scopes,omitempty"`                       // Granted scopes (empty = unrestricted)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateTokenData) Reset() {
+	*x = ValidateTokenData{}
+	mi := &file_proto_user_service_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateTokenData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateTokenData) ProtoMessage() {}
+
+func (x *ValidateTokenData) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateTokenData.ProtoReflect.Descriptor instead.
+func (*ValidateTokenData) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ValidateTokenData) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *ValidateTokenData) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ValidateTokenData) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *ValidateTokenData) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *ValidateTokenData) GetActors() []*TokenActor {
+	if x != nil {
+		return x.Actors
+	}
+	return nil
+}
+
+func (x *ValidateTokenData) GetAuthTime() string {
+	if x != nil {
+		return x.AuthTime
+	}
+	return ""
+}
+
+func (x *ValidateTokenData) GetAmr() []string {
+	if x != nil {
+		return x.Amr
+	}
+	return nil
+}
+
+func (x *ValidateTokenData) GetTokenType() string {
+	if x != nil {
+		return x.TokenType
+	}
+	return ""
+}
+
+func (x *ValidateTokenData) GetAudience() []string {
+	if x != nil {
+		return x.Audience
+	}
+	return nil
+}
+
+func (x *ValidateTokenData) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+type ValidateTokensRequest struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Tokens        []*ValidateTokenRequest `protobuf:"bytes,1,rep,name=tokens,proto3" json:"tokens,omitempty"`                        // At most 100 per batch
+	RequestId     string                  `protobuf:"bytes,2,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"` // Echoed in the response to correlate streamed batches
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateTokensRequest) Reset() {
+	*x = ValidateTokensRequest{}
+	mi := &file_proto_user_service_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateTokensRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateTokensRequest) ProtoMessage() {}
+
+func (x *ValidateTokensRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateTokensRequest.ProtoReflect.Descriptor instead.
+func (*ValidateTokensRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ValidateTokensRequest) GetTokens() []*ValidateTokenRequest {
+	if x != nil {
+		return x.Tokens
+	}
+	return nil
+}
+
+func (x *ValidateTokensRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+type ValidateTokensResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Data          *ValidateTokensData    `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateTokensResponse) Reset() {
+	*x = ValidateTokensResponse{}
+	mi := &file_proto_user_service_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateTokensResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateTokensResponse) ProtoMessage() {}
+
+func (x *ValidateTokensResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateTokensResponse.ProtoReflect.Descriptor instead.
+func (*ValidateTokensResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ValidateTokensResponse) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *ValidateTokensResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ValidateTokensResponse) GetData() *ValidateTokensData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type ValidateTokensData struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	RequestId     string                   `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	Results       []*TokenValidationResult `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"` // Same order as the request
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateTokensData) Reset() {
+	*x = ValidateTokensData{}
+	mi := &file_proto_user_service_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateTokensData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateTokensData) ProtoMessage() {}
+
+func (x *ValidateTokensData) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateTokensData.ProtoReflect.Descriptor instead.
+func (*ValidateTokensData) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ValidateTokensData) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *ValidateTokensData) GetResults() []*TokenValidationResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type TokenValidationResult struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Index          int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"` // Position of the token in the request
+	Valid          bool                   `protobuf:"varint,2,opt,name=valid,proto3" json:"valid,omitempty"`
+	Claims         *ValidateTokenData     `protobuf:"bytes,3,opt,name=claims,proto3" json:"claims,omitempty"` // Set when valid
+	FailureReason  TokenFailureReason     `protobuf:"varint,4,opt,name=failure_reason,json=failureReason,proto3,enum=user.TokenFailureReason" json:"failure_reason,omitempty"`
+	FailureMessage string                 `protobuf:"bytes,5,opt,name=failure_message,json=failureMessage,proto3" json:"failure_message,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *TokenValidationResult) Reset() {
+	*x = TokenValidationResult{}
+	mi := &file_proto_user_service_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TokenValidationResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TokenValidationResult) ProtoMessage() {}
+
+func (x *TokenValidationResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TokenValidationResult.ProtoReflect.Descriptor instead.
+func (*TokenValidationResult) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *TokenValidationResult) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *TokenValidationResult) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *TokenValidationResult) GetClaims() *ValidateTokenData {
+	if x != nil {
+		return x.Claims
+	}
+	return nil
+}
+
+func (x *TokenValidationResult) GetFailureReason() TokenFailureReason {
+	if x != nil {
+		return x.FailureReason
+	}
+	return TokenFailureReason_TOKEN_FAILURE_REASON_UNSPECIFIED
+}
+
+func (x *TokenValidationResult) GetFailureMessage() string {
+	if x != nil {
+		return x.FailureMessage
+	}
+	return ""
+}
+
+type TokenActor struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subject       string                 `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TokenActor) Reset() {
+	*x = TokenActor{}
+	mi := &file_proto_user_service_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TokenActor) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TokenActor) ProtoMessage() {}
+
+func (x *TokenActor) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TokenActor.ProtoReflect.Descriptor instead.
+func (*TokenActor) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *TokenActor) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *TokenActor) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type LogoutRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`                                   // Access token (required)
+	RefreshToken  string                 `protobuf:"bytes,2,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"` // Refresh token (optional but recommended)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogoutRequest) Reset() {
+	*x = LogoutRequest{}
+	mi := &file_proto_user_service_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogoutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogoutRequest) ProtoMessage() {}
+
+func (x *LogoutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogoutRequest.ProtoReflect.Descriptor instead.
+func (*LogoutRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *LogoutRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *LogoutRequest) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+type LogoutResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Data          *LogoutData            `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogoutResponse) Reset() {
+	*x = LogoutResponse{}
+	mi := &file_proto_user_service_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogoutResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogoutResponse) ProtoMessage() {}
+
+func (x *LogoutResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogoutResponse.ProtoReflect.Descriptor instead.
+func (*LogoutResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *LogoutResponse) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *LogoutResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *LogoutResponse) GetData() *LogoutData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type LogoutData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogoutData) Reset() {
+	*x = LogoutData{}
+	mi := &file_proto_user_service_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogoutData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogoutData) ProtoMessage() {}
+
+func (x *LogoutData) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogoutData.ProtoReflect.Descriptor instead.
+func (*LogoutData) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *LogoutData) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type RefreshTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RefreshToken  string                 `protobuf:"bytes,1,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefreshTokenRequest) Reset() {
+	*x = RefreshTokenRequest{}
+	mi := &file_proto_user_service_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshTokenRequest) ProtoMessage() {}
+
+func (x *RefreshTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshTokenRequest.ProtoReflect.Descriptor instead.
+func (*RefreshTokenRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *RefreshTokenRequest) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+type RefreshTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Data          *RefreshTokenData      `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefreshTokenResponse) Reset() {
+	*x = RefreshTokenResponse{}
+	mi := &file_proto_user_service_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshTokenResponse) ProtoMessage() {}
+
+func (x *RefreshTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshTokenResponse.ProtoReflect.Descriptor instead.
+func (*RefreshTokenResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *RefreshTokenResponse) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *RefreshTokenResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RefreshTokenResponse) GetData() *RefreshTokenData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type RefreshTokenData struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken      string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	RefreshToken     string                 `protobuf:"bytes,2,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	TokenType        string                 `protobuf:"bytes,3,opt,name=token_type,json=tokenType,proto3" json:"token_type,omitempty"`                        // "DPoP" or "Bearer"
+	SessionExpiresAt string                 `protobuf:"bytes,4,opt,name=session_expires_at,json=sessionExpiresAt,proto3" json:"session_expires_at,omitempty"` // Absolute session end, unchanged by refreshes (RFC3339, empty = unlimited)
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *RefreshTokenData) Reset() {
+	*x = RefreshTokenData{}
+	mi := &file_proto_user_service_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshTokenData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshTokenData) ProtoMessage() {}
+
+func (x *RefreshTokenData) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshTokenData.ProtoReflect.Descriptor instead.
+func (*RefreshTokenData) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *RefreshTokenData) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *RefreshTokenData) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+func (x *RefreshTokenData) GetTokenType() string {
+	if x != nil {
+		return x.TokenType
+	}
+	return ""
+}
+
+func (x *RefreshTokenData) GetSessionExpiresAt() string {
+	if x != nil {
+		return x.SessionExpiresAt
+	}
+	return ""
+}
+
+type ImpersonateUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TargetUserId  int32                  `protobuf:"varint,1,opt,name=target_user_id,json=targetUserId,proto3" json:"target_user_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"` // Required, written to the audit trail
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImpersonateUserRequest) Reset() {
+	*x = ImpersonateUserRequest{}
+	mi := &file_proto_user_service_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImpersonateUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImpersonateUserRequest) ProtoMessage() {}
+
+func (x *ImpersonateUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImpersonateUserRequest.ProtoReflect.Descriptor instead.
+func (*ImpersonateUserRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ImpersonateUserRequest) GetTargetUserId() int32 {
+	if x != nil {
+		return x.TargetUserId
+	}
+	return 0
+}
+
+func (x *ImpersonateUserRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ImpersonateUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Data          *ImpersonateUserData   `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImpersonateUserResponse) Reset() {
+	*x = ImpersonateUserResponse{}
+	mi := &file_proto_user_service_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImpersonateUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImpersonateUserResponse) ProtoMessage() {}
+
+func (x *ImpersonateUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImpersonateUserResponse.ProtoReflect.Descriptor instead.
+func (*ImpersonateUserResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ImpersonateUserResponse) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *ImpersonateUserResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ImpersonateUserResponse) GetData() *ImpersonateUserData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type ImpersonateUserData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"` // Short-lived, carries an "act" claim naming the admin; no refresh token
+	ExpiresAt     string                 `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	TargetUserId  int32                  `protobuf:"varint,3,opt,name=target_user_id,json=targetUserId,proto3" json:"target_user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImpersonateUserData) Reset() {
+	*x = ImpersonateUserData{}
+	mi := &file_proto_user_service_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImpersonateUserData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImpersonateUserData) ProtoMessage() {}
+
+func (x *ImpersonateUserData) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImpersonateUserData.ProtoReflect.Descriptor instead.
+func (*ImpersonateUserData) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ImpersonateUserData) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *ImpersonateUserData) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+func (x *ImpersonateUserData) GetTargetUserId() int32 {
+	if x != nil {
+		return x.TargetUserId
+	}
+	return 0
+}
+
+type SuspendUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`                        // Required, shown to the user and written to the audit trail
+	ExpiresAt     string                 `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // RFC3339 end of the suspension (empty = until reactivated)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuspendUserRequest) Reset() {
+	*x = SuspendUserRequest{}
+	mi := &file_proto_user_service_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuspendUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuspendUserRequest) ProtoMessage() {}
+
+func (x *SuspendUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuspendUserRequest.ProtoReflect.Descriptor instead.
+func (*SuspendUserRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *SuspendUserRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *SuspendUserRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *SuspendUserRequest) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+type SuspendUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Data          *SuspendUserData       `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuspendUserResponse) Reset() {
+	*x = SuspendUserResponse{}
+	mi := &file_proto_user_service_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuspendUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuspendUserResponse) ProtoMessage() {}
+
+func (x *SuspendUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuspendUserResponse.ProtoReflect.Descriptor instead.
+func (*SuspendUserResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *SuspendUserResponse) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *SuspendUserResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SuspendUserResponse) GetData() *SuspendUserData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type SuspendUserData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuspendUserData) Reset() {
+	*x = SuspendUserData{}
+	mi := &file_proto_user_service_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuspendUserData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuspendUserData) ProtoMessage() {}
+
+func (x *SuspendUserData) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuspendUserData.ProtoReflect.Descriptor instead.
+func (*SuspendUserData) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *SuspendUserData) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type ReactivateUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"` // Optional, written to the audit trail
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReactivateUserRequest) Reset() {
+	*x = ReactivateUserRequest{}
+	mi := &file_proto_user_service_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReactivateUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReactivateUserRequest) ProtoMessage() {}
+
+func (x *ReactivateUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReactivateUserRequest.ProtoReflect.Descriptor instead.
+func (*ReactivateUserRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *ReactivateUserRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ReactivateUserRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ReactivateUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Data          *ReactivateUserData    `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReactivateUserResponse) Reset() {
+	*x = ReactivateUserResponse{}
+	mi := &file_proto_user_service_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReactivateUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReactivateUserResponse) ProtoMessage() {}
+
+func (x *ReactivateUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReactivateUserResponse.ProtoReflect.Descriptor instead.
+func (*ReactivateUserResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *ReactivateUserResponse) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *ReactivateUserResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ReactivateUserResponse) GetData() *ReactivateUserData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type ReactivateUserData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReactivateUserData) Reset() {
+	*x = ReactivateUserData{}
+	mi := &file_proto_user_service_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReactivateUserData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReactivateUserData) ProtoMessage() {}
+
+func (x *ReactivateUserData) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReactivateUserData.ProtoReflect.Descriptor instead.
+func (*ReactivateUserData) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *ReactivateUserData) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type DisableUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"` // Required, shown to the user and written to the audit trail
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisableUserRequest) Reset() {
+	*x = DisableUserRequest{}
+	mi := &file_proto_user_service_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisableUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisableUserRequest) ProtoMessage() {}
+
+func (x *DisableUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisableUserRequest.ProtoReflect.Descriptor instead.
+func (*DisableUserRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *DisableUserRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *DisableUserRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type DisableUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Data          *DisableUserData       `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisableUserResponse) Reset() {
+	*x = DisableUserResponse{}
+	mi := &file_proto_user_service_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisableUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisableUserResponse) ProtoMessage() {}
+
+func (x *DisableUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisableUserResponse.ProtoReflect.Descriptor instead.
+func (*DisableUserResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *DisableUserResponse) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *DisableUserResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *DisableUserResponse) GetData() *DisableUserData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type DisableUserData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisableUserData) Reset() {
+	*x = DisableUserData{}
+	mi := &file_proto_user_service_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisableUserData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisableUserData) ProtoMessage() {}
+
+func (x *DisableUserData) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisableUserData.ProtoReflect.Descriptor instead.
+func (*DisableUserData) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *DisableUserData) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type RequestAccountDeletionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reason        string                 `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"` // Optional feedback from the user
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestAccountDeletionRequest) Reset() {
+	*x = RequestAccountDeletionRequest{}
+	mi := &file_proto_user_service_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestAccountDeletionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestAccountDeletionRequest) ProtoMessage() {}
+
+func (x *RequestAccountDeletionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestAccountDeletionRequest.ProtoReflect.Descriptor instead.
+func (*RequestAccountDeletionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *RequestAccountDeletionRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type RequestAccountDeletionResponse struct {
+	state         protoimpl.MessageState      `protogen:"open.v1"`
+	Code          string                      `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                      `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Data          *RequestAccountDeletionData `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestAccountDeletionResponse) Reset() {
+	*x = RequestAccountDeletionResponse{}
+	mi := &file_proto_user_service_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestAccountDeletionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestAccountDeletionResponse) ProtoMessage() {}
+
+func (x *RequestAccountDeletionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestAccountDeletionResponse.ProtoReflect.Descriptor instead.
+func (*RequestAccountDeletionResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *RequestAccountDeletionResponse) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *RequestAccountDeletionResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RequestAccountDeletionResponse) GetData() *RequestAccountDeletionData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type RequestAccountDeletionData struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	DeletionScheduledAt string                 `protobuf:"bytes,1,opt,name=deletion_scheduled_at,json=deletionScheduledAt,proto3" json:"deletion_scheduled_at,omitempty"` // RFC3339; log in is blocked until then, except to cancel
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *RequestAccountDeletionData) Reset() {
+	*x = RequestAccountDeletionData{}
+	mi := &file_proto_user_service_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestAccountDeletionData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestAccountDeletionData) ProtoMessage() {}
+
+func (x *RequestAccountDeletionData) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestAccountDeletionData.ProtoReflect.Descriptor instead.
+func (*RequestAccountDeletionData) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *RequestAccountDeletionData) GetDeletionScheduledAt() string {
+	if x != nil {
+		return x.DeletionScheduledAt
+	}
+	return ""
+}
+
+// Cancelling uses credentials because all sessions end when deletion is requested
+type CancelAccountDeletionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelAccountDeletionRequest) Reset() {
+	*x = CancelAccountDeletionRequest{}
+	mi := &file_proto_user_service_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelAccountDeletionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelAccountDeletionRequest) ProtoMessage() {}
+
+func (x *CancelAccountDeletionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelAccountDeletionRequest.ProtoReflect.Descriptor instead.
+func (*CancelAccountDeletionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *CancelAccountDeletionRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *CancelAccountDeletionRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type CancelAccountDeletionResponse struct {
+	state         protoimpl.MessageState     `protogen:"open.v1"`
+	Code          string                     `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                     `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Data          *CancelAccountDeletionData `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelAccountDeletionResponse) Reset() {
+	*x = CancelAccountDeletionResponse{}
+	mi := &file_proto_user_service_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelAccountDeletionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelAccountDeletionResponse) ProtoMessage() {}
+
+func (x *CancelAccountDeletionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelAccountDeletionResponse.ProtoReflect.Descriptor instead.
+func (*CancelAccountDeletionResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *CancelAccountDeletionResponse) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *CancelAccountDeletionResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CancelAccountDeletionResponse) GetData() *CancelAccountDeletionData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type CancelAccountDeletionData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelAccountDeletionData) Reset() {
+	*x = CancelAccountDeletionData{}
+	mi := &file_proto_user_service_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelAccountDeletionData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelAccountDeletionData) ProtoMessage() {}
+
+func (x *CancelAccountDeletionData) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelAccountDeletionData.ProtoReflect.Descriptor instead.
+func (*CancelAccountDeletionData) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *CancelAccountDeletionData) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type ExportUserDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportUserDataRequest) Reset() {
+	*x = ExportUserDataRequest{}
+	mi := &file_proto_user_service_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUserDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUserDataRequest) ProtoMessage() {}
+
+func (x *ExportUserDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUserDataRequest.ProtoReflect.Descriptor instead.
+func (*ExportUserDataRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *ExportUserDataRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+// Each message carries a chunk of one archive file; manifest.json is always the last file
+type ExportUserDataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Data          *ExportUserDataChunk   `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportUserDataResponse) Reset() {
+	*x = ExportUserDataResponse{}
+	mi := &file_proto_user_service_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUserDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUserDataResponse) ProtoMessage() {}
+
+func (x *ExportUserDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUserDataResponse.ProtoReflect.Descriptor instead.
+func (*ExportUserDataResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *ExportUserDataResponse) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *ExportUserDataResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ExportUserDataResponse) GetData() *ExportUserDataChunk {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type ExportUserDataChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileName      string                 `protobuf:"bytes,1,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	Content       []byte                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	Sequence      int32                  `protobuf:"varint,3,opt,name=sequence,proto3" json:"sequence,omitempty"`                               // Position of the chunk in the stream, starting at 0
+	LastChunk     bool                   `protobuf:"varint,4,opt,name=last_chunk,json=lastChunk,proto3" json:"last_chunk,omitempty"`            // Last chunk of file_name
+	EndOfArchive  bool                   `protobuf:"varint,5,opt,name=end_of_archive,json=endOfArchive,proto3" json:"end_of_archive,omitempty"` // Last chunk of the export
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportUserDataChunk) Reset() {
+	*x = ExportUserDataChunk{}
+	mi := &file_proto_user_service_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUserDataChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUserDataChunk) ProtoMessage() {}
+
+func (x *ExportUserDataChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUserDataChunk.ProtoReflect.Descriptor instead.
+func (*ExportUserDataChunk) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *ExportUserDataChunk) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *ExportUserDataChunk) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *ExportUserDataChunk) GetSequence() int32 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *ExportUserDataChunk) GetLastChunk() bool {
+	if x != nil {
+		return x.LastChunk
+	}
+	return false
+}
+
+func (x *ExportUserDataChunk) GetEndOfArchive() bool {
+	if x != nil {
+		return x.EndOfArchive
+	}
+	return false
+}
+
+// ExternalIdentity is an account of the user at an external identity provider
+type ExternalIdentity struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Provider      string                 `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"` // Provider name, e.g. "google" or "okta" (case-insensitive)
+	Subject       string                 `protobuf:"bytes,2,opt,name=subject,proto3" json:"subject,omitempty"`   // The provider's stable user identifier ("sub"), matched exactly
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExternalIdentity) Reset() {
+	*x = ExternalIdentity{}
+	mi := &file_proto_user_service_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExternalIdentity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExternalIdentity) ProtoMessage() {}
+
+func (x *ExternalIdentity) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExternalIdentity.ProtoReflect.Descriptor instead.
+func (*ExternalIdentity) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *ExternalIdentity) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *ExternalIdentity) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+type LookupUserRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Key:
+	//
+	//	*LookupUserRequest_Id
+	//	*LookupUserRequest_Email
+	//	*LookupUserRequest_Username
+	//	*LookupUserRequest_ExternalIdentity
+	Key           isLookupUserRequest_Key `protobuf_oneof:"key"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LookupUserRequest) Reset() {
+	*x = LookupUserRequest{}
+	mi := &file_proto_user_service_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LookupUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LookupUserRequest) ProtoMessage() {}
+
+func (x *LookupUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LookupUserRequest.ProtoReflect.Descriptor instead.
+func (*LookupUserRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *LookupUserRequest) GetKey() isLookupUserRequest_Key {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *LookupUserRequest) GetId() int32 {
+	if x != nil {
+		if x, ok := x.Key.(*LookupUserRequest_Id); ok {
+			return x.Id
+		}
+	}
+	return 0
+}
+
+func (x *LookupUserRequest) GetEmail() string {
+	if x != nil {
+		if x, ok := x.Key.(*LookupUserRequest_Email); ok {
+			return x.Email
+		}
+	}
+	return ""
+}
+
+func (x *LookupUserRequest) GetUsername() string {
+	if x != nil {
+		if x, ok := x.Key.(*LookupUserRequest_Username); ok {
+			return x.Username
+		}
+	}
+	return ""
+}
+
+func (x *LookupUserRequest) GetExternalIdentity() *ExternalIdentity {
+	if x != nil {
+		if x, ok := x.Key.(*LookupUserRequest_ExternalIdentity); ok {
+			return x.ExternalIdentity
+		}
+	}
+	return nil
+}
+
+type isLookupUserRequest_Key interface {
+	isLookupUserRequest_Key()
+}
+
+type LookupUserRequest_Id struct {
+	Id int32 `protobuf:"varint,1,opt,name=id,proto3,oneof"`
+}
+
+type LookupUserRequest_Email struct {
+	Email string `protobuf:"bytes,2,opt,name=email,proto3,oneof"` // Matched by normalized email, like Login
+}
+
+type LookupUserRequest_Username struct {
+	Username string `protobuf:"bytes,3,opt,name=username,proto3,oneof"` // Case-insensitive
+}
+
+type LookupUserRequest_ExternalIdentity struct {
+	ExternalIdentity *ExternalIdentity `protobuf:"bytes,4,opt,name=external_identity,json=externalIdentity,proto3,oneof"` // Linked with LinkExternalIdentity
+}
+
+func (*LookupUserRequest_Id) isLookupUserRequest_Key() {}
+
+func (*LookupUserRequest_Email) isLookupUserRequest_Key() {}
+
+func (*LookupUserRequest_Username) isLookupUserRequest_Key() {}
+
+func (*LookupUserRequest_ExternalIdentity) isLookupUserRequest_Key() {}
+
+type LookupUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Data          *LookupUserData        `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LookupUserResponse) Reset() {
+	*x = LookupUserResponse{}
+	mi := &file_proto_user_service_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LookupUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LookupUserResponse) ProtoMessage() {}
+
+func (x *LookupUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LookupUserResponse.ProtoReflect.Descriptor instead.
+func (*LookupUserResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *LookupUserResponse) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *LookupUserResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *LookupUserResponse) GetData() *LookupUserData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type LookupUserData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LookupUserData) Reset() {
+	*x = LookupUserData{}
+	mi := &file_proto_user_service_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LookupUserData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LookupUserData) ProtoMessage() {}
+
+func (x *LookupUserData) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LookupUserData.ProtoReflect.Descriptor instead.
+func (*LookupUserData) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *LookupUserData) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type LinkExternalIdentityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Identity      *ExternalIdentity      `protobuf:"bytes,2,opt,name=identity,proto3" json:"identity,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"` // Optional, written to the audit trail
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkExternalIdentityRequest) Reset() {
+	*x = LinkExternalIdentityRequest{}
+	mi := &file_proto_user_service_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkExternalIdentityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkExternalIdentityRequest) ProtoMessage() {}
+
+func (x *LinkExternalIdentityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkExternalIdentityRequest.ProtoReflect.Descriptor instead.
+func (*LinkExternalIdentityRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *LinkExternalIdentityRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *LinkExternalIdentityRequest) GetIdentity() *ExternalIdentity {
+	if x != nil {
+		return x.Identity
+	}
+	return nil
+}
+
+func (x *LinkExternalIdentityRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type LinkExternalIdentityResponse struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Code          string                    `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                    `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Data          *LinkExternalIdentityData `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkExternalIdentityResponse) Reset() {
+	*x = LinkExternalIdentityResponse{}
+	mi := &file_proto_user_service_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkExternalIdentityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkExternalIdentityResponse) ProtoMessage() {}
+
+func (x *LinkExternalIdentityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkExternalIdentityResponse.ProtoReflect.Descriptor instead.
+func (*LinkExternalIdentityResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *LinkExternalIdentityResponse) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *LinkExternalIdentityResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *LinkExternalIdentityResponse) GetData() *LinkExternalIdentityData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type LinkExternalIdentityData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkExternalIdentityData) Reset() {
+	*x = LinkExternalIdentityData{}
+	mi := &file_proto_user_service_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkExternalIdentityData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkExternalIdentityData) ProtoMessage() {}
+
+func (x *LinkExternalIdentityData) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkExternalIdentityData.ProtoReflect.Descriptor instead.
+func (*LinkExternalIdentityData) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *LinkExternalIdentityData) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type UnlinkExternalIdentityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Identity      *ExternalIdentity      `protobuf:"bytes,2,opt,name=identity,proto3" json:"identity,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"` // Optional, written to the audit trail
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnlinkExternalIdentityRequest) Reset() {
+	*x = UnlinkExternalIdentityRequest{}
+	mi := &file_proto_user_service_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlinkExternalIdentityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlinkExternalIdentityRequest) ProtoMessage() {}
+
+func (x *UnlinkExternalIdentityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlinkExternalIdentityRequest.ProtoReflect.Descriptor instead.
+func (*UnlinkExternalIdentityRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *UnlinkExternalIdentityRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *UnlinkExternalIdentityRequest) GetIdentity() *ExternalIdentity {
+	if x != nil {
+		return x.Identity
+	}
+	return nil
+}
+
+func (x *UnlinkExternalIdentityRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type UnlinkExternalIdentityResponse struct {
+	state         protoimpl.MessageState      `protogen:"open.v1"`
+	Code          string                      `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                      `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Data          *UnlinkExternalIdentityData `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnlinkExternalIdentityResponse) Reset() {
+	*x = UnlinkExternalIdentityResponse{}
+	mi := &file_proto_user_service_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlinkExternalIdentityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlinkExternalIdentityResponse) ProtoMessage() {}
+
+func (x *UnlinkExternalIdentityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlinkExternalIdentityResponse.ProtoReflect.Descriptor instead.
+func (*UnlinkExternalIdentityResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *UnlinkExternalIdentityResponse) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *UnlinkExternalIdentityResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *UnlinkExternalIdentityResponse) GetData() *UnlinkExternalIdentityData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type UnlinkExternalIdentityData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnlinkExternalIdentityData) Reset() {
+	*x = UnlinkExternalIdentityData{}
+	mi := &file_proto_user_service_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlinkExternalIdentityData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlinkExternalIdentityData) ProtoMessage() {}
+
+func (x *UnlinkExternalIdentityData) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlinkExternalIdentityData.ProtoReflect.Descriptor instead.
+func (*UnlinkExternalIdentityData) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *UnlinkExternalIdentityData) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type RestoreUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"` // Optional, written to the audit trail
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreUserRequest) Reset() {
+	*x = RestoreUserRequest{}
+	mi := &file_proto_user_service_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreUserRequest) ProtoMessage() {}
+
+func (x *RestoreUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreUserRequest.ProtoReflect.Descriptor instead.
+func (*RestoreUserRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *RestoreUserRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *RestoreUserRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type RestoreUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Data          *RestoreUserData       `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreUserResponse) Reset() {
+	*x = RestoreUserResponse{}
+	mi := &file_proto_user_service_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreUserResponse) ProtoMessage() {}
+
+func (x *RestoreUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreUserResponse.ProtoReflect.Descriptor instead.
+func (*RestoreUserResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *RestoreUserResponse) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *RestoreUserResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RestoreUserResponse) GetData() *RestoreUserData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type RestoreUserData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreUserData) Reset() {
+	*x = RestoreUserData{}
+	mi := &file_proto_user_service_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreUserData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreUserData) ProtoMessage() {}
+
+func (x *RestoreUserData) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreUserData.ProtoReflect.Descriptor instead.
+func (*RestoreUserData) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *RestoreUserData) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type ListDeletedUsersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeletedUsersRequest) Reset() {
+	*x = ListDeletedUsersRequest{}
+	mi := &file_proto_user_service_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeletedUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeletedUsersRequest) ProtoMessage() {}
+
+func (x *ListDeletedUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeletedUsersRequest.ProtoReflect.Descriptor instead.
+func (*ListDeletedUsersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *ListDeletedUsersRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListDeletedUsersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListDeletedUsersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Data          *ListDeletedUsersData  `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeletedUsersResponse) Reset() {
+	*x = ListDeletedUsersResponse{}
+	mi := &file_proto_user_service_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeletedUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeletedUsersResponse) ProtoMessage() {}
+
+func (x *ListDeletedUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeletedUsersResponse.ProtoReflect.Descriptor instead.
+func (*ListDeletedUsersResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *ListDeletedUsersResponse) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *ListDeletedUsersResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ListDeletedUsersResponse) GetData() *ListDeletedUsersData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type ListDeletedUsersData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*User                `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	Size          int32                  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	HasMore       bool                   `protobuf:"varint,5,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeletedUsersData) Reset() {
+	*x = ListDeletedUsersData{}
+	mi := &file_proto_user_service_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeletedUsersData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeletedUsersData) ProtoMessage() {}
+
+func (x *ListDeletedUsersData) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeletedUsersData.ProtoReflect.Descriptor instead.
+func (*ListDeletedUsersData) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *ListDeletedUsersData) GetUsers() []*User {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *ListDeletedUsersData) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListDeletedUsersData) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListDeletedUsersData) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *ListDeletedUsersData) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+type AnonymizeUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"` // Required, e.g. the erasure request reference; written to the audit trail
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnonymizeUserRequest) Reset() {
+	*x = AnonymizeUserRequest{}
+	mi := &file_proto_user_service_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnonymizeUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnonymizeUserRequest) ProtoMessage() {}
+
+func (x *AnonymizeUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnonymizeUserRequest.ProtoReflect.Descriptor instead.
+func (*AnonymizeUserRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *AnonymizeUserRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *AnonymizeUserRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type AnonymizeUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Data          *AnonymizeUserData     `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnonymizeUserResponse) Reset() {
+	*x = AnonymizeUserResponse{}
+	mi := &file_proto_user_service_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnonymizeUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnonymizeUserResponse) ProtoMessage() {}
+
+func (x *AnonymizeUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnonymizeUserResponse.ProtoReflect.Descriptor instead.
+func (*AnonymizeUserResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *AnonymizeUserResponse) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *AnonymizeUserResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *AnonymizeUserResponse) GetData() *AnonymizeUserData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type AnonymizeUserData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnonymizeUserData) Reset() {
+	*x = AnonymizeUserData{}
+	mi := &file_proto_user_service_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnonymizeUserData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnonymizeUserData) ProtoMessage() {}
+
+func (x *AnonymizeUserData) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnonymizeUserData.ProtoReflect.Descriptor instead.
+func (*AnonymizeUserData) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *AnonymizeUserData) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+// The first message of an UploadAvatar stream carries the metadata, every following one a chunk of the image
+type UploadAvatarRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*UploadAvatarRequest_Metadata
+	//	*UploadAvatarRequest_Chunk
+	Payload       isUploadAvatarRequest_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadAvatarRequest) Reset() {
+	*x = UploadAvatarRequest{}
+	mi := &file_proto_user_service_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadAvatarRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadAvatarRequest) ProtoMessage() {}
+
+func (x *UploadAvatarRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadAvatarRequest.ProtoReflect.Descriptor instead.
+func (*UploadAvatarRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *UploadAvatarRequest) GetPayload() isUploadAvatarRequest_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *UploadAvatarRequest) GetMetadata() *AvatarMetadata {
+	if x != nil {
+		if x, ok := x.Payload.(*UploadAvatarRequest_Metadata); ok {
+			return x.Metadata
+		}
+	}
+	return nil
+}
+
+func (x *UploadAvatarRequest) GetChunk() []byte {
+	if x != nil {
+		if x, ok := x.Payload.(*UploadAvatarRequest_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+type isUploadAvatarRequest_Payload interface {
+	isUploadAvatarRequest_Payload()
+}
+
+type UploadAvatarRequest_Metadata struct {
+	Metadata *AvatarMetadata `protobuf:"bytes,1,opt,name=metadata,proto3,oneof"`
+}
+
+type UploadAvatarRequest_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*UploadAvatarRequest_Metadata) isUploadAvatarRequest_Payload() {}
+
+func (*UploadAvatarRequest_Chunk) isUploadAvatarRequest_Payload() {}
+
+type AvatarMetadata struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"` // image/jpeg, image/png, image/gif or image/webp; must match the uploaded bytes
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AvatarMetadata) Reset() {
+	*x = AvatarMetadata{}
+	mi := &file_proto_user_service_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AvatarMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AvatarMetadata) ProtoMessage() {}
+
+func (x *AvatarMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AvatarMetadata.ProtoReflect.Descriptor instead.
+func (*AvatarMetadata) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *AvatarMetadata) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *AvatarMetadata) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+type UploadAvatarResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Data          *UploadAvatarData      `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadAvatarResponse) Reset() {
+	*x = UploadAvatarResponse{}
+	mi := &file_proto_user_service_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadAvatarResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadAvatarResponse) ProtoMessage() {}
+
+func (x *UploadAvatarResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadAvatarResponse.ProtoReflect.Descriptor instead.
+func (*UploadAvatarResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *UploadAvatarResponse) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *UploadAvatarResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *UploadAvatarResponse) GetData() *UploadAvatarData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type UploadAvatarData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	Renditions    []*AvatarRendition     `protobuf:"bytes,2,rep,name=renditions,proto3" json:"renditions,omitempty"` // Largest first; the first one is User.avatar_url
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadAvatarData) Reset() {
+	*x = UploadAvatarData{}
+	mi := &file_proto_user_service_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadAvatarData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadAvatarData) ProtoMessage() {}
+
+func (x *UploadAvatarData) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadAvatarData.ProtoReflect.Descriptor instead.
+func (*UploadAvatarData) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *UploadAvatarData) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *UploadAvatarData) GetRenditions() []*AvatarRendition {
+	if x != nil {
+		return x.Renditions
+	}
+	return nil
+}
+
+type AvatarRendition struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Size          int32                  `protobuf:"varint,1,opt,name=size,proto3" json:"size,omitempty"` // Width and height in pixels (smaller if the upload was smaller)
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AvatarRendition) Reset() {
+	*x = AvatarRendition{}
+	mi := &file_proto_user_service_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AvatarRendition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AvatarRendition) ProtoMessage() {}
+
+func (x *AvatarRendition) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AvatarRendition.ProtoReflect.Descriptor instead.
+func (*AvatarRendition) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *AvatarRendition) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *AvatarRendition) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+type DeleteAvatarRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAvatarRequest) Reset() {
+	*x = DeleteAvatarRequest{}
+	mi := &file_proto_user_service_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAvatarRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAvatarRequest) ProtoMessage() {}
+
+func (x *DeleteAvatarRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAvatarRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAvatarRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *DeleteAvatarRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type DeleteAvatarResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Data          *DeleteAvatarData      `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAvatarResponse) Reset() {
+	*x = DeleteAvatarResponse{}
+	mi := &file_proto_user_service_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAvatarResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAvatarResponse) ProtoMessage() {}
+
+func (x *DeleteAvatarResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAvatarResponse.ProtoReflect.Descriptor instead.
+func (*DeleteAvatarResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *DeleteAvatarResponse) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *DeleteAvatarResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *DeleteAvatarResponse) GetData() *DeleteAvatarData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type DeleteAvatarData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAvatarData) Reset() {
+	*x = DeleteAvatarData{}
+	mi := &file_proto_user_service_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAvatarData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAvatarData) ProtoMessage() {}
+
+func (x *DeleteAvatarData) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[89]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAvatarData.ProtoReflect.Descriptor instead.
+func (*DeleteAvatarData) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *DeleteAvatarData) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+// Caller identified by "authorization: Bearer <access token>" metadata
+type RequestEmailChangeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NewEmail      string                 `protobuf:"bytes,1,opt,name=new_email,json=newEmail,proto3" json:"new_email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestEmailChangeRequest) Reset() {
+	*x = RequestEmailChangeRequest{}
+	mi := &file_proto_user_service_proto_msgTypes[90]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestEmailChangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestEmailChangeRequest) ProtoMessage() {}
+
+func (x *RequestEmailChangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[90]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestEmailChangeRequest.ProtoReflect.Descriptor instead.
+func (*RequestEmailChangeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{90}
+}
+
+func (x *RequestEmailChangeRequest) GetNewEmail() string {
+	if x != nil {
+		return x.NewEmail
+	}
+	return ""
+}
+
+type RequestEmailChangeResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Code          string                  `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                  `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Data          *RequestEmailChangeData `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestEmailChangeResponse) Reset() {
+	*x = RequestEmailChangeResponse{}
+	mi := &file_proto_user_service_proto_msgTypes[91]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestEmailChangeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestEmailChangeResponse) ProtoMessage() {}
+
+func (x *RequestEmailChangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[91]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestEmailChangeResponse.ProtoReflect.Descriptor instead.
+func (*RequestEmailChangeResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{91}
+}
+
+func (x *RequestEmailChangeResponse) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *RequestEmailChangeResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RequestEmailChangeResponse) GetData() *RequestEmailChangeData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type RequestEmailChangeData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PendingEmail  string                 `protobuf:"bytes,1,opt,name=pending_email,json=pendingEmail,proto3" json:"pending_email,omitempty"`
+	ExpiresAt     string                 `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // The confirmation token is valid until this time (RFC3339)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestEmailChangeData) Reset() {
+	*x = RequestEmailChangeData{}
+	mi := &file_proto_user_service_proto_msgTypes[92]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestEmailChangeData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestEmailChangeData) ProtoMessage() {}
+
+func (x *RequestEmailChangeData) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[92]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestEmailChangeData.ProtoReflect.Descriptor instead.
+func (*RequestEmailChangeData) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{92}
+}
+
+func (x *RequestEmailChangeData) GetPendingEmail() string {
+	if x != nil {
+		return x.PendingEmail
+	}
+	return ""
+}
+
+func (x *RequestEmailChangeData) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+type ConfirmEmailChangeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // Token sent to the new email address
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmEmailChangeRequest) Reset() {
+	*x = ConfirmEmailChangeRequest{}
+	mi := &file_proto_user_service_proto_msgTypes[93]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmEmailChangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmEmailChangeRequest) ProtoMessage() {}
+
+func (x *ConfirmEmailChangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[93]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmEmailChangeRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmEmailChangeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{93}
+}
+
+func (x *ConfirmEmailChangeRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type ConfirmEmailChangeResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Code          string                  `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                  `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Data          *ConfirmEmailChangeData `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmEmailChangeResponse) Reset() {
+	*x = ConfirmEmailChangeResponse{}
+	mi := &file_proto_user_service_proto_msgTypes[94]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmEmailChangeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmEmailChangeResponse) ProtoMessage() {}
+
+func (x *ConfirmEmailChangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[94]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmEmailChangeResponse.ProtoReflect.Descriptor instead.
+func (*ConfirmEmailChangeResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{94}
+}
+
+func (x *ConfirmEmailChangeResponse) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *ConfirmEmailChangeResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ConfirmEmailChangeResponse) GetData() *ConfirmEmailChangeData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type ConfirmEmailChangeData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmEmailChangeData) Reset() {
+	*x = ConfirmEmailChangeData{}
+	mi := &file_proto_user_service_proto_msgTypes[95]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmEmailChangeData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmEmailChangeData) ProtoMessage() {}
+
+func (x *ConfirmEmailChangeData) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[95]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmEmailChangeData.ProtoReflect.Descriptor instead.
+func (*ConfirmEmailChangeData) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{95}
+}
+
+func (x *ConfirmEmailChangeData) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type AttributeSchema struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       int32                  `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	Schema        *structpb.Struct       `protobuf:"bytes,2,opt,name=schema,proto3" json:"schema,omitempty"` // JSON Schema (draft 2020-12) for User.attributes
+	CreatedBy     int32                  `protobuf:"varint,3,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttributeSchema) Reset() {
+	*x = AttributeSchema{}
+	mi := &file_proto_user_service_proto_msgTypes[96]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttributeSchema) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttributeSchema) ProtoMessage() {}
+
+func (x *AttributeSchema) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[96]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttributeSchema.ProtoReflect.Descriptor instead.
+func (*AttributeSchema) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{96}
+}
+
+func (x *AttributeSchema) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *AttributeSchema) GetSchema() *structpb.Struct {
+	if x != nil {
+		return x.Schema
+	}
+	return nil
+}
+
+func (x *AttributeSchema) GetCreatedBy() int32 {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return 0
+}
+
+func (x *AttributeSchema) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type GetAttributeSchemaRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAttributeSchemaRequest) Reset() {
+	*x = GetAttributeSchemaRequest{}
+	mi := &file_proto_user_service_proto_msgTypes[97]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAttributeSchemaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAttributeSchemaRequest) ProtoMessage() {}
+
+func (x *GetAttributeSchemaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[97]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAttributeSchemaRequest.ProtoReflect.Descriptor instead.
+func (*GetAttributeSchemaRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{97}
+}
+
+type GetAttributeSchemaResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Code          string                  `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                  `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Data          *GetAttributeSchemaData `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAttributeSchemaResponse) Reset() {
+	*x = GetAttributeSchemaResponse{}
+	mi := &file_proto_user_service_proto_msgTypes[98]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAttributeSchemaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAttributeSchemaResponse) ProtoMessage() {}
+
+func (x *GetAttributeSchemaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[98]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAttributeSchemaResponse.ProtoReflect.Descriptor instead.
+func (*GetAttributeSchemaResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{98}
+}
+
+func (x *GetAttributeSchemaResponse) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *GetAttributeSchemaResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GetAttributeSchemaResponse) GetData() *GetAttributeSchemaData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type GetAttributeSchemaData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Schema        *AttributeSchema       `protobuf:"bytes,1,opt,name=schema,proto3" json:"schema,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAttributeSchemaData) Reset() {
+	*x = GetAttributeSchemaData{}
+	mi := &file_proto_user_service_proto_msgTypes[99]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAttributeSchemaData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAttributeSchemaData) ProtoMessage() {}
+
+func (x *GetAttributeSchemaData) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[99]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAttributeSchemaData.ProtoReflect.Descriptor instead.
+func (*GetAttributeSchemaData) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{99}
+}
+
+func (x *GetAttributeSchemaData) GetSchema() *AttributeSchema {
+	if x != nil {
+		return x.Schema
+	}
+	return nil
+}
+
+type SetAttributeSchemaRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Schema        *structpb.Struct       `protobuf:"bytes,1,opt,name=schema,proto3" json:"schema,omitempty"` // Becomes the current schema; existing attributes are not revalidated
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetAttributeSchemaRequest) Reset() {
+	*x = SetAttributeSchemaRequest{}
+	mi := &file_proto_user_service_proto_msgTypes[100]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetAttributeSchemaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetAttributeSchemaRequest) ProtoMessage() {}
+
+func (x *SetAttributeSchemaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[100]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetAttributeSchemaRequest.ProtoReflect.Descriptor instead.
+func (*SetAttributeSchemaRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{100}
+}
+
+func (x *SetAttributeSchemaRequest) GetSchema() *structpb.Struct {
+	if x != nil {
+		return x.Schema
+	}
+	return nil
+}
+
+type SetAttributeSchemaResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Code          string                  `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                  `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Data          *SetAttributeSchemaData `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetAttributeSchemaResponse) Reset() {
+	*x = SetAttributeSchemaResponse{}
+	mi := &file_proto_user_service_proto_msgTypes[101]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetAttributeSchemaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetAttributeSchemaResponse) ProtoMessage() {}
+
+func (x *SetAttributeSchemaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[101]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetAttributeSchemaResponse.ProtoReflect.Descriptor instead.
+func (*SetAttributeSchemaResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{101}
+}
+
+func (x *SetAttributeSchemaResponse) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *SetAttributeSchemaResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SetAttributeSchemaResponse) GetData() *SetAttributeSchemaData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type SetAttributeSchemaData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Schema        *AttributeSchema       `protobuf:"bytes,1,opt,name=schema,proto3" json:"schema,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetAttributeSchemaData) Reset() {
+	*x = SetAttributeSchemaData{}
+	mi := &file_proto_user_service_proto_msgTypes[102]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetAttributeSchemaData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetAttributeSchemaData) ProtoMessage() {}
+
+func (x *SetAttributeSchemaData) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[102]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetAttributeSchemaData.ProtoReflect.Descriptor instead.
+func (*SetAttributeSchemaData) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{102}
+}
+
+func (x *SetAttributeSchemaData) GetSchema() *AttributeSchema {
+	if x != nil {
+		return x.Schema
+	}
+	return nil
+}
+
+type ExchangeTokenRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	SubjectToken string                 `protobuf:"bytes,1,opt,name=subject_token,json=subjectToken,proto3" json:"subject_token,omitempty"` // Access token of the user being acted for
+	Audience     string                 `protobuf:"bytes,2,opt,name=audience,proto3" json:"audience,omitempty"`                             // Downstream service the new token is meant for
+	Scopes       []string               `protobuf:"bytes,3,rep,name=scopes,proto3" json:"scopes,omitempty"`                                 // Must be a subset of the subject token's scopes
+	ExpiresIn    int32                  `protobuf:"varint,4,opt,name=expires_in,json=expiresIn,proto3" json:"expires_in,omitempty"`         // Requested lifetime in seconds (optional, capped by policy and subject token)
+	// Deprecated: Marked as deprecated in proto/user_service.proto.
+	Actor         string `protobuf:"bytes,5,opt,name=actor,proto3" json:"actor,omitempty"` // Must be empty: the actor is the service authenticated by x-service-id/x-service-secret
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExchangeTokenRequest) Reset() {
+	*x = ExchangeTokenRequest{}
+	mi := &file_proto_user_service_proto_msgTypes[103]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExchangeTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExchangeTokenRequest) ProtoMessage() {}
+
+func (x *ExchangeTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[103]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExchangeTokenRequest.ProtoReflect.Descriptor instead.
+func (*ExchangeTokenRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{103}
+}
+
+func (x *ExchangeTokenRequest) GetSubjectToken() string {
+	if x != nil {
+		return x.SubjectToken
+	}
+	return ""
+}
+
+func (x *ExchangeTokenRequest) GetAudience() string {
+	if x != nil {
+		return x.Audience
+	}
+	return ""
+}
+
+func (x *ExchangeTokenRequest) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *ExchangeTokenRequest) GetExpiresIn() int32 {
+	if x != nil {
+		return x.ExpiresIn
+	}
+	return 0
+}
+
+// Deprecated: Marked as deprecated in proto/user_service.proto.
+func (x *ExchangeTokenRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+type ExchangeTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Data          *ExchangeTokenData     `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExchangeTokenResponse) Reset() {
+	*x = ExchangeTokenResponse{}
+	mi := &file_proto_user_service_proto_msgTypes[104]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExchangeTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExchangeTokenResponse) ProtoMessage() {}
+
+func (x *ExchangeTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[104]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExchangeTokenResponse.ProtoReflect.Descriptor instead.
+func (*ExchangeTokenResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{104}
+}
+
+func (x *ExchangeTokenResponse) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *ExchangeTokenResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ExchangeTokenResponse) GetData() *ExchangeTokenData {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type ExchangeTokenData struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken     string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	IssuedTokenType string                 `protobuf:"bytes,2,opt,name=issued_token_type,json=issuedTokenType,proto3" json:"issued_token_type,omitempty"` // urn:ietf:params:oauth:token-type:access_token
+	TokenType       string                 `protobuf:"bytes,3,opt,name=token_type,json=tokenType,proto3" json:"token_type,omitempty"`                     // Always "Bearer"
+	ExpiresIn       int32                  `protobuf:"varint,4,opt,name=expires_in,json=expiresIn,proto3" json:"expires_in,omitempty"`
+	Audience        string                 `protobuf:"bytes,5,opt,name=audience,proto3" json:"audience,omitempty"`
+	Scopes          []string               `protobuf:"bytes,6,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ExchangeTokenData) Reset() {
+	*x = ExchangeTokenData{}
+	mi := &file_proto_user_service_proto_msgTypes[105]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExchangeTokenData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExchangeTokenData) ProtoMessage() {}
+
+func (x *ExchangeTokenData) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[105]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExchangeTokenData.ProtoReflect.Descriptor instead.
+func (*ExchangeTokenData) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{105}
+}
+
+func (x *ExchangeTokenData) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *ExchangeTokenData) GetIssuedTokenType() string {
+	if x != nil {
+		return x.IssuedTokenType
+	}
+	return ""
+}
+
+func (x *ExchangeTokenData) GetTokenType() string {
+	if x != nil {
+		return x.TokenType
+	}
+	return ""
+}
+
+func (x *ExchangeTokenData) GetExpiresIn() int32 {
+	if x != nil {
+		return x.ExpiresIn
+	}
+	return 0
+}
+
+func (x *ExchangeTokenData) GetAudience() string {
 	if x != nil {
-		return x.Token
+		return x.Audience
 	}
 	return ""
 }
 
-func (x *LogoutRequest) GetRefreshToken() string {
+func (x *ExchangeTokenData) GetScopes() []string {
 	if x != nil {
-		return x.RefreshToken
+		return x.Scopes
 	}
-	return ""
+	return nil
 }
 
-type LogoutResponse struct {
+type LoginEvent struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Data          *LogoutData            `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	EventType     string                 `protobuf:"bytes,3,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"` // login | refresh | new_device
+	Outcome       string                 `protobuf:"bytes,4,opt,name=outcome,proto3" json:"outcome,omitempty"`                      // success | failure
+	FailureReason string                 `protobuf:"bytes,5,opt,name=failure_reason,json=failureReason,proto3" json:"failure_reason,omitempty"`
+	IpAddress     string                 `protobuf:"bytes,6,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
+	UserAgent     string                 `protobuf:"bytes,7,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *LogoutResponse) Reset() {
-	*x = LogoutResponse{}
-	mi := &file_proto_user_service_proto_msgTypes[23]
+func (x *LoginEvent) Reset() {
+	*x = LoginEvent{}
+	mi := &file_proto_user_service_proto_msgTypes[106]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LogoutResponse) String() string {
+func (x *LoginEvent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LogoutResponse) ProtoMessage() {}
+func (*LoginEvent) ProtoMessage() {}
 
-func (x *LogoutResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_user_service_proto_msgTypes[23]
+func (x *LoginEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[106]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1331,98 +6468,91 @@ func (x *LogoutResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LogoutResponse.ProtoReflect.Descriptor instead.
-func (*LogoutResponse) Descriptor() ([]byte, []int) {
-	return file_proto_user_service_proto_rawDescGZIP(), []int{23}
+// Deprecated: Use LoginEvent.ProtoReflect.Descriptor instead.
+func (*LoginEvent) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{106}
 }
 
-func (x *LogoutResponse) GetCode() string {
+func (x *LoginEvent) GetId() int64 {
 	if x != nil {
-		return x.Code
+		return x.Id
 	}
-	return ""
+	return 0
 }
 
-func (x *LogoutResponse) GetMessage() string {
+func (x *LoginEvent) GetUserId() int32 {
 	if x != nil {
-		return x.Message
+		return x.UserId
 	}
-	return ""
+	return 0
 }
 
-func (x *LogoutResponse) GetData() *LogoutData {
+func (x *LoginEvent) GetEventType() string {
 	if x != nil {
-		return x.Data
+		return x.EventType
 	}
-	return nil
-}
-
-type LogoutData struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	return ""
 }
 
-func (x *LogoutData) Reset() {
-	*x = LogoutData{}
-	mi := &file_proto_user_service_proto_msgTypes[24]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *LoginEvent) GetOutcome() string {
+	if x != nil {
+		return x.Outcome
+	}
+	return ""
 }
 
-func (x *LogoutData) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *LoginEvent) GetFailureReason() string {
+	if x != nil {
+		return x.FailureReason
+	}
+	return ""
 }
 
-func (*LogoutData) ProtoMessage() {}
-
-func (x *LogoutData) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_user_service_proto_msgTypes[24]
+func (x *LoginEvent) GetIpAddress() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.IpAddress
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use LogoutData.ProtoReflect.Descriptor instead.
-func (*LogoutData) Descriptor() ([]byte, []int) {
-	return file_proto_user_service_proto_rawDescGZIP(), []int{24}
+func (x *LoginEvent) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
 }
 
-func (x *LogoutData) GetSuccess() bool {
+func (x *LoginEvent) GetCreatedAt() string {
 	if x != nil {
-		return x.Success
+		return x.CreatedAt
 	}
-	return false
+	return ""
 }
 
-type RefreshTokenRequest struct {
+type ListLoginEventsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	RefreshToken  string                 `protobuf:"bytes,1,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RefreshTokenRequest) Reset() {
-	*x = RefreshTokenRequest{}
-	mi := &file_proto_user_service_proto_msgTypes[25]
+func (x *ListLoginEventsRequest) Reset() {
+	*x = ListLoginEventsRequest{}
+	mi := &file_proto_user_service_proto_msgTypes[107]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RefreshTokenRequest) String() string {
+func (x *ListLoginEventsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RefreshTokenRequest) ProtoMessage() {}
+func (*ListLoginEventsRequest) ProtoMessage() {}
 
-func (x *RefreshTokenRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_user_service_proto_msgTypes[25]
+func (x *ListLoginEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[107]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1433,42 +6563,56 @@ func (x *RefreshTokenRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RefreshTokenRequest.ProtoReflect.Descriptor instead.
-func (*RefreshTokenRequest) Descriptor() ([]byte, []int) {
-	return file_proto_user_service_proto_rawDescGZIP(), []int{25}
+// Deprecated: Use ListLoginEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListLoginEventsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{107}
 }
 
-func (x *RefreshTokenRequest) GetRefreshToken() string {
+func (x *ListLoginEventsRequest) GetUserId() int32 {
 	if x != nil {
-		return x.RefreshToken
+		return x.UserId
 	}
-	return ""
+	return 0
 }
 
-type RefreshTokenResponse struct {
+func (x *ListLoginEventsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListLoginEventsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListLoginEventsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
 	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Data          *RefreshTokenData      `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	Data          *ListLoginEventsData   `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RefreshTokenResponse) Reset() {
-	*x = RefreshTokenResponse{}
-	mi := &file_proto_user_service_proto_msgTypes[26]
+func (x *ListLoginEventsResponse) Reset() {
+	*x = ListLoginEventsResponse{}
+	mi := &file_proto_user_service_proto_msgTypes[108]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RefreshTokenResponse) String() string {
+func (x *ListLoginEventsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RefreshTokenResponse) ProtoMessage() {}
+func (*ListLoginEventsResponse) ProtoMessage() {}
 
-func (x *RefreshTokenResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_user_service_proto_msgTypes[26]
+func (x *ListLoginEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[108]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1479,55 +6623,58 @@ func (x *RefreshTokenResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RefreshTokenResponse.ProtoReflect.Descriptor instead.
-func (*RefreshTokenResponse) Descriptor() ([]byte, []int) {
-	return file_proto_user_service_proto_rawDescGZIP(), []int{26}
+// Deprecated: Use ListLoginEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListLoginEventsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{108}
 }
 
-func (x *RefreshTokenResponse) GetCode() string {
+func (x *ListLoginEventsResponse) GetCode() string {
 	if x != nil {
 		return x.Code
 	}
 	return ""
 }
 
-func (x *RefreshTokenResponse) GetMessage() string {
+func (x *ListLoginEventsResponse) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-func (x *RefreshTokenResponse) GetData() *RefreshTokenData {
+func (x *ListLoginEventsResponse) GetData() *ListLoginEventsData {
 	if x != nil {
 		return x.Data
 	}
 	return nil
 }
 
-type RefreshTokenData struct {
+type ListLoginEventsData struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
-	RefreshToken  string                 `protobuf:"bytes,2,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	Events        []*LoginEvent          `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	Size          int32                  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	HasMore       bool                   `protobuf:"varint,5,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RefreshTokenData) Reset() {
-	*x = RefreshTokenData{}
-	mi := &file_proto_user_service_proto_msgTypes[27]
+func (x *ListLoginEventsData) Reset() {
+	*x = ListLoginEventsData{}
+	mi := &file_proto_user_service_proto_msgTypes[109]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RefreshTokenData) String() string {
+func (x *ListLoginEventsData) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RefreshTokenData) ProtoMessage() {}
+func (*ListLoginEventsData) ProtoMessage() {}
 
-func (x *RefreshTokenData) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_user_service_proto_msgTypes[27]
+func (x *ListLoginEventsData) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_proto_msgTypes[109]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1538,30 +6685,51 @@ func (x *RefreshTokenData) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RefreshTokenData.ProtoReflect.Descriptor instead.
-func (*RefreshTokenData) Descriptor() ([]byte, []int) {
-	return file_proto_user_service_proto_rawDescGZIP(), []int{27}
+// Deprecated: Use ListLoginEventsData.ProtoReflect.Descriptor instead.
+func (*ListLoginEventsData) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_proto_rawDescGZIP(), []int{109}
 }
 
-func (x *RefreshTokenData) GetAccessToken() string {
+func (x *ListLoginEventsData) GetEvents() []*LoginEvent {
 	if x != nil {
-		return x.AccessToken
+		return x.Events
 	}
-	return ""
+	return nil
 }
 
-func (x *RefreshTokenData) GetRefreshToken() string {
+func (x *ListLoginEventsData) GetTotal() int64 {
 	if x != nil {
-		return x.RefreshToken
+		return x.Total
 	}
-	return ""
+	return 0
+}
+
+func (x *ListLoginEventsData) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListLoginEventsData) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *ListLoginEventsData) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
 }
 
 var File_proto_user_service_proto protoreflect.FileDescriptor
 
 const file_proto_user_service_proto_rawDesc = "" +
 	"\n" +
-	"\x18proto/user_service.proto\x12\x04user\"~\n" +
+	"\x18proto/user_service.proto\x12\x04user\x1a google/protobuf/field_mask.proto\x1a\x1cgoogle/protobuf/struct.proto\"\xbf\x04\n" +
 	"\x04User\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
@@ -1569,11 +6737,29 @@ const file_proto_user_service_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x04 \x01(\tR\tcreatedAt\x12\x1d\n" +
 	"\n" +
-	"updated_at\x18\x05 \x01(\tR\tupdatedAt\"Y\n" +
+	"updated_at\x18\x05 \x01(\tR\tupdatedAt\x12\x12\n" +
+	"\x04role\x18\x06 \x01(\tR\x04role\x12\"\n" +
+	"\rlast_login_at\x18\a \x01(\tR\vlastLoginAt\x12(\n" +
+	"\x06status\x18\b \x01(\x0e2\x10.user.UserStatusR\x06status\x12#\n" +
+	"\rstatus_reason\x18\t \x01(\tR\fstatusReason\x12'\n" +
+	"\x0fsuspended_until\x18\n" +
+	" \x01(\tR\x0esuspendedUntil\x12\x1d\n" +
+	"\n" +
+	"deleted_at\x18\v \x01(\tR\tdeletedAt\x122\n" +
+	"\x15deletion_scheduled_at\x18\f \x01(\tR\x13deletionScheduledAt\x12#\n" +
+	"\ranonymized_at\x18\r \x01(\tR\fanonymizedAt\x127\n" +
+	"\n" +
+	"attributes\x18\x0e \x01(\v2\x17.google.protobuf.StructR\n" +
+	"attributes\x12\x1d\n" +
+	"\n" +
+	"avatar_url\x18\x0f \x01(\tR\tavatarUrl\x12#\n" +
+	"\rpending_email\x18\x10 \x01(\tR\fpendingEmail\x12\x1a\n" +
+	"\busername\x18\x11 \x01(\tR\busername\"u\n" +
 	"\x11CreateUserRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
 	"\x05email\x18\x02 \x01(\tR\x05email\x12\x1a\n" +
-	"\bpassword\x18\x03 \x01(\tR\bpassword\"l\n" +
+	"\bpassword\x18\x03 \x01(\tR\bpassword\x12\x1a\n" +
+	"\busername\x18\x04 \x01(\tR\busername\"l\n" +
 	"\x12CreateUserResponse\x12\x12\n" +
 	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12(\n" +
@@ -1589,15 +6775,55 @@ const file_proto_user_service_proto_rawDesc = "" +
 	"\x04data\x18\x03 \x01(\v2\x11.user.GetUserDataR\x04data\"-\n" +
 	"\vGetUserData\x12\x1e\n" +
 	"\x04user\x18\x01 \x01(\v2\n" +
-	".user.UserR\x04user\"\x98\x01\n" +
+	".user.UserR\x04user\"c\n" +
+	"\x14BatchGetUsersRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\x05R\x03ids\x129\n" +
+	"\n" +
+	"field_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskR\tfieldMask\"r\n" +
+	"\x15BatchGetUsersResponse\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12+\n" +
+	"\x04data\x18\x03 \x01(\v2\x17.user.BatchGetUsersDataR\x04data\"V\n" +
+	"\x11BatchGetUsersData\x12 \n" +
+	"\x05users\x18\x01 \x03(\v2\n" +
+	".user.UserR\x05users\x12\x1f\n" +
+	"\vmissing_ids\x18\x02 \x03(\x05R\n" +
+	"missingIds\"6\n" +
+	"\x18GetUserByUsernameRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\"z\n" +
+	"\x19GetUserByUsernameResponse\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12/\n" +
+	"\x04data\x18\x03 \x01(\v2\x1b.user.GetUserByUsernameDataR\x04data\"7\n" +
+	"\x15GetUserByUsernameData\x12\x1e\n" +
+	"\x04user\x18\x01 \x01(\v2\n" +
+	".user.UserR\x04user\"^\n" +
+	"\x18CheckAvailabilityRequest\x12\x16\n" +
+	"\x05email\x18\x01 \x01(\tH\x00R\x05email\x12\x1c\n" +
+	"\busername\x18\x02 \x01(\tH\x00R\busernameB\f\n" +
+	"\n" +
+	"identifier\"z\n" +
+	"\x19CheckAvailabilityResponse\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12/\n" +
+	"\x04data\x18\x03 \x01(\v2\x1b.user.CheckAvailabilityDataR\x04data\"e\n" +
+	"\x15CheckAvailabilityData\x12\x1c\n" +
+	"\tavailable\x18\x01 \x01(\bR\tavailable\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12\x16\n" +
+	"\x06detail\x18\x03 \x01(\tR\x06detail\"\xff\x01\n" +
 	"\x11UpdateUserRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x17\n" +
 	"\x04name\x18\x02 \x01(\tH\x00R\x04name\x88\x01\x01\x12\x19\n" +
 	"\x05email\x18\x03 \x01(\tH\x01R\x05email\x88\x01\x01\x12\x1f\n" +
-	"\bpassword\x18\x04 \x01(\tH\x02R\bpassword\x88\x01\x01B\a\n" +
+	"\bpassword\x18\x04 \x01(\tH\x02R\bpassword\x88\x01\x01\x127\n" +
+	"\n" +
+	"attributes\x18\x05 \x01(\v2\x17.google.protobuf.StructR\n" +
+	"attributes\x12\x1f\n" +
+	"\busername\x18\x06 \x01(\tH\x03R\busername\x88\x01\x01B\a\n" +
 	"\x05_nameB\b\n" +
 	"\x06_emailB\v\n" +
-	"\t_password\"l\n" +
+	"\t_passwordB\v\n" +
+	"\t_username\"l\n" +
 	"\x12UpdateUserResponse\x12\x12\n" +
 	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12(\n" +
@@ -1612,41 +6838,104 @@ const file_proto_user_service_proto_rawDesc = "" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12(\n" +
 	"\x04data\x18\x03 \x01(\v2\x14.user.DeleteUserDataR\x04data\"*\n" +
 	"\x0eDeleteUserData\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\"C\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xf2\x03\n" +
 	"\x10ListUsersRequest\x12\x12\n" +
 	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x1b\n" +
-	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\"j\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x127\n" +
+	"\n" +
+	"attributes\x18\x03 \x01(\v2\x17.google.protobuf.StructR\n" +
+	"attributes\x12\x12\n" +
+	"\x04name\x18\x04 \x01(\tR\x04name\x12\x14\n" +
+	"\x05email\x18\x05 \x01(\tR\x05email\x12#\n" +
+	"\rcreated_after\x18\x06 \x01(\tR\fcreatedAfter\x12%\n" +
+	"\x0ecreated_before\x18\a \x01(\tR\rcreatedBefore\x12#\n" +
+	"\rupdated_after\x18\b \x01(\tR\fupdatedAfter\x12%\n" +
+	"\x0eupdated_before\x18\t \x01(\tR\rupdatedBefore\x12(\n" +
+	"\x06status\x18\n" +
+	" \x01(\x0e2\x10.user.UserStatusR\x06status\x12\x12\n" +
+	"\x04role\x18\v \x01(\tR\x04role\x12\x19\n" +
+	"\border_by\x18\f \x01(\tR\aorderBy\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\r \x01(\tR\tpageToken\x12(\n" +
+	"\rinclude_total\x18\x0e \x01(\bH\x00R\fincludeTotal\x88\x01\x01B\x10\n" +
+	"\x0e_include_total\"j\n" +
 	"\x11ListUsersResponse\x12\x12\n" +
 	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12'\n" +
-	"\x04data\x18\x03 \x01(\v2\x13.user.ListUsersDataR\x04data\"\x8a\x01\n" +
+	"\x04data\x18\x03 \x01(\v2\x13.user.ListUsersDataR\x04data\"\xdb\x01\n" +
 	"\rListUsersData\x12 \n" +
 	"\x05users\x18\x01 \x03(\v2\n" +
 	".user.UserR\x05users\x12\x14\n" +
 	"\x05total\x18\x02 \x01(\x03R\x05total\x12\x12\n" +
 	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x12\n" +
 	"\x04size\x18\x04 \x01(\x05R\x04size\x12\x19\n" +
-	"\bhas_more\x18\x05 \x01(\bR\ahasMore\"@\n" +
+	"\bhas_more\x18\x05 \x01(\bR\ahasMore\x12&\n" +
+	"\x0fnext_page_token\x18\x06 \x01(\tR\rnextPageToken\x12'\n" +
+	"\x0ftotal_estimated\x18\a \x01(\bR\x0etotalEstimated\"~\n" +
 	"\fLoginRequest\x12\x14\n" +
 	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1a\n" +
-	"\bpassword\x18\x02 \x01(\tR\bpassword\"b\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x1f\n" +
+	"\vremember_me\x18\x03 \x01(\bR\n" +
+	"rememberMe\x12\x1b\n" +
+	"\tclient_id\x18\x04 \x01(\tR\bclientId\"b\n" +
 	"\rLoginResponse\x12\x12\n" +
 	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12#\n" +
-	"\x04data\x18\x03 \x01(\v2\x0f.user.LoginDataR\x04data\"S\n" +
+	"\x04data\x18\x03 \x01(\v2\x0f.user.LoginDataR\x04data\"\xde\x01\n" +
 	"\tLoginData\x12!\n" +
 	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12#\n" +
-	"\rrefresh_token\x18\x02 \x01(\tR\frefreshToken\",\n" +
+	"\rrefresh_token\x18\x02 \x01(\tR\frefreshToken\x12<\n" +
+	"\x1apassword_rotation_required\x18\x03 \x01(\bR\x18passwordRotationRequired\x12\x1d\n" +
+	"\n" +
+	"token_type\x18\x04 \x01(\tR\ttokenType\x12,\n" +
+	"\x12session_expires_at\x18\x05 \x01(\tR\x10sessionExpiresAt\"\xa3\x01\n" +
 	"\x14ValidateTokenRequest\x12\x14\n" +
-	"\x05token\x18\x01 \x01(\tR\x05token\"r\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x1d\n" +
+	"\n" +
+	"dpop_proof\x18\x02 \x01(\tR\tdpopProof\x12\x1f\n" +
+	"\vhttp_method\x18\x03 \x01(\tR\n" +
+	"httpMethod\x12\x19\n" +
+	"\bhttp_uri\x18\x04 \x01(\tR\ahttpUri\x12\x1a\n" +
+	"\baudience\x18\x05 \x01(\tR\baudience\"r\n" +
 	"\x15ValidateTokenResponse\x12\x12\n" +
 	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12+\n" +
-	"\x04data\x18\x03 \x01(\v2\x17.user.ValidateTokenDataR\x04data\"X\n" +
+	"\x04data\x18\x03 \x01(\v2\x17.user.ValidateTokenDataR\x04data\"\x98\x02\n" +
 	"\x11ValidateTokenData\x12\x14\n" +
 	"\x05valid\x18\x01 \x01(\bR\x05valid\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\x03R\x06userId\x12\x14\n" +
-	"\x05email\x18\x03 \x01(\tR\x05email\"J\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12\x12\n" +
+	"\x04role\x18\x04 \x01(\tR\x04role\x12(\n" +
+	"\x06actors\x18\x05 \x03(\v2\x10.user.TokenActorR\x06actors\x12\x1b\n" +
+	"\tauth_time\x18\x06 \x01(\tR\bauthTime\x12\x10\n" +
+	"\x03amr\x18\a \x03(\tR\x03amr\x12\x1d\n" +
+	"\n" +
+	"token_type\x18\b \x01(\tR\ttokenType\x12\x1a\n" +
+	"\baudience\x18\t \x03(\tR\baudience\x12\x16\n" +
+	"\x06scopes\x18\n" +
+	" \x03(\tR\x06scopes\"j\n" +
+	"\x15ValidateTokensRequest\x122\n" +
+	"\x06tokens\x18\x01 \x03(\v2\x1a.user.ValidateTokenRequestR\x06tokens\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x02 \x01(\tR\trequestId\"t\n" +
+	"\x16ValidateTokensResponse\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12,\n" +
+	"\x04data\x18\x03 \x01(\v2\x18.user.ValidateTokensDataR\x04data\"j\n" +
+	"\x12ValidateTokensData\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x01 \x01(\tR\trequestId\x125\n" +
+	"\aresults\x18\x02 \x03(\v2\x1b.user.TokenValidationResultR\aresults\"\xde\x01\n" +
+	"\x15TokenValidationResult\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x14\n" +
+	"\x05valid\x18\x02 \x01(\bR\x05valid\x12/\n" +
+	"\x06claims\x18\x03 \x01(\v2\x17.user.ValidateTokenDataR\x06claims\x12?\n" +
+	"\x0efailure_reason\x18\x04 \x01(\x0e2\x18.user.TokenFailureReasonR\rfailureReason\x12'\n" +
+	"\x0ffailure_message\x18\x05 \x01(\tR\x0efailureMessage\"<\n" +
+	"\n" +
+	"TokenActor\x12\x18\n" +
+	"\asubject\x18\x01 \x01(\tR\asubject\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\"J\n" +
 	"\rLogoutRequest\x12\x14\n" +
 	"\x05token\x18\x01 \x01(\tR\x05token\x12#\n" +
 	"\rrefresh_token\x18\x02 \x01(\tR\frefreshToken\"d\n" +
@@ -1662,14 +6951,303 @@ const file_proto_user_service_proto_rawDesc = "" +
 	"\x14RefreshTokenResponse\x12\x12\n" +
 	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12*\n" +
-	"\x04data\x18\x03 \x01(\v2\x16.user.RefreshTokenDataR\x04data\"Z\n" +
+	"\x04data\x18\x03 \x01(\v2\x16.user.RefreshTokenDataR\x04data\"\xa7\x01\n" +
 	"\x10RefreshTokenData\x12!\n" +
 	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12#\n" +
-	"\rrefresh_token\x18\x02 \x01(\tR\frefreshToken2\xbe\x04\n" +
+	"\rrefresh_token\x18\x02 \x01(\tR\frefreshToken\x12\x1d\n" +
+	"\n" +
+	"token_type\x18\x03 \x01(\tR\ttokenType\x12,\n" +
+	"\x12session_expires_at\x18\x04 \x01(\tR\x10sessionExpiresAt\"V\n" +
+	"\x16ImpersonateUserRequest\x12$\n" +
+	"\x0etarget_user_id\x18\x01 \x01(\x05R\ftargetUserId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"v\n" +
+	"\x17ImpersonateUserResponse\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12-\n" +
+	"\x04data\x18\x03 \x01(\v2\x19.user.ImpersonateUserDataR\x04data\"}\n" +
+	"\x13ImpersonateUserData\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\tR\texpiresAt\x12$\n" +
+	"\x0etarget_user_id\x18\x03 \x01(\x05R\ftargetUserId\"d\n" +
+	"\x12SuspendUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\tR\texpiresAt\"n\n" +
+	"\x13SuspendUserResponse\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12)\n" +
+	"\x04data\x18\x03 \x01(\v2\x15.user.SuspendUserDataR\x04data\"1\n" +
+	"\x0fSuspendUserData\x12\x1e\n" +
+	"\x04user\x18\x01 \x01(\v2\n" +
+	".user.UserR\x04user\"H\n" +
+	"\x15ReactivateUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"t\n" +
+	"\x16ReactivateUserResponse\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12,\n" +
+	"\x04data\x18\x03 \x01(\v2\x18.user.ReactivateUserDataR\x04data\"4\n" +
+	"\x12ReactivateUserData\x12\x1e\n" +
+	"\x04user\x18\x01 \x01(\v2\n" +
+	".user.UserR\x04user\"E\n" +
+	"\x12DisableUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"n\n" +
+	"\x13DisableUserResponse\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12)\n" +
+	"\x04data\x18\x03 \x01(\v2\x15.user.DisableUserDataR\x04data\"1\n" +
+	"\x0fDisableUserData\x12\x1e\n" +
+	"\x04user\x18\x01 \x01(\v2\n" +
+	".user.UserR\x04user\"7\n" +
+	"\x1dRequestAccountDeletionRequest\x12\x16\n" +
+	"\x06reason\x18\x01 \x01(\tR\x06reason\"\x84\x01\n" +
+	"\x1eRequestAccountDeletionResponse\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x124\n" +
+	"\x04data\x18\x03 \x01(\v2 .user.RequestAccountDeletionDataR\x04data\"P\n" +
+	"\x1aRequestAccountDeletionData\x122\n" +
+	"\x15deletion_scheduled_at\x18\x01 \x01(\tR\x13deletionScheduledAt\"P\n" +
+	"\x1cCancelAccountDeletionRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\"\x82\x01\n" +
+	"\x1dCancelAccountDeletionResponse\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x123\n" +
+	"\x04data\x18\x03 \x01(\v2\x1f.user.CancelAccountDeletionDataR\x04data\";\n" +
+	"\x19CancelAccountDeletionData\x12\x1e\n" +
+	"\x04user\x18\x01 \x01(\v2\n" +
+	".user.UserR\x04user\"0\n" +
+	"\x15ExportUserDataRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\"u\n" +
+	"\x16ExportUserDataResponse\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12-\n" +
+	"\x04data\x18\x03 \x01(\v2\x19.user.ExportUserDataChunkR\x04data\"\xad\x01\n" +
+	"\x13ExportUserDataChunk\x12\x1b\n" +
+	"\tfile_name\x18\x01 \x01(\tR\bfileName\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\fR\acontent\x12\x1a\n" +
+	"\bsequence\x18\x03 \x01(\x05R\bsequence\x12\x1d\n" +
+	"\n" +
+	"last_chunk\x18\x04 \x01(\bR\tlastChunk\x12$\n" +
+	"\x0eend_of_archive\x18\x05 \x01(\bR\fendOfArchive\"H\n" +
+	"\x10ExternalIdentity\x12\x1a\n" +
+	"\bprovider\x18\x01 \x01(\tR\bprovider\x12\x18\n" +
+	"\asubject\x18\x02 \x01(\tR\asubject\"\xa9\x01\n" +
+	"\x11LookupUserRequest\x12\x10\n" +
+	"\x02id\x18\x01 \x01(\x05H\x00R\x02id\x12\x16\n" +
+	"\x05email\x18\x02 \x01(\tH\x00R\x05email\x12\x1c\n" +
+	"\busername\x18\x03 \x01(\tH\x00R\busername\x12E\n" +
+	"\x11external_identity\x18\x04 \x01(\v2\x16.user.ExternalIdentityH\x00R\x10externalIdentityB\x05\n" +
+	"\x03key\"l\n" +
+	"\x12LookupUserResponse\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12(\n" +
+	"\x04data\x18\x03 \x01(\v2\x14.user.LookupUserDataR\x04data\"0\n" +
+	"\x0eLookupUserData\x12\x1e\n" +
+	"\x04user\x18\x01 \x01(\v2\n" +
+	".user.UserR\x04user\"\x82\x01\n" +
+	"\x1bLinkExternalIdentityRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x122\n" +
+	"\bidentity\x18\x02 \x01(\v2\x16.user.ExternalIdentityR\bidentity\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"\x80\x01\n" +
+	"\x1cLinkExternalIdentityResponse\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x122\n" +
+	"\x04data\x18\x03 \x01(\v2\x1e.user.LinkExternalIdentityDataR\x04data\":\n" +
+	"\x18LinkExternalIdentityData\x12\x1e\n" +
+	"\x04user\x18\x01 \x01(\v2\n" +
+	".user.UserR\x04user\"\x84\x01\n" +
+	"\x1dUnlinkExternalIdentityRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x122\n" +
+	"\bidentity\x18\x02 \x01(\v2\x16.user.ExternalIdentityR\bidentity\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"\x84\x01\n" +
+	"\x1eUnlinkExternalIdentityResponse\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x124\n" +
+	"\x04data\x18\x03 \x01(\v2 .user.UnlinkExternalIdentityDataR\x04data\"<\n" +
+	"\x1aUnlinkExternalIdentityData\x12\x1e\n" +
+	"\x04user\x18\x01 \x01(\v2\n" +
+	".user.UserR\x04user\"E\n" +
+	"\x12RestoreUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"n\n" +
+	"\x13RestoreUserResponse\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12)\n" +
+	"\x04data\x18\x03 \x01(\v2\x15.user.RestoreUserDataR\x04data\"1\n" +
+	"\x0fRestoreUserData\x12\x1e\n" +
+	"\x04user\x18\x01 \x01(\v2\n" +
+	".user.UserR\x04user\"J\n" +
+	"\x17ListDeletedUsersRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\"x\n" +
+	"\x18ListDeletedUsersResponse\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12.\n" +
+	"\x04data\x18\x03 \x01(\v2\x1a.user.ListDeletedUsersDataR\x04data\"\x91\x01\n" +
+	"\x14ListDeletedUsersData\x12 \n" +
+	"\x05users\x18\x01 \x03(\v2\n" +
+	".user.UserR\x05users\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x12\n" +
+	"\x04size\x18\x04 \x01(\x05R\x04size\x12\x19\n" +
+	"\bhas_more\x18\x05 \x01(\bR\ahasMore\"G\n" +
+	"\x14AnonymizeUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"r\n" +
+	"\x15AnonymizeUserResponse\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12+\n" +
+	"\x04data\x18\x03 \x01(\v2\x17.user.AnonymizeUserDataR\x04data\"3\n" +
+	"\x11AnonymizeUserData\x12\x1e\n" +
+	"\x04user\x18\x01 \x01(\v2\n" +
+	".user.UserR\x04user\"l\n" +
+	"\x13UploadAvatarRequest\x122\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x14.user.AvatarMetadataH\x00R\bmetadata\x12\x16\n" +
+	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunkB\t\n" +
+	"\apayload\"L\n" +
+	"\x0eAvatarMetadata\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\"p\n" +
+	"\x14UploadAvatarResponse\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12*\n" +
+	"\x04data\x18\x03 \x01(\v2\x16.user.UploadAvatarDataR\x04data\"i\n" +
+	"\x10UploadAvatarData\x12\x1e\n" +
+	"\x04user\x18\x01 \x01(\v2\n" +
+	".user.UserR\x04user\x125\n" +
+	"\n" +
+	"renditions\x18\x02 \x03(\v2\x15.user.AvatarRenditionR\n" +
+	"renditions\"7\n" +
+	"\x0fAvatarRendition\x12\x12\n" +
+	"\x04size\x18\x01 \x01(\x05R\x04size\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\".\n" +
+	"\x13DeleteAvatarRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\"p\n" +
+	"\x14DeleteAvatarResponse\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12*\n" +
+	"\x04data\x18\x03 \x01(\v2\x16.user.DeleteAvatarDataR\x04data\"2\n" +
+	"\x10DeleteAvatarData\x12\x1e\n" +
+	"\x04user\x18\x01 \x01(\v2\n" +
+	".user.UserR\x04user\"8\n" +
+	"\x19RequestEmailChangeRequest\x12\x1b\n" +
+	"\tnew_email\x18\x01 \x01(\tR\bnewEmail\"|\n" +
+	"\x1aRequestEmailChangeResponse\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x120\n" +
+	"\x04data\x18\x03 \x01(\v2\x1c.user.RequestEmailChangeDataR\x04data\"\\\n" +
+	"\x16RequestEmailChangeData\x12#\n" +
+	"\rpending_email\x18\x01 \x01(\tR\fpendingEmail\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\tR\texpiresAt\"1\n" +
+	"\x19ConfirmEmailChangeRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"|\n" +
+	"\x1aConfirmEmailChangeResponse\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x120\n" +
+	"\x04data\x18\x03 \x01(\v2\x1c.user.ConfirmEmailChangeDataR\x04data\"8\n" +
+	"\x16ConfirmEmailChangeData\x12\x1e\n" +
+	"\x04user\x18\x01 \x01(\v2\n" +
+	".user.UserR\x04user\"\x9a\x01\n" +
+	"\x0fAttributeSchema\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\x05R\aversion\x12/\n" +
+	"\x06schema\x18\x02 \x01(\v2\x17.google.protobuf.StructR\x06schema\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x03 \x01(\x05R\tcreatedBy\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\tR\tcreatedAt\"\x1b\n" +
+	"\x19GetAttributeSchemaRequest\"|\n" +
+	"\x1aGetAttributeSchemaResponse\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x120\n" +
+	"\x04data\x18\x03 \x01(\v2\x1c.user.GetAttributeSchemaDataR\x04data\"G\n" +
+	"\x16GetAttributeSchemaData\x12-\n" +
+	"\x06schema\x18\x01 \x01(\v2\x15.user.AttributeSchemaR\x06schema\"L\n" +
+	"\x19SetAttributeSchemaRequest\x12/\n" +
+	"\x06schema\x18\x01 \x01(\v2\x17.google.protobuf.StructR\x06schema\"|\n" +
+	"\x1aSetAttributeSchemaResponse\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x120\n" +
+	"\x04data\x18\x03 \x01(\v2\x1c.user.SetAttributeSchemaDataR\x04data\"G\n" +
+	"\x16SetAttributeSchemaData\x12-\n" +
+	"\x06schema\x18\x01 \x01(\v2\x15.user.AttributeSchemaR\x06schema\"\xa8\x01\n" +
+	"\x14ExchangeTokenRequest\x12#\n" +
+	"\rsubject_token\x18\x01 \x01(\tR\fsubjectToken\x12\x1a\n" +
+	"\baudience\x18\x02 \x01(\tR\baudience\x12\x16\n" +
+	"\x06scopes\x18\x03 \x03(\tR\x06scopes\x12\x1d\n" +
+	"\n" +
+	"expires_in\x18\x04 \x01(\x05R\texpiresIn\x12\x18\n" +
+	"\x05actor\x18\x05 \x01(\tB\x02\x18\x01R\x05actor\"r\n" +
+	"\x15ExchangeTokenResponse\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12+\n" +
+	"\x04data\x18\x03 \x01(\v2\x17.user.ExchangeTokenDataR\x04data\"\xd4\x01\n" +
+	"\x11ExchangeTokenData\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12*\n" +
+	"\x11issued_token_type\x18\x02 \x01(\tR\x0fissuedTokenType\x12\x1d\n" +
+	"\n" +
+	"token_type\x18\x03 \x01(\tR\ttokenType\x12\x1d\n" +
+	"\n" +
+	"expires_in\x18\x04 \x01(\x05R\texpiresIn\x12\x1a\n" +
+	"\baudience\x18\x05 \x01(\tR\baudience\x12\x16\n" +
+	"\x06scopes\x18\x06 \x03(\tR\x06scopes\"\xf2\x01\n" +
+	"\n" +
+	"LoginEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x05R\x06userId\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x03 \x01(\tR\teventType\x12\x18\n" +
+	"\aoutcome\x18\x04 \x01(\tR\aoutcome\x12%\n" +
+	"\x0efailure_reason\x18\x05 \x01(\tR\rfailureReason\x12\x1d\n" +
+	"\n" +
+	"ip_address\x18\x06 \x01(\tR\tipAddress\x12\x1d\n" +
+	"\n" +
+	"user_agent\x18\a \x01(\tR\tuserAgent\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\b \x01(\tR\tcreatedAt\"b\n" +
+	"\x16ListLoginEventsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\"v\n" +
+	"\x17ListLoginEventsResponse\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12-\n" +
+	"\x04data\x18\x03 \x01(\v2\x19.user.ListLoginEventsDataR\x04data\"\x98\x01\n" +
+	"\x13ListLoginEventsData\x12(\n" +
+	"\x06events\x18\x01 \x03(\v2\x10.user.LoginEventR\x06events\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x12\n" +
+	"\x04size\x18\x04 \x01(\x05R\x04size\x12\x19\n" +
+	"\bhas_more\x18\x05 \x01(\bR\ahasMore*\x8f\x01\n" +
+	"\n" +
+	"UserStatus\x12\x1b\n" +
+	"\x17USER_STATUS_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12USER_STATUS_ACTIVE\x10\x01\x12\x19\n" +
+	"\x15USER_STATUS_SUSPENDED\x10\x02\x12\x18\n" +
+	"\x14USER_STATUS_DISABLED\x10\x03\x12\x17\n" +
+	"\x13USER_STATUS_PENDING\x10\x04*\x99\x03\n" +
+	"\x12TokenFailureReason\x12$\n" +
+	" TOKEN_FAILURE_REASON_UNSPECIFIED\x10\x00\x12\x1e\n" +
+	"\x1aTOKEN_FAILURE_REASON_EMPTY\x10\x01\x12\"\n" +
+	"\x1eTOKEN_FAILURE_REASON_MALFORMED\x10\x02\x12 \n" +
+	"\x1cTOKEN_FAILURE_REASON_EXPIRED\x10\x03\x12 \n" +
+	"\x1cTOKEN_FAILURE_REASON_REVOKED\x10\x04\x12*\n" +
+	"&TOKEN_FAILURE_REASON_INVALID_SIGNATURE\x10\x05\x12)\n" +
+	"%TOKEN_FAILURE_REASON_WRONG_TOKEN_TYPE\x10\x06\x12*\n" +
+	"&TOKEN_FAILURE_REASON_AUDIENCE_MISMATCH\x10\a\x12+\n" +
+	"'TOKEN_FAILURE_REASON_DPOP_PROOF_INVALID\x10\b\x12%\n" +
+	"!TOKEN_FAILURE_REASON_USER_REVOKED\x10\t2\x87\x15\n" +
 	"\vUserService\x12?\n" +
 	"\n" +
 	"CreateUser\x12\x17.user.CreateUserRequest\x1a\x18.user.CreateUserResponse\x126\n" +
-	"\aGetUser\x12\x14.user.GetUserRequest\x1a\x15.user.GetUserResponse\x12?\n" +
+	"\aGetUser\x12\x14.user.GetUserRequest\x1a\x15.user.GetUserResponse\x12H\n" +
+	"\rBatchGetUsers\x12\x1a.user.BatchGetUsersRequest\x1a\x1b.user.BatchGetUsersResponse\x12T\n" +
+	"\x11GetUserByUsername\x12\x1e.user.GetUserByUsernameRequest\x1a\x1f.user.GetUserByUsernameResponse\x12T\n" +
+	"\x11CheckAvailability\x12\x1e.user.CheckAvailabilityRequest\x1a\x1f.user.CheckAvailabilityResponse\x12?\n" +
 	"\n" +
 	"UpdateUser\x12\x17.user.UpdateUserRequest\x1a\x18.user.UpdateUserResponse\x12?\n" +
 	"\n" +
@@ -1677,8 +7255,32 @@ const file_proto_user_service_proto_rawDesc = "" +
 	"\tListUsers\x12\x16.user.ListUsersRequest\x1a\x17.user.ListUsersResponse\x120\n" +
 	"\x05Login\x12\x12.user.LoginRequest\x1a\x13.user.LoginResponse\x12E\n" +
 	"\fRefreshToken\x12\x19.user.RefreshTokenRequest\x1a\x1a.user.RefreshTokenResponse\x12H\n" +
-	"\rValidateToken\x12\x1a.user.ValidateTokenRequest\x1a\x1b.user.ValidateTokenResponse\x123\n" +
-	"\x06Logout\x12\x13.user.LogoutRequest\x1a\x14.user.LogoutResponseB+Z)github.com/thatlq1812/agrios-shared/protob\x06proto3"
+	"\rValidateToken\x12\x1a.user.ValidateTokenRequest\x1a\x1b.user.ValidateTokenResponse\x12K\n" +
+	"\x0eValidateTokens\x12\x1b.user.ValidateTokensRequest\x1a\x1c.user.ValidateTokensResponse\x12U\n" +
+	"\x14StreamValidateTokens\x12\x1b.user.ValidateTokensRequest\x1a\x1c.user.ValidateTokensResponse(\x010\x01\x123\n" +
+	"\x06Logout\x12\x13.user.LogoutRequest\x1a\x14.user.LogoutResponse\x12H\n" +
+	"\rExchangeToken\x12\x1a.user.ExchangeTokenRequest\x1a\x1b.user.ExchangeTokenResponse\x12N\n" +
+	"\x0fListLoginEvents\x12\x1c.user.ListLoginEventsRequest\x1a\x1d.user.ListLoginEventsResponse\x12c\n" +
+	"\x16RequestAccountDeletion\x12#.user.RequestAccountDeletionRequest\x1a$.user.RequestAccountDeletionResponse\x12`\n" +
+	"\x15CancelAccountDeletion\x12\".user.CancelAccountDeletionRequest\x1a#.user.CancelAccountDeletionResponse\x12M\n" +
+	"\x0eExportUserData\x12\x1b.user.ExportUserDataRequest\x1a\x1c.user.ExportUserDataResponse0\x01\x12W\n" +
+	"\x12GetAttributeSchema\x12\x1f.user.GetAttributeSchemaRequest\x1a .user.GetAttributeSchemaResponse\x12G\n" +
+	"\fUploadAvatar\x12\x19.user.UploadAvatarRequest\x1a\x1a.user.UploadAvatarResponse(\x01\x12E\n" +
+	"\fDeleteAvatar\x12\x19.user.DeleteAvatarRequest\x1a\x1a.user.DeleteAvatarResponse\x12W\n" +
+	"\x12RequestEmailChange\x12\x1f.user.RequestEmailChangeRequest\x1a .user.RequestEmailChangeResponse\x12W\n" +
+	"\x12ConfirmEmailChange\x12\x1f.user.ConfirmEmailChangeRequest\x1a .user.ConfirmEmailChangeResponse\x12N\n" +
+	"\x0fImpersonateUser\x12\x1c.user.ImpersonateUserRequest\x1a\x1d.user.ImpersonateUserResponse\x12B\n" +
+	"\vSuspendUser\x12\x18.user.SuspendUserRequest\x1a\x19.user.SuspendUserResponse\x12K\n" +
+	"\x0eReactivateUser\x12\x1b.user.ReactivateUserRequest\x1a\x1c.user.ReactivateUserResponse\x12B\n" +
+	"\vDisableUser\x12\x18.user.DisableUserRequest\x1a\x19.user.DisableUserResponse\x12B\n" +
+	"\vRestoreUser\x12\x18.user.RestoreUserRequest\x1a\x19.user.RestoreUserResponse\x12Q\n" +
+	"\x10ListDeletedUsers\x12\x1d.user.ListDeletedUsersRequest\x1a\x1e.user.ListDeletedUsersResponse\x12H\n" +
+	"\rAnonymizeUser\x12\x1a.user.AnonymizeUserRequest\x1a\x1b.user.AnonymizeUserResponse\x12W\n" +
+	"\x12SetAttributeSchema\x12\x1f.user.SetAttributeSchemaRequest\x1a .user.SetAttributeSchemaResponse\x12?\n" +
+	"\n" +
+	"LookupUser\x12\x17.user.LookupUserRequest\x1a\x18.user.LookupUserResponse\x12]\n" +
+	"\x14LinkExternalIdentity\x12!.user.LinkExternalIdentityRequest\x1a\".user.LinkExternalIdentityResponse\x12c\n" +
+	"\x16UnlinkExternalIdentity\x12#.user.UnlinkExternalIdentityRequest\x1a$.user.UnlinkExternalIdentityResponseB+Z)github.com/thatlq1812/agrios-shared/protob\x06proto3"
 
 var (
 	file_proto_user_service_proto_rawDescOnce sync.Once
@@ -1692,74 +7294,274 @@ func file_proto_user_service_proto_rawDescGZIP() []byte {
 	return file_proto_user_service_proto_rawDescData
 }
 
-var file_proto_user_service_proto_msgTypes = make([]protoimpl.MessageInfo, 28)
+var file_proto_user_service_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_proto_user_service_proto_msgTypes = make([]protoimpl.MessageInfo, 110)
 var file_proto_user_service_proto_goTypes = []any{
-	(*User)(nil),                  // 0: user.User
-	(*CreateUserRequest)(nil),     // 1: user.CreateUserRequest
-	(*CreateUserResponse)(nil),    // 2: user.CreateUserResponse
-	(*CreateUserData)(nil),        // 3: user.CreateUserData
-	(*GetUserRequest)(nil),        // 4: user.GetUserRequest
-	(*GetUserResponse)(nil),       // 5: user.GetUserResponse
-	(*GetUserData)(nil),           // 6: user.GetUserData
-	(*UpdateUserRequest)(nil),     // 7: user.UpdateUserRequest
-	(*UpdateUserResponse)(nil),    // 8: user.UpdateUserResponse
-	(*UpdateUserData)(nil),        // 9: user.UpdateUserData
-	(*DeleteUserRequest)(nil),     // 10: user.DeleteUserRequest
-	(*DeleteUserResponse)(nil),    // 11: user.DeleteUserResponse
-	(*DeleteUserData)(nil),        // 12: user.DeleteUserData
-	(*ListUsersRequest)(nil),      // 13: user.ListUsersRequest
-	(*ListUsersResponse)(nil),     // 14: user.ListUsersResponse
-	(*ListUsersData)(nil),         // 15: user.ListUsersData
-	(*LoginRequest)(nil),          // 16: user.LoginRequest
-	(*LoginResponse)(nil),         // 17: user.LoginResponse
-	(*LoginData)(nil),             // 18: user.LoginData
-	(*ValidateTokenRequest)(nil),  // 19: user.ValidateTokenRequest
-	(*ValidateTokenResponse)(nil), // 20: user.ValidateTokenResponse
-	(*ValidateTokenData)(nil),     // 21: user.ValidateTokenData
-	(*LogoutRequest)(nil),         // 22: user.LogoutRequest
-	(*LogoutResponse)(nil),        // 23: user.LogoutResponse
-	(*LogoutData)(nil),            // 24: user.LogoutData
-	(*RefreshTokenRequest)(nil),   // 25: user.RefreshTokenRequest
-	(*RefreshTokenResponse)(nil),  // 26: user.RefreshTokenResponse
-	(*RefreshTokenData)(nil),      // 27: user.RefreshTokenData
+	(UserStatus)(0),                        // 0: user.UserStatus
+	(TokenFailureReason)(0),                // 1: user.TokenFailureReason
+	(*User)(nil),                           // 2: user.User
+	(*CreateUserRequest)(nil),              // 3: user.CreateUserRequest
+	(*CreateUserResponse)(nil),             // 4: user.CreateUserResponse
+	(*CreateUserData)(nil),                 // 5: user.CreateUserData
+	(*GetUserRequest)(nil),                 // 6: user.GetUserRequest
+	(*GetUserResponse)(nil),                // 7: user.GetUserResponse
+	(*GetUserData)(nil),                    // 8: user.GetUserData
+	(*BatchGetUsersRequest)(nil),           // 9: user.BatchGetUsersRequest
+	(*BatchGetUsersResponse)(nil),          // 10: user.BatchGetUsersResponse
+	(*BatchGetUsersData)(nil),              // 11: user.BatchGetUsersData
+	(*GetUserByUsernameRequest)(nil),       // 12: user.GetUserByUsernameRequest
+	(*GetUserByUsernameResponse)(nil),      // 13: user.GetUserByUsernameResponse
+	(*GetUserByUsernameData)(nil),          // 14: user.GetUserByUsernameData
+	(*CheckAvailabilityRequest)(nil),       // 15: user.CheckAvailabilityRequest
+	(*CheckAvailabilityResponse)(nil),      // 16: user.CheckAvailabilityResponse
+	(*CheckAvailabilityData)(nil),          // 17: user.CheckAvailabilityData
+	(*UpdateUserRequest)(nil),              // 18: user.UpdateUserRequest
+	(*UpdateUserResponse)(nil),             // 19: user.UpdateUserResponse
+	(*UpdateUserData)(nil),                 // 20: user.UpdateUserData
+	(*DeleteUserRequest)(nil),              // 21: user.DeleteUserRequest
+	(*DeleteUserResponse)(nil),             // 22: user.DeleteUserResponse
+	(*DeleteUserData)(nil),                 // 23: user.DeleteUserData
+	(*ListUsersRequest)(nil),               // 24: user.ListUsersRequest
+	(*ListUsersResponse)(nil),              // 25: user.ListUsersResponse
+	(*ListUsersData)(nil),                  // 26: user.ListUsersData
+	(*LoginRequest)(nil),                   // 27: user.LoginRequest
+	(*LoginResponse)(nil),                  // 28: user.LoginResponse
+	(*LoginData)(nil),                      // 29: user.LoginData
+	(*ValidateTokenRequest)(nil),           // 30: user.ValidateTokenRequest
+	(*ValidateTokenResponse)(nil),          // 31: user.ValidateTokenResponse
+	(*ValidateTokenData)(nil),              // 32: user.ValidateTokenData
+	(*ValidateTokensRequest)(nil),          // 33: user.ValidateTokensRequest
+	(*ValidateTokensResponse)(nil),         // 34: user.ValidateTokensResponse
+	(*ValidateTokensData)(nil),             // 35: user.ValidateTokensData
+	(*TokenValidationResult)(nil),          // 36: user.TokenValidationResult
+	(*TokenActor)(nil),                     // 37: user.TokenActor
+	(*LogoutRequest)(nil),                  // 38: user.LogoutRequest
+	(*LogoutResponse)(nil),                 // 39: user.LogoutResponse
+	(*LogoutData)(nil),                     // 40: user.LogoutData
+	(*RefreshTokenRequest)(nil),            // 41: user.RefreshTokenRequest
+	(*RefreshTokenResponse)(nil),           // 42: user.RefreshTokenResponse
+	(*RefreshTokenData)(nil),               // 43: user.RefreshTokenData
+	(*ImpersonateUserRequest)(nil),         // 44: user.ImpersonateUserRequest
+	(*ImpersonateUserResponse)(nil),        // 45: user.ImpersonateUserResponse
+	(*ImpersonateUserData)(nil),            // 46: user.ImpersonateUserData
+	(*SuspendUserRequest)(nil),             // 47: user.SuspendUserRequest
+	(*SuspendUserResponse)(nil),            // 48: user.SuspendUserResponse
+	(*SuspendUserData)(nil),                // 49: user.SuspendUserData
+	(*ReactivateUserRequest)(nil),          // 50: user.ReactivateUserRequest
+	(*ReactivateUserResponse)(nil),         // 51: user.ReactivateUserResponse
+	(*ReactivateUserData)(nil),             // 52: user.ReactivateUserData
+	(*DisableUserRequest)(nil),             // 53: user.DisableUserRequest
+	(*DisableUserResponse)(nil),            // 54: user.DisableUserResponse
+	(*DisableUserData)(nil),                // 55: user.DisableUserData
+	(*RequestAccountDeletionRequest)(nil),  // 56: user.RequestAccountDeletionRequest
+	(*RequestAccountDeletionResponse)(nil), // 57: user.RequestAccountDeletionResponse
+	(*RequestAccountDeletionData)(nil),     // 58: user.RequestAccountDeletionData
+	(*CancelAccountDeletionRequest)(nil),   // 59: user.CancelAccountDeletionRequest
+	(*CancelAccountDeletionResponse)(nil),  // 60: user.CancelAccountDeletionResponse
+	(*CancelAccountDeletionData)(nil),      // 61: user.CancelAccountDeletionData
+	(*ExportUserDataRequest)(nil),          // 62: user.ExportUserDataRequest
+	(*ExportUserDataResponse)(nil),         // 63: user.ExportUserDataResponse
+	(*ExportUserDataChunk)(nil),            // 64: user.ExportUserDataChunk
+	(*ExternalIdentity)(nil),               // 65: user.ExternalIdentity
+	(*LookupUserRequest)(nil),              // 66: user.LookupUserRequest
+	(*LookupUserResponse)(nil),             // 67: user.LookupUserResponse
+	(*LookupUserData)(nil),                 // 68: user.LookupUserData
+	(*LinkExternalIdentityRequest)(nil),    // 69: user.LinkExternalIdentityRequest
+	(*LinkExternalIdentityResponse)(nil),   // 70: user.LinkExternalIdentityResponse
+	(*LinkExternalIdentityData)(nil),       // 71: user.LinkExternalIdentityData
+	(*UnlinkExternalIdentityRequest)(nil),  // 72: user.UnlinkExternalIdentityRequest
+	(*UnlinkExternalIdentityResponse)(nil), // 73: user.UnlinkExternalIdentityResponse
+	(*UnlinkExternalIdentityData)(nil),     // 74: user.UnlinkExternalIdentityData
+	(*RestoreUserRequest)(nil),             // 75: user.RestoreUserRequest
+	(*RestoreUserResponse)(nil),            // 76: user.RestoreUserResponse
+	(*RestoreUserData)(nil),                // 77: user.RestoreUserData
+	(*ListDeletedUsersRequest)(nil),        // 78: user.ListDeletedUsersRequest
+	(*ListDeletedUsersResponse)(nil),       // 79: user.ListDeletedUsersResponse
+	(*ListDeletedUsersData)(nil),           // 80: user.ListDeletedUsersData
+	(*AnonymizeUserRequest)(nil),           // 81: user.AnonymizeUserRequest
+	(*AnonymizeUserResponse)(nil),          // 82: user.AnonymizeUserResponse
+	(*AnonymizeUserData)(nil),              // 83: user.AnonymizeUserData
+	(*UploadAvatarRequest)(nil),            // 84: user.UploadAvatarRequest
+	(*AvatarMetadata)(nil),                 // 85: user.AvatarMetadata
+	(*UploadAvatarResponse)(nil),           // 86: user.UploadAvatarResponse
+	(*UploadAvatarData)(nil),               // 87: user.UploadAvatarData
+	(*AvatarRendition)(nil),                // 88: user.AvatarRendition
+	(*DeleteAvatarRequest)(nil),            // 89: user.DeleteAvatarRequest
+	(*DeleteAvatarResponse)(nil),           // 90: user.DeleteAvatarResponse
+	(*DeleteAvatarData)(nil),               // 91: user.DeleteAvatarData
+	(*RequestEmailChangeRequest)(nil),      // 92: user.RequestEmailChangeRequest
+	(*RequestEmailChangeResponse)(nil),     // 93: user.RequestEmailChangeResponse
+	(*RequestEmailChangeData)(nil),         // 94: user.RequestEmailChangeData
+	(*ConfirmEmailChangeRequest)(nil),      // 95: user.ConfirmEmailChangeRequest
+	(*ConfirmEmailChangeResponse)(nil),     // 96: user.ConfirmEmailChangeResponse
+	(*ConfirmEmailChangeData)(nil),         // 97: user.ConfirmEmailChangeData
+	(*AttributeSchema)(nil),                // 98: user.AttributeSchema
+	(*GetAttributeSchemaRequest)(nil),      // 99: user.GetAttributeSchemaRequest
+	(*GetAttributeSchemaResponse)(nil),     // 100: user.GetAttributeSchemaResponse
+	(*GetAttributeSchemaData)(nil),         // 101: user.GetAttributeSchemaData
+	(*SetAttributeSchemaRequest)(nil),      // 102: user.SetAttributeSchemaRequest
+	(*SetAttributeSchemaResponse)(nil),     // 103: user.SetAttributeSchemaResponse
+	(*SetAttributeSchemaData)(nil),         // 104: user.SetAttributeSchemaData
+	(*ExchangeTokenRequest)(nil),           // 105: user.ExchangeTokenRequest
+	(*ExchangeTokenResponse)(nil),          // 106: user.ExchangeTokenResponse
+	(*ExchangeTokenData)(nil),              // 107: user.ExchangeTokenData
+	(*LoginEvent)(nil),                     // 108: user.LoginEvent
+	(*ListLoginEventsRequest)(nil),         // 109: user.ListLoginEventsRequest
+	(*ListLoginEventsResponse)(nil),        // 110: user.ListLoginEventsResponse
+	(*ListLoginEventsData)(nil),            // 111: user.ListLoginEventsData
+	(*structpb.Struct)(nil),                // 112: google.protobuf.Struct
+	(*fieldmaskpb.FieldMask)(nil),          // 113: google.protobuf.FieldMask
 }
 var file_proto_user_service_proto_depIdxs = []int32{
-	3,  // 0: user.CreateUserResponse.data:type_name -> user.CreateUserData
-	0,  // 1: user.CreateUserData.user:type_name -> user.User
-	6,  // 2: user.GetUserResponse.data:type_name -> user.GetUserData
-	0,  // 3: user.GetUserData.user:type_name -> user.User
-	9,  // 4: user.UpdateUserResponse.data:type_name -> user.UpdateUserData
-	0,  // 5: user.UpdateUserData.user:type_name -> user.User
-	12, // 6: user.DeleteUserResponse.data:type_name -> user.DeleteUserData
-	15, // 7: user.ListUsersResponse.data:type_name -> user.ListUsersData
-	0,  // 8: user.ListUsersData.users:type_name -> user.User
-	18, // 9: user.LoginResponse.data:type_name -> user.LoginData
-	21, // 10: user.ValidateTokenResponse.data:type_name -> user.ValidateTokenData
-	24, // 11: user.LogoutResponse.data:type_name -> user.LogoutData
-	27, // 12: user.RefreshTokenResponse.data:type_name -> user.RefreshTokenData
-	1,  // 13: user.UserService.CreateUser:input_type -> user.CreateUserRequest
-	4,  // 14: user.UserService.GetUser:input_type -> user.GetUserRequest
-	7,  // 15: user.UserService.UpdateUser:input_type -> user.UpdateUserRequest
-	10, // 16: user.UserService.DeleteUser:input_type -> user.DeleteUserRequest
-	13, // 17: user.UserService.ListUsers:input_type -> user.ListUsersRequest
-	16, // 18: user.UserService.Login:input_type -> user.LoginRequest
-	25, // 19: user.UserService.RefreshToken:input_type -> user.RefreshTokenRequest
-	19, // 20: user.UserService.ValidateToken:input_type -> user.ValidateTokenRequest
-	22, // 21: user.UserService.Logout:input_type -> user.LogoutRequest
-	2,  // 22: user.UserService.CreateUser:output_type -> user.CreateUserResponse
-	5,  // 23: user.UserService.GetUser:output_type -> user.GetUserResponse
-	8,  // 24: user.UserService.UpdateUser:output_type -> user.UpdateUserResponse
-	11, // 25: user.UserService.DeleteUser:output_type -> user.DeleteUserResponse
-	14, // 26: user.UserService.ListUsers:output_type -> user.ListUsersResponse
-	17, // 27: user.UserService.Login:output_type -> user.LoginResponse
-	26, // 28: user.UserService.RefreshToken:output_type -> user.RefreshTokenResponse
-	20, // 29: user.UserService.ValidateToken:output_type -> user.ValidateTokenResponse
-	23, // 30: user.UserService.Logout:output_type -> user.LogoutResponse
-	22, // [22:31] is the sub-list for method output_type
-	13, // [13:22] is the sub-list for method input_type
-	13, // [13:13] is the sub-list for extension type_name
-	13, // [13:13] is the sub-list for extension extendee
-	0,  // [0:13] is the sub-list for field type_name
+	0,   // 0: user.User.status:type_name -> user.UserStatus
+	112, // 1: user.User.attributes:type_name -> google.protobuf.Struct
+	5,   // 2: user.CreateUserResponse.data:type_name -> user.CreateUserData
+	2,   // 3: user.CreateUserData.user:type_name -> user.User
+	8,   // 4: user.GetUserResponse.data:type_name -> user.GetUserData
+	2,   // 5: user.GetUserData.user:type_name -> user.User
+	113, // 6: user.BatchGetUsersRequest.field_mask:type_name -> google.protobuf.FieldMask
+	11,  // 7: user.BatchGetUsersResponse.data:type_name -> user.BatchGetUsersData
+	2,   // 8: user.BatchGetUsersData.users:type_name -> user.User
+	14,  // 9: user.GetUserByUsernameResponse.data:type_name -> user.GetUserByUsernameData
+	2,   // 10: user.GetUserByUsernameData.user:type_name -> user.User
+	17,  // 11: user.CheckAvailabilityResponse.data:type_name -> user.CheckAvailabilityData
+	112, // 12: user.UpdateUserRequest.attributes:type_name -> google.protobuf.Struct
+	20,  // 13: user.UpdateUserResponse.data:type_name -> user.UpdateUserData
+	2,   // 14: user.UpdateUserData.user:type_name -> user.User
+	23,  // 15: user.DeleteUserResponse.data:type_name -> user.DeleteUserData
+	112, // 16: user.ListUsersRequest.attributes:type_name -> google.protobuf.Struct
+	0,   // 17: user.ListUsersRequest.status:type_name -> user.UserStatus
+	26,  // 18: user.ListUsersResponse.data:type_name -> user.ListUsersData
+	2,   // 19: user.ListUsersData.users:type_name -> user.User
+	29,  // 20: user.LoginResponse.data:type_name -> user.LoginData
+	32,  // 21: user.ValidateTokenResponse.data:type_name -> user.ValidateTokenData
+	37,  // 22: user.ValidateTokenData.actors:type_name -> user.TokenActor
+	30,  // 23: user.ValidateTokensRequest.tokens:type_name -> user.ValidateTokenRequest
+	35,  // 24: user.ValidateTokensResponse.data:type_name -> user.ValidateTokensData
+	36,  // 25: user.ValidateTokensData.results:type_name -> user.TokenValidationResult
+	32,  // 26: user.TokenValidationResult.claims:type_name -> user.ValidateTokenData
+	1,   // 27: user.TokenValidationResult.failure_reason:type_name -> user.TokenFailureReason
+	40,  // 28: user.LogoutResponse.data:type_name -> user.LogoutData
+	43,  // 29: user.RefreshTokenResponse.data:type_name -> user.RefreshTokenData
+	46,  // 30: user.ImpersonateUserResponse.data:type_name -> user.ImpersonateUserData
+	49,  // 31: user.SuspendUserResponse.data:type_name -> user.SuspendUserData
+	2,   // 32: user.SuspendUserData.user:type_name -> user.User
+	52,  // 33: user.ReactivateUserResponse.data:type_name -> user.ReactivateUserData
+	2,   // 34: user.ReactivateUserData.user:type_name -> user.User
+	55,  // 35: user.DisableUserResponse.data:type_name -> user.DisableUserData
+	2,   // 36: user.DisableUserData.user:type_name -> user.User
+	58,  // 37: user.RequestAccountDeletionResponse.data:type_name -> user.RequestAccountDeletionData
+	61,  // 38: user.CancelAccountDeletionResponse.data:type_name -> user.CancelAccountDeletionData
+	2,   // 39: user.CancelAccountDeletionData.user:type_name -> user.User
+	64,  // 40: user.ExportUserDataResponse.data:type_name -> user.ExportUserDataChunk
+	65,  // 41: user.LookupUserRequest.external_identity:type_name -> user.ExternalIdentity
+	68,  // 42: user.LookupUserResponse.data:type_name -> user.LookupUserData
+	2,   // 43: user.LookupUserData.user:type_name -> user.User
+	65,  // 44: user.LinkExternalIdentityRequest.identity:type_name -> user.ExternalIdentity
+	71,  // 45: user.LinkExternalIdentityResponse.data:type_name -> user.LinkExternalIdentityData
+	2,   // 46: user.LinkExternalIdentityData.user:type_name -> user.User
+	65,  // 47: user.UnlinkExternalIdentityRequest.identity:type_name -> user.ExternalIdentity
+	74,  // 48: user.UnlinkExternalIdentityResponse.data:type_name -> user.UnlinkExternalIdentityData
+	2,   // 49: user.UnlinkExternalIdentityData.user:type_name -> user.User
+	77,  // 50: user.RestoreUserResponse.data:type_name -> user.RestoreUserData
+	2,   // 51: user.RestoreUserData.user:type_name -> user.User
+	80,  // 52: user.ListDeletedUsersResponse.data:type_name -> user.ListDeletedUsersData
+	2,   // 53: user.ListDeletedUsersData.users:type_name -> user.User
+	83,  // 54: user.AnonymizeUserResponse.data:type_name -> user.AnonymizeUserData
+	2,   // 55: user.AnonymizeUserData.user:type_name -> user.User
+	85,  // 56: user.UploadAvatarRequest.metadata:type_name -> user.AvatarMetadata
+	87,  // 57: user.UploadAvatarResponse.data:type_name -> user.UploadAvatarData
+	2,   // 58: user.UploadAvatarData.user:type_name -> user.User
+	88,  // 59: user.UploadAvatarData.renditions:type_name -> user.AvatarRendition
+	91,  // 60: user.DeleteAvatarResponse.data:type_name -> user.DeleteAvatarData
+	2,   // 61: user.DeleteAvatarData.user:type_name -> user.User
+	94,  // 62: user.RequestEmailChangeResponse.data:type_name -> user.RequestEmailChangeData
+	97,  // 63: user.ConfirmEmailChangeResponse.data:type_name -> user.ConfirmEmailChangeData
+	2,   // 64: user.ConfirmEmailChangeData.user:type_name -> user.User
+	112, // 65: user.AttributeSchema.schema:type_name -> google.protobuf.Struct
+	101, // 66: user.GetAttributeSchemaResponse.data:type_name -> user.GetAttributeSchemaData
+	98,  // 67: user.GetAttributeSchemaData.schema:type_name -> user.AttributeSchema
+	112, // 68: user.SetAttributeSchemaRequest.schema:type_name -> google.protobuf.Struct
+	104, // 69: user.SetAttributeSchemaResponse.data:type_name -> user.SetAttributeSchemaData
+	98,  // 70: user.SetAttributeSchemaData.schema:type_name -> user.AttributeSchema
+	107, // 71: user.ExchangeTokenResponse.data:type_name -> user.ExchangeTokenData
+	111, // 72: user.ListLoginEventsResponse.data:type_name -> user.ListLoginEventsData
+	108, // 73: user.ListLoginEventsData.events:type_name -> user.LoginEvent
+	3,   // 74: user.UserService.CreateUser:input_type -> user.CreateUserRequest
+	6,   // 75: user.UserService.GetUser:input_type -> user.GetUserRequest
+	9,   // 76: user.UserService.BatchGetUsers:input_type -> user.BatchGetUsersRequest
+	12,  // 77: user.UserService.GetUserByUsername:input_type -> user.GetUserByUsernameRequest
+	15,  // 78: user.UserService.CheckAvailability:input_type -> user.CheckAvailabilityRequest
+	18,  // 79: user.UserService.UpdateUser:input_type -> user.UpdateUserRequest
+	21,  // 80: user.UserService.DeleteUser:input_type -> user.DeleteUserRequest
+	24,  // 81: user.UserService.ListUsers:input_type -> user.ListUsersRequest
+	27,  // 82: user.UserService.Login:input_type -> user.LoginRequest
+	41,  // 83: user.UserService.RefreshToken:input_type -> user.RefreshTokenRequest
+	30,  // 84: user.UserService.ValidateToken:input_type -> user.ValidateTokenRequest
+	33,  // 85: user.UserService.ValidateTokens:input_type -> user.ValidateTokensRequest
+	33,  // 86: user.UserService.StreamValidateTokens:input_type -> user.ValidateTokensRequest
+	38,  // 87: user.UserService.Logout:input_type -> user.LogoutRequest
+	105, // 88: user.UserService.ExchangeToken:input_type -> user.ExchangeTokenRequest
+	109, // 89: user.UserService.ListLoginEvents:input_type -> user.ListLoginEventsRequest
+	56,  // 90: user.UserService.RequestAccountDeletion:input_type -> user.RequestAccountDeletionRequest
+	59,  // 91: user.UserService.CancelAccountDeletion:input_type -> user.CancelAccountDeletionRequest
+	62,  // 92: user.UserService.ExportUserData:input_type -> user.ExportUserDataRequest
+	99,  // 93: user.UserService.GetAttributeSchema:input_type -> user.GetAttributeSchemaRequest
+	84,  // 94: user.UserService.UploadAvatar:input_type -> user.UploadAvatarRequest
+	89,  // 95: user.UserService.DeleteAvatar:input_type -> user.DeleteAvatarRequest
+	92,  // 96: user.UserService.RequestEmailChange:input_type -> user.RequestEmailChangeRequest
+	95,  // 97: user.UserService.ConfirmEmailChange:input_type -> user.ConfirmEmailChangeRequest
+	44,  // 98: user.UserService.ImpersonateUser:input_type -> user.ImpersonateUserRequest
+	47,  // 99: user.UserService.SuspendUser:input_type -> user.SuspendUserRequest
+	50,  // 100: user.UserService.ReactivateUser:input_type -> user.ReactivateUserRequest
+	53,  // 101: user.UserService.DisableUser:input_type -> user.DisableUserRequest
+	75,  // 102: user.UserService.RestoreUser:input_type -> user.RestoreUserRequest
+	78,  // 103: user.UserService.ListDeletedUsers:input_type -> user.ListDeletedUsersRequest
+	81,  // 104: user.UserService.AnonymizeUser:input_type -> user.AnonymizeUserRequest
+	102, // 105: user.UserService.SetAttributeSchema:input_type -> user.SetAttributeSchemaRequest
+	66,  // 106: user.UserService.LookupUser:input_type -> user.LookupUserRequest
+	69,  // 107: user.UserService.LinkExternalIdentity:input_type -> user.LinkExternalIdentityRequest
+	72,  // 108: user.UserService.UnlinkExternalIdentity:input_type -> user.UnlinkExternalIdentityRequest
+	4,   // 109: user.UserService.CreateUser:output_type -> user.CreateUserResponse
+	7,   // 110: user.UserService.GetUser:output_type -> user.GetUserResponse
+	10,  // 111: user.UserService.BatchGetUsers:output_type -> user.BatchGetUsersResponse
+	13,  // 112: user.UserService.GetUserByUsername:output_type -> user.GetUserByUsernameResponse
+	16,  // 113: user.UserService.CheckAvailability:output_type -> user.CheckAvailabilityResponse
+	19,  // 114: user.UserService.UpdateUser:output_type -> user.UpdateUserResponse
+	22,  // 115: user.UserService.DeleteUser:output_type -> user.DeleteUserResponse
+	25,  // 116: user.UserService.ListUsers:output_type -> user.ListUsersResponse
+	28,  // 117: user.UserService.Login:output_type -> user.LoginResponse
+	42,  // 118: user.UserService.RefreshToken:output_type -> user.RefreshTokenResponse
+	31,  // 119: user.UserService.ValidateToken:output_type -> user.ValidateTokenResponse
+	34,  // 120: user.UserService.ValidateTokens:output_type -> user.ValidateTokensResponse
+	34,  // 121: user.UserService.StreamValidateTokens:output_type -> user.ValidateTokensResponse
+	39,  // 122: user.UserService.Logout:output_type -> user.LogoutResponse
+	106, // 123: user.UserService.ExchangeToken:output_type -> user.ExchangeTokenResponse
+	110, // 124: user.UserService.ListLoginEvents:output_type -> user.ListLoginEventsResponse
+	57,  // 125: user.UserService.RequestAccountDeletion:output_type -> user.RequestAccountDeletionResponse
+	60,  // 126: user.UserService.CancelAccountDeletion:output_type -> user.CancelAccountDeletionResponse
+	63,  // 127: user.UserService.ExportUserData:output_type -> user.ExportUserDataResponse
+	100, // 128: user.UserService.GetAttributeSchema:output_type -> user.GetAttributeSchemaResponse
+	86,  // 129: user.UserService.UploadAvatar:output_type -> user.UploadAvatarResponse
+	90,  // 130: user.UserService.DeleteAvatar:output_type -> user.DeleteAvatarResponse
+	93,  // 131: user.UserService.RequestEmailChange:output_type -> user.RequestEmailChangeResponse
+	96,  // 132: user.UserService.ConfirmEmailChange:output_type -> user.ConfirmEmailChangeResponse
+	45,  // 133: user.UserService.ImpersonateUser:output_type -> user.ImpersonateUserResponse
+	48,  // 134: user.UserService.SuspendUser:output_type -> user.SuspendUserResponse
+	51,  // 135: user.UserService.ReactivateUser:output_type -> user.ReactivateUserResponse
+	54,  // 136: user.UserService.DisableUser:output_type -> user.DisableUserResponse
+	76,  // 137: user.UserService.RestoreUser:output_type -> user.RestoreUserResponse
+	79,  // 138: user.UserService.ListDeletedUsers:output_type -> user.ListDeletedUsersResponse
+	82,  // 139: user.UserService.AnonymizeUser:output_type -> user.AnonymizeUserResponse
+	103, // 140: user.UserService.SetAttributeSchema:output_type -> user.SetAttributeSchemaResponse
+	67,  // 141: user.UserService.LookupUser:output_type -> user.LookupUserResponse
+	70,  // 142: user.UserService.LinkExternalIdentity:output_type -> user.LinkExternalIdentityResponse
+	73,  // 143: user.UserService.UnlinkExternalIdentity:output_type -> user.UnlinkExternalIdentityResponse
+	109, // [109:144] is the sub-list for method output_type
+	74,  // [74:109] is the sub-list for method input_type
+	74,  // [74:74] is the sub-list for extension type_name
+	74,  // [74:74] is the sub-list for extension extendee
+	0,   // [0:74] is the sub-list for field type_name
 }
 
 func init() { file_proto_user_service_proto_init() }
@@ -1767,19 +7569,35 @@ func file_proto_user_service_proto_init() {
 	if File_proto_user_service_proto != nil {
 		return
 	}
-	file_proto_user_service_proto_msgTypes[7].OneofWrappers = []any{}
+	file_proto_user_service_proto_msgTypes[13].OneofWrappers = []any{
+		(*CheckAvailabilityRequest_Email)(nil),
+		(*CheckAvailabilityRequest_Username)(nil),
+	}
+	file_proto_user_service_proto_msgTypes[16].OneofWrappers = []any{}
+	file_proto_user_service_proto_msgTypes[22].OneofWrappers = []any{}
+	file_proto_user_service_proto_msgTypes[64].OneofWrappers = []any{
+		(*LookupUserRequest_Id)(nil),
+		(*LookupUserRequest_Email)(nil),
+		(*LookupUserRequest_Username)(nil),
+		(*LookupUserRequest_ExternalIdentity)(nil),
+	}
+	file_proto_user_service_proto_msgTypes[82].OneofWrappers = []any{
+		(*UploadAvatarRequest_Metadata)(nil),
+		(*UploadAvatarRequest_Chunk)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_user_service_proto_rawDesc), len(file_proto_user_service_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   28,
+			NumEnums:      2,
+			NumMessages:   110,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_proto_user_service_proto_goTypes,
 		DependencyIndexes: file_proto_user_service_proto_depIdxs,
+		EnumInfos:         file_proto_user_service_proto_enumTypes,
 		MessageInfos:      file_proto_user_service_proto_msgTypes,
 	}.Build()
 	File_proto_user_service_proto = out.File
@@ -32,7 +32,9 @@ read -p "   Create database? (y/n) " -n 1 -r
 echo
 if [[ $REPLY =~ ^[Yy]$ ]]; then
     psql -U postgres -c "CREATE DATABASE agrios_users;" 2>/dev/null || echo "   Database already exists"
-    psql -U postgres -d agrios_users -f migrations/001_create_users_table.sql
+    for migration in migrations/*.sql; do
+        psql -U postgres -d agrios_users -f "$migration"
+    done
     echo "✅ Database setup complete"
 else
     echo "⏭️  Skipping database setup"